@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/appdir"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/sealed"
+	"yescode-tui/internal/snapshot"
+)
+
+// runApply implements `yc apply FILE`, bringing the selections and balance
+// preference in line with a desired-state file.
+func runApply(args []string) {
+	fs := flag.NewFlagSet("yc apply", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	var (
+		dryRun = fs.Bool("dry-run", false, i18n.T("只显示将要执行的变更，不实际应用"))
+		yes    = fs.Bool("yes", false, i18n.T("跳过确认提示直接应用"))
+		asJSON = registerJSONFlag(fs)
+	)
+	encrypt := registerEncryptFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("用法: yc apply [选项] <state.yaml|state.json>"))
+		fs.PrintDefaults()
+	}
+	conn.parse(fs, args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		exitf("打开状态文件失败: %v", err)
+	}
+	desired, err := snapshot.Decode(f)
+	f.Close()
+	if err != nil {
+		exitf("解析状态文件失败: %v", err)
+	}
+
+	box, err := encrypt.stateBox()
+	if err != nil {
+		exitf("读取加密密钥失败: %v", err)
+	}
+	ctx := context.Background()
+	client := conn.newClient()
+	live, err := snapshot.Capture(ctx, client)
+	if err != nil {
+		exitf("获取账户状态失败: %v", err)
+	}
+	converge(ctx, client, desired, live, convergeOptions{dryRun: *dryRun, yes: *yes, json: *asJSON, box: box})
+}
+
+// runRollback implements `yc rollback`, restoring the rollback point saved
+// before the last batch operation.
+func runRollback(args []string) {
+	fs := flag.NewFlagSet("yc rollback", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	var (
+		dryRun = fs.Bool("dry-run", false, i18n.T("只显示将要执行的变更，不实际应用"))
+		yes    = fs.Bool("yes", false, i18n.T("跳过确认提示直接应用"))
+		asJSON = registerJSONFlag(fs)
+	)
+	encrypt := registerEncryptFlag(fs)
+	conn.parse(fs, args)
+
+	box, err := encrypt.stateBox()
+	if err != nil {
+		exitf("读取加密密钥失败: %v", err)
+	}
+	ctx := context.Background()
+	client := conn.newClient()
+	live, err := snapshot.Capture(ctx, client)
+	if err != nil {
+		exitf("获取账户状态失败: %v", err)
+	}
+	store, err := rollbackStore(box)
+	if err != nil {
+		exitf("定位回滚点失败: %v", err)
+	}
+	saved, err := store.Load(live.Account.Key())
+	if errors.Is(err, snapshot.ErrNoRollback) {
+		exitf("没有可用的回滚点")
+	}
+	if err != nil {
+		exitf("读取回滚点失败: %v", err)
+	}
+	opts := convergeOptions{dryRun: *dryRun, yes: *yes, json: *asJSON, box: box}
+	fmt.Fprintf(opts.messages(), i18n.T("回滚点：%s\n"), store.Path(live.Account.Key()))
+	converge(ctx, client, saved, live, opts)
+}
+
+type convergeOptions struct {
+	dryRun bool
+	yes    bool
+	json   bool
+	box    *sealed.Box // 加密回滚点，未开启 --encrypt-state 时为 nil
+}
+
+// messages is where progress text goes; with --json stdout carries only JSON.
+func (o convergeOptions) messages() io.Writer {
+	if o.json {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// convergeResult is the --json output of apply and rollback.
+type convergeResult struct {
+	Plan    *snapshot.Plan `json:"plan"`
+	Applied bool           `json:"applied"`
+	Steps   []convergeStep `json:"steps,omitempty"`
+}
+
+type convergeStep struct {
+	Step  string `json:"step"`
+	Error string `json:"error,omitempty"`
+}
+
+// converge prints the plan from live to desired and, once confirmed, saves a
+// rollback point and applies it.
+func converge(ctx context.Context, client *api.Client, desired, live *snapshot.Snapshot, opts convergeOptions) {
+	plan, err := snapshot.NewPlan(ctx, client, desired, live)
+	if err != nil {
+		exitf("生成变更计划失败: %v", err)
+	}
+	out := opts.messages()
+	result := convergeResult{Plan: plan}
+	if opts.json {
+		defer func() { printJSON(result) }()
+	}
+	if plan.Empty() {
+		fmt.Fprintln(out, i18n.T("当前状态已与文件一致，无需变更"))
+		return
+	}
+
+	fmt.Fprintln(out, i18n.T("将执行以下变更："))
+	if p := plan.Preference; p != nil {
+		fmt.Fprintf(out, "  ~ balance_preference: %s → %s\n", p.From, p.To)
+	}
+	for _, s := range plan.Switches {
+		fmt.Fprintf(out, "  ~ %s\n", s)
+	}
+	if opts.dryRun {
+		return
+	}
+	if !opts.yes && !confirm(out, i18n.T("确认应用？[y/N] ")) {
+		fmt.Fprintln(out, i18n.T("已取消"))
+		return
+	}
+
+	store, err := rollbackStore(opts.box)
+	if err == nil {
+		err = store.Save(live.Account.Key(), live)
+	}
+	if err != nil {
+		exitf("保存回滚点失败: %v", err)
+	}
+
+	fmt.Fprintln(out)
+	result.Applied = true
+	err = plan.Apply(ctx, client, func(step string, err error) {
+		if err != nil {
+			result.Steps = append(result.Steps, convergeStep{Step: step, Error: err.Error()})
+			fmt.Fprintf(out, "  ✗ %s: %v\n", step, err)
+			return
+		}
+		result.Steps = append(result.Steps, convergeStep{Step: step})
+		fmt.Fprintf(out, "  ✓ %s\n", step)
+	})
+	if err != nil {
+		if opts.json {
+			printJSON(result)
+		}
+		exitf("\n部分变更应用失败，可使用 yc rollback 恢复")
+	}
+	fmt.Fprintln(out, i18n.T("\n已保存回滚点，可使用 yc rollback 恢复"))
+}
+
+// rollbackStore opens the rollback points in the application directory,
+// sealed with box when --encrypt-state is on.
+func rollbackStore(box *sealed.Box) (*snapshot.RollbackStore, error) {
+	dir, err := appdir.Path("rollback")
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.NewSealedRollbackStore(dir, box), nil
+}
+
+// confirm asks a yes/no question on stdin; anything but y/yes means no.
+func confirm(out io.Writer, prompt string) bool {
+	fmt.Fprint(out, prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"yescode-tui/internal/calendar"
+	"yescode-tui/internal/i18n"
+)
+
+// runCalendar implements `yc calendar`, exporting renewal and reset events as iCalendar.
+func runCalendar(args []string) {
+	fs := flag.NewFlagSet("yc calendar", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	var (
+		output     = fs.String("o", "", i18n.T("输出 .ics 文件路径（默认输出到标准输出）"))
+		remindDays = fs.Int("remind-days", 3, i18n.T("订阅到期前提前提醒的天数"))
+	)
+	conn.parse(fs, args)
+
+	client := conn.newClient()
+	profile, err := client.GetProfile(context.Background())
+	if err != nil {
+		exitf("获取用户资料失败: %v", err)
+	}
+
+	ics := calendar.Build(profile, calendar.Options{RemindDays: *remindDays})
+	if *output == "" {
+		fmt.Print(ics)
+		return
+	}
+	if err := os.WriteFile(*output, []byte(ics), 0o644); err != nil {
+		exitf("写入日历文件失败: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, i18n.T("已写入 %s\n"), *output)
+}
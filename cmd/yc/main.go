@@ -4,48 +4,577 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"runtime/debug"
+	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"yescode-tui/internal/alert"
 	"yescode-tui/internal/api"
+	"yescode-tui/internal/appdir"
+	"yescode-tui/internal/config"
+	"yescode-tui/internal/debuglog"
+	"yescode-tui/internal/demo"
+	"yescode-tui/internal/eventlog"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/notify"
+	"yescode-tui/internal/peer"
 	"yescode-tui/internal/tui"
 )
 
 func main() {
+	// 先按环境变量选择语言，使 -h 的说明也能翻译；--lang 和配置文件在解析参数后生效
+	i18n.SetLang("")
+	args := os.Args[1:]
+	if len(args) > 0 {
+		switch args[0] {
+		case "calendar":
+			runCalendar(args[1:])
+			return
+		case "reconcile":
+			runReconcile(args[1:])
+			return
+		case "snapshot":
+			runSnapshot(args[1:])
+			return
+		case "apply":
+			runApply(args[1:])
+			return
+		case "rollback":
+			runRollback(args[1:])
+			return
+		case "profile":
+			runProfile(args[1:])
+			return
+		case "providers":
+			runProviders(args[1:])
+			return
+		case "selection":
+			runSelection(args[1:])
+			return
+		case "selftest":
+			runSelftest(args[1:])
+			return
+		case "decrypt":
+			runDecrypt(args[1:])
+			return
+		}
+	}
+	runTUI(args)
+}
+
+// runTUI launches the interactive interface.
+func runTUI(args []string) {
+	fs := flag.NewFlagSet("yc", flag.ExitOnError)
+	conn := registerClientFlags(fs)
 	var (
-		apiKeyFlag = flag.String("api-key", "", "YesCode API Key（可使用环境变量 YESCODE_API_KEY）")
-		baseURL    = flag.String("base-url", "", "自定义 API Base URL（默认 https://co.yes.vg）")
+		alertRules = fs.String("alerts", "", i18n.T("告警规则，逗号分隔（例如 balance<5,week_pct>=90）"))
+		anomaly    = fs.Float64("anomaly-ratio", tui.DefaultAnomalyRatio, i18n.T("今日消费达到近 14 天日均的多少倍时在用户资料页顶部提示异常（0 表示不提示）"))
+		notifyLang = fs.String("notify-lang", "", i18n.T("通知消息语言（zh / en），默认跟随 --lang"))
+		lowBW      = fs.Bool("low-bandwidth", false, i18n.T("省流模式：延长自动刷新间隔、不预取提供商详情"))
+		focusInd   = fs.String("focus-indicator", "marker", i18n.T("焦点面板的额外提示，逗号分隔：marker（[焦点] 标记）、inverse（反色光标行）或 none"))
+		theme      = fs.String("theme", tui.ThemeDefault, i18n.T("界面主题（dark / light / solarized / dracula / high-contrast，或配置文件中 [themes.NAME] 定义的自定义主题）"))
+		noColor    = fs.Bool("no-color", false, i18n.T("不使用颜色（设置环境变量 NO_COLOR 时同样生效）"))
+		icons      = fs.String("icons", tui.IconsUnicode, i18n.T("图标集（unicode / nerd / ascii），nerd 需要终端使用 Nerd Font"))
+		density    = fs.String("density", string(tui.DensityCompact), i18n.T("列表密度：compact（单行）或 comfortable（在每行下方显示说明）"))
+		chrome     = fs.String("chrome", string(tui.ChromeFull), i18n.T("顶部布局：full（标题、提示和标签页各占一行）、minimal（合并为一行）或 auto（终端不足 30 行时使用 minimal）"))
+		order      = fs.String("provider-order", "", i18n.T("固定的提供商顺序，逗号分隔的 ID 或名称（例如 3,Claude），未列出的按 API 顺序排在后面"))
+		planFile   = fs.String("plan", "", i18n.T("期望状态文件（yc snapshot 的输出或手写），启动后在“计划”界面中选择要应用的变更"))
+		refresh    = fs.Duration("refresh-interval", 0, i18n.T("用户资料自动刷新间隔（0 表示默认：5s，省流模式下 60s）"))
+		trend      = fs.Duration("trend-window", 6*time.Hour, i18n.T("用户资料页消费趋势图覆盖的时间范围"))
+		idleLock   = fs.Duration("idle-lock", 0, i18n.T("无操作多久后锁定屏幕并暂停刷新（例如 10m，0 表示不锁定）"))
+		lockPIN    = fs.String("lock-pin", "", i18n.T("解锁屏幕所需的 PIN（也可设置环境变量 YESCODE_LOCK_PIN），不设置时按任意键即可解锁"))
+		confirm    = fs.Bool("confirm-quit", false, i18n.T("退出前弹出“确定退出？”确认框"))
+		debugMode  = fs.Bool("debug", false, i18n.T("记录每个 API 请求的方法、路径、状态码和耗时到日志文件，并可按 Ctrl+L 查看"))
+		debugBody  = fs.Bool("debug-bodies", false, i18n.T("调试日志同时记录请求和响应内容（密钥、令牌和邮箱等字段会被移除），需配合 --debug"))
+		debugPath  = fs.String("debug-log", "", i18n.T("调试日志文件路径（默认为配置目录下的 debug.log，超过 1 MiB 时轮转，保留 3 个旧文件）"))
+		stream     = fs.Bool("stream", false, i18n.T("订阅服务端事件流（实验性，该接口不在公开的 API 文档中），连接期间暂停轮询用户资料；默认始终定时轮询"))
+		noCache    = fs.Bool("no-cache", false, i18n.T("不在内存中缓存提供商列表和方案（默认缓存 10–30 秒，切换方案或按 r 刷新时清空）"))
+		debugUI    = fs.Bool("debug-ui", false, i18n.T("记录界面处理的每条消息（按键、鼠标、窗口大小、加载结果）到配置目录下的 events.log，并可按 Ctrl+E 查看"))
+		demoMode   = fs.Bool("demo", false, i18n.T("演示模式：使用内置的示例数据运行，不需要 API Key，也不读写本地记录"))
+		script     = fs.String("script", "", i18n.T("从文件或命名管道逐行读取按键脚本驱动界面（忽略键盘输入，脚本结束时退出），用于录制演示和端到端测试"))
+		storage    = registerStoreFlags(fs)
 	)
-	flag.Parse()
+	conn.parse(fs, args)
 
-	apiKey := strings.TrimSpace(*apiKeyFlag)
-	if apiKey == "" {
-		apiKey = strings.TrimSpace(os.Getenv("YESCODE_API_KEY"))
+	for name, palette := range conn.themes {
+		if err := tui.RegisterTheme(name, palette); err != nil {
+			exitf("配置无效: %v", err)
+		}
+	}
+	if err := tui.ApplyTheme(*theme); err != nil {
+		exitf("--theme 无效: %v", err)
+	}
+	if *noColor || os.Getenv("NO_COLOR") != "" {
+		tui.DisableColor()
+	}
+	if err := tui.ApplyIcons(*icons); err != nil {
+		exitf("--icons 无效: %v", err)
+	}
+
+	marker, inverse, err := parseFocusIndicators(*focusInd)
+	if err != nil {
+		exitf("--focus-indicator 无效: %v", err)
+	}
+
+	var clientOpts []api.Option
+	var modelOpts []tui.Option
+	rowDensity, err := tui.ParseDensity(*density)
+	if err != nil {
+		exitf("--density 无效: %v", err)
+	}
+	chromeMode, err := tui.ParseChrome(*chrome)
+	if err != nil {
+		exitf("--chrome 无效: %v", err)
+	}
+	modelOpts = append(modelOpts, tui.WithFocusIndicators(marker, inverse), tui.WithDensity(rowDensity), tui.WithChrome(chromeMode))
+	if spec := strings.TrimSpace(*order); spec != "" {
+		modelOpts = append(modelOpts, tui.WithProviderOrder(strings.Split(spec, ",")))
+	}
+	if *refresh < 0 {
+		exitf("--refresh-interval 不能为负数")
+	}
+	if path := strings.TrimSpace(*planFile); path != "" {
+		modelOpts = append(modelOpts, tui.WithPlanFile(path))
+	}
+	if *refresh > 0 {
+		modelOpts = append(modelOpts, tui.WithRefreshInterval(*refresh))
+	}
+	if *trend <= 0 {
+		exitf("--trend-window 必须大于 0")
+	}
+	modelOpts = append(modelOpts, tui.WithTrendWindow(*trend))
+	if *idleLock > 0 {
+		modelOpts = append(modelOpts, tui.WithIdleLock(*idleLock, *lockPIN))
+	}
+	if *confirm {
+		modelOpts = append(modelOpts, tui.WithConfirmQuit())
+	}
+	// 用户资料每 5 秒轮询一次，条件请求让未变化的数据只换回一个 304
+	clientOpts = append(clientOpts, api.WithConditionalRequests())
+	if !*noCache {
+		clientOpts = append(clientOpts, api.WithResponseCache(api.DefaultCacheTTLs()))
+	}
+	if *lowBW {
+		modelOpts = append(modelOpts, tui.WithLowBandwidth())
+	}
+	if *stream {
+		modelOpts = append(modelOpts, tui.WithStream())
+	}
+	modelOpts = append(modelOpts, tui.WithAnomalyRatio(*anomaly))
+	box, err := storage.stateBox()
+	if err != nil {
+		exitf("读取加密密钥失败: %v", err)
+	}
+	var requestLog *debuglog.Log
+	if *debugMode {
+		path := *debugPath
+		if path == "" {
+			if path, err = appdir.Path("debug.log"); err != nil {
+				exitf("无法确定调试日志路径: %v", err)
+			}
+		}
+		if requestLog, err = debuglog.OpenSealed(path, *debugBody, box); err != nil {
+			exitf("打开调试日志失败: %v", err)
+		}
+		clientOpts = append(clientOpts, api.WithTracer(requestLog))
+		modelOpts = append(modelOpts, tui.WithDebugLog(requestLog))
+	}
+	var eventLog *eventlog.Log
+	if *debugUI {
+		path, err := appdir.Path("events.log")
+		if err != nil {
+			exitf("无法确定事件日志路径: %v", err)
+		}
+		if eventLog, err = eventlog.OpenSealed(path, box); err != nil {
+			exitf("打开事件日志失败: %v", err)
+		}
+		modelOpts = append(modelOpts, tui.WithEventLog(eventLog))
+	}
+	var client api.Service
+	if *demoMode {
+		// 演示数据和消费记录都在内存中，不会改动真实账户的本地记录
+		fake, samples := demo.New(time.Now())
+		client = fake
+		modelOpts = append(modelOpts, tui.WithHistory(samples))
+	} else {
+		client = conn.newClient(clientOpts...)
+		var others []tui.Account
+		for _, account := range conn.accounts {
+			if account.Name != *conn.account {
+				others = append(others, tui.Account{Name: account.Name, Client: conn.forAccount(account).newClient(clientOpts...)})
+			}
+		}
+		modelOpts = append(modelOpts, tui.WithAccounts(*conn.account, others))
+
+		local, err := openLocalState(storage)
+		if err != nil {
+			exitf("打开本地记录失败: %v", err)
+		}
+		defer local.Close()
+		modelOpts = append(modelOpts, tui.WithHistory(local.history), tui.WithRecent(local.recent))
+		if path, err := appdir.Path("instances.json"); err == nil {
+			board, err := peer.OpenSealed(path, box, requestLog)
+			if err != nil {
+				exitf("读取实例协调文件失败: %v", err)
+			}
+			modelOpts = append(modelOpts, tui.WithPeers(board))
+		}
+		if store, err := rollbackStore(box); err == nil {
+			modelOpts = append(modelOpts, tui.WithRollback(store))
+		}
+	}
+	if spec := strings.TrimSpace(*alertRules); spec != "" {
+		rules, err := alert.ParseRules(spec)
+		if err != nil {
+			exitf("告警规则无效: %v", err)
+		}
+		lang := *notifyLang
+		if lang == "" {
+			// 跟随界面语言，zh-CN / en-US 取语言部分
+			lang, _, _ = strings.Cut(i18n.Lang(), "-")
+		}
+		dispatcher, err := buildNotifier(lang)
+		if err != nil {
+			exitf("初始化通知渠道失败: %v", err)
+		}
+		if dispatcher.Empty() {
+			exitf("已配置告警规则但没有可用的通知渠道，请设置 YESCODE_TELEGRAM_BOT_TOKEN 或 YESCODE_DINGTALK_TOKEN")
+		}
+		modelOpts = append(modelOpts, tui.WithAlerts(alert.NewEngine(rules), dispatcher))
+	}
+
+	modelOpts = append(modelOpts, tui.WithDiagnosticBundle(tui.BundleInfo{
+		Version: buildVersion(),
+		Config:  bundleConfig(fs, conn.accounts),
+	}))
+
+	model := tui.NewModel(client, modelOpts...)
+	programOpts := []tea.ProgramOption{
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(), // 启用鼠标支持
+	}
+	if *script != "" {
+		// 脚本是唯一的输入来源，保证每次运行的结果相同
+		programOpts = append(programOpts, tea.WithInput(nil))
+	}
+	program := tea.NewProgram(model, programOpts...)
+	var scriptErr <-chan error
+	if *script != "" {
+		scriptErr = runScript(program, *script)
+	}
+	_, err = program.Run()
+	// 无论如何退出，都先写完本地状态
+	model.Shutdown()
+	if requestLog != nil {
+		requestLog.Close()
+	}
+	if eventLog != nil {
+		eventLog.Close()
+	}
+	if err != nil {
+		exitf("程序运行失败: %v", err)
+	}
+	select {
+	case err := <-scriptErr:
+		exitf("脚本 %s 执行失败: %v", *script, err)
+	default:
+	}
+}
+
+// clientFlags holds the connection flags shared by every command.
+type clientFlags struct {
+	apiKey      *string
+	baseURL     *string
+	retryStatus *string
+	retryReads  *int
+	retryWrites *int
+	retryDelay  *time.Duration
+	retryMax    *time.Duration
+	timeout     *time.Duration
+	attemptTO   *time.Duration
+	connectTo   *string
+	dns         *string
+	http3       *bool
+	proxy       *string
+	caCert      *string
+	clientCert  *string
+	clientKey   *string
+	faults      *string
+	config      *string
+	account     *string
+	lang        *string
+	locale      *string
+	clock       *string
+
+	// accounts holds the named accounts from the config file.
+	accounts []config.Account
+	// themes holds the custom [themes.NAME] palettes from the config file.
+	themes map[string]map[string]string
+}
+
+func registerClientFlags(fs *flag.FlagSet) *clientFlags {
+	return &clientFlags{
+		apiKey:      fs.String("api-key", "", i18n.T("YesCode API Key（可使用环境变量 YESCODE_API_KEY）")),
+		baseURL:     fs.String("base-url", "", i18n.T("自定义 API Base URL（默认 https://co.yes.vg）")),
+		retryStatus: fs.String("retry-status", "502,503,504", i18n.T("需要重试的 HTTP 状态码，逗号分隔（网络错误总会重试）")),
+		retryReads:  fs.Int("retry-reads", 2, i18n.T("读取类请求（GET）的最大尝试次数")),
+		retryWrites: fs.Int("retry-writes", 1, i18n.T("写入类请求（PUT）的最大尝试次数")),
+		retryDelay:  fs.Duration("retry-backoff", 300*time.Millisecond, i18n.T("第一次重试前的等待时间，之后每次重试翻倍（另加 ±50% 随机抖动）")),
+		retryMax:    fs.Duration("retry-max-delay", 5*time.Second, i18n.T("两次重试之间的最长等待时间（0 表示不限制）")),
+		timeout:     fs.Duration("timeout", 30*time.Second, i18n.T("每次 API 调用的总时限，包括重试和重试前的等待")),
+		attemptTO:   fs.Duration("attempt-timeout", 10*time.Second, i18n.T("单次 HTTP 请求（含读取响应）的时限，超时后按重试策略重试")),
+		connectTo:   fs.String("connect-to", "", i18n.T("直接连接的地址（IP 或 IP:端口），Host/SNI 仍使用 API 域名")),
+		dns:         fs.String("dns", "", i18n.T("自定义 DNS 服务器（例如 223.5.5.5），替代系统解析")),
+		proxy:       fs.String("proxy", "", i18n.T("代理地址，支持 http://、https://、socks5:// 和 socks5h://（默认读取 HTTPS_PROXY、HTTP_PROXY、ALL_PROXY 和 NO_PROXY 环境变量）")),
+		caCert:      fs.String("ca-cert", "", i18n.T("额外信任的 CA 证书文件（PEM），用于使用私有 CA 的自建或企业网关端点")),
+		clientCert:  fs.String("client-cert", "", i18n.T("双向 TLS 的客户端证书文件（PEM），需配合 --client-key")),
+		clientKey:   fs.String("client-key", "", i18n.T("双向 TLS 的客户端私钥文件（PEM），需配合 --client-cert")),
+		faults:      fs.String("inject-faults", "", i18n.T("调试用：随机注入故障以测试错误处理，例如 timeout=0.1,500=0.2,slow=0.3,delay=5s（概率 0–1）")),
+		http3:       fs.Bool("http3", false, i18n.T("实验性：优先使用 HTTP/3 (QUIC)，不可用时自动回退到 HTTP/1.1/2")),
+		config:      fs.String("config", "", i18n.T("配置文件路径（默认 ~/.config/yescode-tui/config.toml，可使用环境变量 YESCODE_CONFIG）")),
+		account:     fs.String("account", "", i18n.T("使用配置文件中 [accounts.NAME] 定义的账户")),
+		lang:        fs.String("lang", "auto", i18n.T("界面语言（zh / en），auto 表示按 LC_ALL、LC_MESSAGES 或 LANG 环境变量检测；同时决定服务端错误信息的语言")),
+		locale:      fs.String("locale", "", i18n.Tf("金额与日期的格式（%s），默认跟随 --lang", strings.Join(format.Locales(), " / "))),
+		clock:       fs.String("clock", format.ClockAuto, i18n.T("时间显示为 12 或 24 小时制（auto 表示按 --locale：en-US 为 12 小时制，其余为 24 小时制）")),
+	}
+}
+
+// parse parses args, then fills every flag not given on the command line
+// from the environment and the config file.
+func (f *clientFlags) parse(fs *flag.FlagSet, args []string) {
+	fs.Parse(args)
+	// 配置文件也可能设置语言和 locale，因此在所有来源合并之后再应用
+	defer func() {
+		if err := i18n.SetLang(*f.lang); err != nil {
+			exitf("--lang 无效: %v", err)
+		}
+		locale := *f.locale
+		if locale == "" {
+			locale = i18n.Lang()
+		}
+		if err := format.SetLocale(locale); err != nil {
+			exitf("--locale 无效: %v", err)
+		}
+		if err := format.SetClock(*f.clock); err != nil {
+			exitf("--clock 无效: %v", err)
+		}
+	}()
+
+	path, required := strings.TrimSpace(*f.config), true
+	if path == "" {
+		path = strings.TrimSpace(os.Getenv("YESCODE_CONFIG"))
 	}
+	if path == "" {
+		var err error
+		if path, err = appdir.Path("config.toml"); err != nil {
+			return
+		}
+		required = false
+	}
+	file, err := config.Load(path, required)
+	if err != nil {
+		exitf("读取配置文件失败: %v", err)
+	}
+	explicit := make(map[string]bool)
+	fs.Visit(func(fl *flag.Flag) { explicit[fl.Name] = true })
+	if err := config.Apply(fs, file.Values); err != nil {
+		exitf("配置无效: %v", err)
+	}
+	f.accounts = file.Accounts
+	f.themes = file.Themes
+
+	// 未配置默认 API Key 时使用第一个命名账户
+	name := strings.TrimSpace(*f.account)
+	if name == "" && strings.TrimSpace(*f.apiKey) == "" && len(file.Accounts) > 0 {
+		name = file.Accounts[0].Name
+	}
+	if name == "" {
+		return
+	}
+	account, ok := file.Account(name)
+	if !ok {
+		exitf("--account 无效: 配置文件中没有账户 %q", name)
+	}
+	*f.account = name
+	// 命令行显式给出的参数仍然优先
+	if !explicit["api-key"] {
+		*f.apiKey = account.APIKey
+	}
+	if !explicit["base-url"] {
+		*f.baseURL = account.BaseURL
+	}
+}
+
+// forAccount returns a copy of f that connects with the account's key and URL.
+func (f *clientFlags) forAccount(account config.Account) *clientFlags {
+	c := *f
+	c.apiKey = &account.APIKey
+	c.baseURL = &account.BaseURL
+	return &c
+}
+
+// newClient resolves the API key and builds the client with the flag options
+// followed by extra, exiting on failure.
+func (f *clientFlags) newClient(extra ...api.Option) *api.Client {
+	apiKey := strings.TrimSpace(*f.apiKey)
 	if apiKey == "" {
-		fmt.Fprintln(os.Stderr, "缺少 API Key，请使用 --api-key 或设置环境变量 YESCODE_API_KEY")
-		os.Exit(1)
+		exitf("缺少 API Key，请使用 --api-key、环境变量 YESCODE_API_KEY 或配置文件中的 api_key")
 	}
 
 	var opts []api.Option
-	if custom := strings.TrimSpace(*baseURL); custom != "" {
+	if custom := strings.TrimSpace(*f.baseURL); custom != "" {
 		opts = append(opts, api.WithBaseURL(custom))
 	}
 
+	if addr := strings.TrimSpace(*f.connectTo); addr != "" {
+		opts = append(opts, api.WithConnectAddress(addr))
+	}
+	if server := strings.TrimSpace(*f.dns); server != "" {
+		opts = append(opts, api.WithResolver(server))
+	}
+
+	if *f.http3 {
+		opts = append(opts, api.WithHTTP3())
+	}
+	if proxy := strings.TrimSpace(*f.proxy); proxy != "" {
+		opts = append(opts, api.WithProxy(proxy))
+	}
+	tlsConfig, err := api.TLSConfigFromFiles(strings.TrimSpace(*f.caCert), strings.TrimSpace(*f.clientCert), strings.TrimSpace(*f.clientKey))
+	if err != nil {
+		exitf("TLS 证书配置无效: %v", err)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, api.WithTLSConfig(tlsConfig))
+	}
+	if spec := strings.TrimSpace(*f.faults); spec != "" {
+		faults, err := api.ParseFaults(spec)
+		if err != nil {
+			exitf("--inject-faults 无效: %v", err)
+		}
+		opts = append(opts, api.WithFaultInjection(faults))
+	}
+	opts = append(opts, api.WithLanguage(i18n.Lang()))
+
+	if *f.timeout <= 0 || *f.attemptTO <= 0 {
+		exitf("--timeout 和 --attempt-timeout 必须大于 0")
+	}
+	opts = append(opts, api.WithTimeout(*f.timeout), api.WithAttemptTimeout(*f.attemptTO))
+
+	policy := api.DefaultRetryPolicy()
+	policy.MaxAttempts[api.ClassRead] = *f.retryReads
+	policy.MaxAttempts[api.ClassWrite] = *f.retryWrites
+	if *f.retryDelay < 0 || *f.retryMax < 0 {
+		exitf("--retry-backoff 和 --retry-max-delay 不能为负数")
+	}
+	policy.Delay = *f.retryDelay
+	policy.MaxDelay = *f.retryMax
+	statuses, err := parseStatusList(*f.retryStatus)
+	if err != nil {
+		exitf("--retry-status 无效: %v", err)
+	}
+	policy.RetryStatus = statuses
+	opts = append(opts, api.WithRetryPolicy(policy))
+	opts = append(opts, extra...)
+
 	client, err := api.NewClient(apiKey, opts...)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "初始化 API 客户端失败: %v\n", err)
-		os.Exit(1)
+		exitf("初始化 API 客户端失败: %v", err)
 	}
+	return client
+}
 
-	program := tea.NewProgram(
-		tui.NewModel(client),
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(), // 启用鼠标支持
-	)
-	if err := program.Start(); err != nil {
-		fmt.Fprintf(os.Stderr, "程序运行失败: %v\n", err)
-		os.Exit(1)
+// parseStatusList parses a comma-separated list of HTTP status codes.
+func parseStatusList(spec string) (map[int]bool, error) {
+	statuses := make(map[int]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		code, err := strconv.Atoi(part)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid status code %q", part)
+		}
+		statuses[code] = true
+	}
+	return statuses, nil
+}
+
+// parseFocusIndicators parses the comma-separated --focus-indicator value.
+func parseFocusIndicators(spec string) (marker, inverse bool, err error) {
+	for _, part := range strings.Split(spec, ",") {
+		switch strings.TrimSpace(part) {
+		case "marker":
+			marker = true
+		case "inverse":
+			inverse = true
+		case "none", "":
+		default:
+			return false, false, fmt.Errorf("unknown indicator %q", part)
+		}
+	}
+	return marker, inverse, nil
+}
+
+// buildNotifier assembles notification channels from environment variables.
+func buildNotifier(lang string) (*notify.Dispatcher, error) {
+	var channels []notify.Channel
+
+	if token := strings.TrimSpace(os.Getenv("YESCODE_TELEGRAM_BOT_TOKEN")); token != "" {
+		tg, err := notify.NewTelegram(token, strings.TrimSpace(os.Getenv("YESCODE_TELEGRAM_CHAT_ID")))
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, tg)
 	}
+
+	if token := strings.TrimSpace(os.Getenv("YESCODE_DINGTALK_TOKEN")); token != "" {
+		dt, err := notify.NewDingTalk(token, strings.TrimSpace(os.Getenv("YESCODE_DINGTALK_SECRET")))
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, dt)
+	}
+
+	return notify.NewDispatcher(lang, channels...), nil
+}
+
+// buildVersion describes the running binary from its embedded build info.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" && len(s.Value) >= 12 {
+			version += " (" + s.Value[:12] + ")"
+		}
+	}
+	return version
+}
+
+// bundleConfig collects the effective settings, account URLs and YESCODE_
+// environment variables for the diagnostic bundle, with secrets redacted.
+func bundleConfig(fs *flag.FlagSet, accounts []config.Account) map[string]string {
+	values := config.Effective(fs)
+	for _, a := range accounts {
+		values["accounts."+a.Name+".base_url"] = a.BaseURL
+	}
+	for _, kv := range os.Environ() {
+		if name, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(name, "YESCODE_") {
+			values["env."+name] = value
+		}
+	}
+	return config.Redact(values)
+}
+
+// exitf prints the message, translated, to stderr and exits with status 1.
+func exitf(format string, args ...any) {
+	fmt.Fprintln(os.Stderr, i18n.Tf(format, args...))
+	os.Exit(1)
 }
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"yescode-tui/internal/i18n"
+)
+
+// registerJSONFlag adds --json to a non-interactive command.
+func registerJSONFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("json", false, i18n.T("以 JSON 输出结构化结果（便于通过 jq 处理），提示信息改为输出到标准错误"))
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		exitf("输出 JSON 失败: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+// runProfile implements `yc profile`, printing the account profile.
+func runProfile(args []string) {
+	fs := flag.NewFlagSet("yc profile", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	asJSON := registerJSONFlag(fs)
+	conn.parse(fs, args)
+
+	profile, err := conn.newClient().GetProfile(context.Background())
+	if err != nil {
+		exitf("获取用户资料失败: %v", err)
+	}
+	if *asJSON {
+		printJSON(profile)
+		return
+	}
+
+	plan := profile.SubscriptionPlan
+	fmt.Printf(i18n.T("用户名：%s\n"), profile.Username)
+	fmt.Printf(i18n.T("邮箱：%s\n"), profile.Email)
+	fmt.Printf(i18n.T("订阅余额：%s\n"), format.Money(profile.SubscriptionBalance))
+	fmt.Printf(i18n.T("按需余额：%s\n"), format.Money(profile.PayAsYouGoBalance))
+	fmt.Printf(i18n.T("总余额：%s\n"), format.Money(profile.Balance))
+	fmt.Printf(i18n.T("余额偏好：%s\n"), profile.BalancePreference)
+	if plan.Name != "" {
+		fmt.Printf(i18n.T("订阅计划：%s (%s)\n"), plan.Name, format.Money(plan.Price))
+	}
+	if expiry := profile.SubscriptionExpiry; expiry != "" {
+		if t, err := time.Parse(time.RFC3339, expiry); err == nil {
+			expiry = format.DateTime(t)
+		}
+		fmt.Printf(i18n.T("订阅到期：%s\n"), expiry)
+	}
+	fmt.Printf(i18n.T("本周消费：%s / %s\n"), format.Money(profile.CurrentWeekSpend), format.Money(plan.WeeklyLimit))
+	fmt.Printf(i18n.T("本月消费：%s / %s\n"), format.Money(profile.CurrentMonthSpend), format.Money(plan.MonthlySpendLimit))
+}
+
+// runProviders implements `yc providers`, listing the available providers.
+func runProviders(args []string) {
+	fs := flag.NewFlagSet("yc providers", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	asJSON := registerJSONFlag(fs)
+	conn.parse(fs, args)
+
+	resp, err := conn.newClient().GetAvailableProviders(context.Background())
+	if err != nil {
+		exitf("获取提供商列表失败: %v", err)
+	}
+	if *asJSON {
+		printJSON(resp)
+		return
+	}
+
+	fmt.Println(pad("ID", 6), pad(i18n.T("名称"), -24), pad(i18n.T("来源"), -14), pad(i18n.T("类型"), -12), i18n.T("倍率"))
+	for _, b := range resp.Providers {
+		name := b.Provider.DisplayName
+		if b.IsDefault {
+			name += i18n.T(" (默认)")
+		}
+		fmt.Println(pad(strconv.Itoa(b.Provider.ID), 6), pad(name, -24), pad(b.Source, -14), pad(b.Provider.Type, -12), fmt.Sprintf("×%.2f", b.RateMultiplier))
+	}
+	fmt.Printf(i18n.T("\n订阅：%s · 按需余额：%s\n"), yesNo(resp.HasSubscription), yesNo(resp.HasPaygBalance))
+}
+
+// runSelection implements `yc selection [PROVIDER]`, printing the selected
+// alternative of one provider (by ID or name) or of every provider.
+func runSelection(args []string) {
+	fs := flag.NewFlagSet("yc selection", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	asJSON := registerJSONFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("用法: yc selection [选项] [提供商 ID 或名称]"))
+		fs.PrintDefaults()
+	}
+	conn.parse(fs, args)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	client := conn.newClient()
+	resp, err := client.GetAvailableProviders(ctx)
+	if err != nil {
+		exitf("获取提供商列表失败: %v", err)
+	}
+	providers := resp.Providers
+	if fs.NArg() == 1 {
+		bucket, ok := findBucket(providers, fs.Arg(0))
+		if !ok {
+			exitf("没有提供商 %q", fs.Arg(0))
+		}
+		providers = []api.ProviderBucket{bucket}
+	}
+
+	selections := make([]*api.ProviderSelection, 0, len(providers))
+	for _, b := range providers {
+		sel, err := client.GetProviderSelection(ctx, b.Provider.ID)
+		if err != nil {
+			exitf("获取 %s 的当前方案失败: %v", b.Provider.DisplayName, err)
+		}
+		selections = append(selections, sel)
+	}
+	if *asJSON {
+		// 指定单个提供商时输出对象，否则输出数组
+		if fs.NArg() == 1 {
+			printJSON(selections[0])
+		} else {
+			printJSON(selections)
+		}
+		return
+	}
+	for i, sel := range selections {
+		alt := sel.SelectedAlternative
+		fmt.Printf("%s: %s (%d) ×%.2f\n", providers[i].Provider.DisplayName, alt.DisplayName, sel.SelectedAlternativeID, alt.RateMultiplier)
+	}
+}
+
+// findBucket looks a provider up by ID or case-insensitive name.
+func findBucket(providers []api.ProviderBucket, query string) (api.ProviderBucket, bool) {
+	id, err := strconv.Atoi(query)
+	for _, b := range providers {
+		if (err == nil && b.Provider.ID == id) || strings.EqualFold(b.Provider.DisplayName, query) {
+			return b, true
+		}
+	}
+	return api.ProviderBucket{}, false
+}
+
+func yesNo(v bool) string {
+	if v {
+		return i18n.T("有")
+	}
+	return i18n.T("无")
+}
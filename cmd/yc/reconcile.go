@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/reconcile"
+)
+
+// runReconcile implements `yc reconcile FILE`, comparing an external usage
+// export with the spend recorded by local sampling.
+func runReconcile(args []string) {
+	fs := flag.NewFlagSet("yc reconcile", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	var (
+		multiplier = fs.Float64("multiplier", 1, i18n.T("外部费用换算倍率（例如当前方案的倍率 ×0.8）"))
+		tolerance  = fs.Float64("tolerance", 0.2, i18n.T("允许的相对差异，超过即标记为异常（0.2 = 20%）"))
+		asJSON     = registerJSONFlag(fs)
+		storage    = registerStoreFlags(fs)
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("用法: yc reconcile [选项] <usage.csv|usage.json>"))
+		fs.PrintDefaults()
+	}
+	conn.parse(fs, args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		exitf("打开用量文件失败: %v", err)
+	}
+	external, err := reconcile.Parse(f, time.Local)
+	f.Close()
+	if err != nil {
+		exitf("解析用量文件失败: %v", err)
+	}
+	if len(external) == 0 {
+		exitf("用量文件中没有数据")
+	}
+
+	client := conn.newClient()
+	profile, err := client.GetProfile(context.Background())
+	if err != nil {
+		exitf("获取用户资料失败: %v", err)
+	}
+
+	local, err := openLocalState(storage)
+	if err != nil {
+		exitf("打开本地记录失败: %v", err)
+	}
+	now := time.Now()
+	days := int(now.Sub(external[0].Date).Hours()/24) + 1
+	samples, err := local.history.Load(history.AccountOf(profile), external[0].Date.AddDate(0, 0, -1))
+	// 比对只需要已读出的采样，先关闭本地记录，之后的 os.Exit 不会跳过它
+	local.Close()
+	if err != nil {
+		exitf("读取本地采样失败: %v", err)
+	}
+	reported := history.DailySpend(samples, days, now)
+
+	rows := reconcile.Compare(external, reported, *multiplier, *tolerance)
+	if code := printReconcile(rows, *asJSON, *tolerance); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// printReconcile prints the compared days and returns the exit code: 3 when
+// any day differs by more than the tolerance.
+func printReconcile(rows []reconcile.Row, asJSON bool, tolerance float64) int {
+	mismatches := 0
+	for _, row := range rows {
+		if row.Mismatch {
+			mismatches++
+		}
+	}
+	code := 0
+	if mismatches > 0 {
+		code = 3
+	}
+	if asJSON {
+		printJSON(rows)
+		return code
+	}
+	fmt.Println(pad(i18n.T("日期"), -12), pad(i18n.T("外部记录"), 12), pad("YesCode", 12), pad(i18n.T("差额"), 12))
+	for _, row := range rows {
+		if !row.Sampled && !row.Estimated {
+			fmt.Println(pad(row.Date.Format(time.DateOnly), -12), pad(format.Money(row.External), 12), pad("-", 12), pad("-", 12), i18n.T(" (未采样)"))
+			continue
+		}
+		mark := ""
+		switch {
+		case row.Estimated:
+			mark = i18n.T("  (估算，不参与比对)")
+		case row.Mismatch:
+			mark = i18n.T("  ⚠ 差异超出容差")
+		}
+		fmt.Println(pad(row.Date.Format(time.DateOnly), -12), pad(format.Money(row.External), 12), pad(format.Money(row.Reported), 12), pad(format.Money(row.Diff()), 12)+mark)
+	}
+	fmt.Printf(i18n.T("\n共 %d 天，%d 天差异超出 %.0f%% 容差\n"), len(rows), mismatches, tolerance*100)
+	return code
+}
+
+// pad aligns s to width display cells; negative width pads on the right.
+func pad(s string, width int) string {
+	left := width < 0
+	if left {
+		width = -width
+	}
+	gap := width - lipgloss.Width(s)
+	if gap <= 0 {
+		return s
+	}
+	if left {
+		return s + strings.Repeat(" ", gap)
+	}
+	return strings.Repeat(" ", gap) + s
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyTypes maps Bubble Tea key names ("enter", "ctrl+c", "f5", ...) back to
+// their key types.
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{"space": tea.KeySpace}
+	for k := tea.KeyType(-100); k < 128; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes && k != tea.KeySpace {
+			types[name] = k
+		}
+	}
+	return types
+}()
+
+// scriptStep is one line of a --script file.
+type scriptStep struct {
+	msgs  []tea.Msg
+	sleep time.Duration
+	quit  bool
+}
+
+// parseScriptLine parses one script line; blank lines and # comments yield
+// an empty step. The commands are:
+//
+//	key NAME...    press keys by name (enter, down, ctrl+c, alt+x, q, space)
+//	type TEXT      type TEXT one character at a time
+//	size W H       resize the window to W×H cells
+//	sleep DUR      wait, e.g. for API responses (500ms, 2s)
+//	quit           end the program
+func parseScriptLine(line string) (scriptStep, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return scriptStep{}, nil
+	}
+	cmd, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+	switch cmd {
+	case "key":
+		var step scriptStep
+		for _, name := range strings.Fields(rest) {
+			key, err := parseKey(name)
+			if err != nil {
+				return step, err
+			}
+			step.msgs = append(step.msgs, key)
+		}
+		if len(step.msgs) == 0 {
+			return step, fmt.Errorf("key needs at least one key name")
+		}
+		return step, nil
+	case "type":
+		var step scriptStep
+		for _, r := range rest {
+			key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+			if r == ' ' {
+				key.Type = tea.KeySpace
+			}
+			step.msgs = append(step.msgs, key)
+		}
+		return step, nil
+	case "size":
+		fields := strings.Fields(rest)
+		if len(fields) != 2 {
+			return scriptStep{}, fmt.Errorf("size needs a width and a height")
+		}
+		width, err1 := strconv.Atoi(fields[0])
+		height, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil || width <= 0 || height <= 0 {
+			return scriptStep{}, fmt.Errorf("invalid size %q", rest)
+		}
+		return scriptStep{msgs: []tea.Msg{tea.WindowSizeMsg{Width: width, Height: height}}}, nil
+	case "sleep":
+		d, err := time.ParseDuration(rest)
+		if err != nil || d < 0 {
+			return scriptStep{}, fmt.Errorf("invalid duration %q", rest)
+		}
+		return scriptStep{sleep: d}, nil
+	case "quit":
+		return scriptStep{quit: true}, nil
+	}
+	return scriptStep{}, fmt.Errorf("unknown command %q (use key, type, size, sleep or quit)", cmd)
+}
+
+// parseKey turns a key name into the message Bubble Tea sends for it.
+func parseKey(name string) (tea.KeyMsg, error) {
+	var key tea.KeyMsg
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		key.Alt = true
+		name = rest
+	}
+	if t, ok := keyTypes[name]; ok {
+		key.Type = t
+		if t == tea.KeySpace {
+			key.Runes = []rune{' '}
+		}
+		return key, nil
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		key.Type = tea.KeyRunes
+		key.Runes = []rune(name)
+		return key, nil
+	}
+	return key, fmt.Errorf("unknown key %q", name)
+}
+
+// runScript feeds the script at path, which may be a FIFO, to program line by
+// line and quits the program when the script ends. The returned channel
+// yields the first error, if any.
+func runScript(program *tea.Program, path string) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		defer program.Quit()
+		f, err := os.Open(path)
+		if err != nil {
+			errc <- err
+			return
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for n := 1; scanner.Scan(); n++ {
+			step, err := parseScriptLine(scanner.Text())
+			if err != nil {
+				errc <- fmt.Errorf("line %d: %w", n, err)
+				return
+			}
+			for _, msg := range step.msgs {
+				program.Send(msg)
+			}
+			time.Sleep(step.sleep)
+			if step.quit {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			errc <- err
+		}
+	}()
+	return errc
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+// runSelftest implements `yc selftest`, calling every endpoint with the
+// configured key and reporting which ones the account can use and where the
+// responses differ from the client's structs.
+func runSelftest(args []string) {
+	fs := flag.NewFlagSet("yc selftest", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	var (
+		write    = fs.Bool("write", false, i18n.T("同时测试写入接口：重新提交当前的余额偏好和第一个提供商的当前方案（不会改变账户状态）"))
+		fixtures = fs.String("fixtures", "", i18n.T("不访问 API，改为检查目录中录制的响应（文件名为 类型[.说明].json，例如 internal/api/fixtures）"))
+		asJSON   = registerJSONFlag(fs)
+	)
+	conn.parse(fs, args)
+	if dir := strings.TrimSpace(*fixtures); dir != "" {
+		runFixtureChecks(dir, *asJSON)
+		return
+	}
+
+	checks := conn.newClient().SelfTest(context.Background(), *write)
+	failed := 0
+	for _, c := range checks {
+		if !c.OK() {
+			failed++
+		}
+	}
+	if *asJSON {
+		printJSON(checks)
+		if failed > 0 {
+			os.Exit(3)
+		}
+		return
+	}
+
+	fmt.Println(pad(i18n.T("方法"), -6), pad(i18n.T("接口"), -50), pad(i18n.T("状态"), 6), pad(i18n.T("耗时"), 8), " "+i18n.T("结果"))
+	for _, c := range checks {
+		status := "-"
+		if c.StatusCode != 0 {
+			status = strconv.Itoa(c.StatusCode)
+		}
+		fmt.Println(pad(c.Method, -6), pad(c.Endpoint, -50), pad(status, 6), pad(c.Latency.Round(time.Millisecond).String(), 8), " "+checkResult(c.StatusCode, c.Error))
+		if len(c.UnknownFields) > 0 {
+			fmt.Println(i18n.T("       响应中多出的字段：") + strings.Join(c.UnknownFields, ", "))
+		}
+		if len(c.MissingFields) > 0 {
+			fmt.Println(i18n.T("       响应中缺少的字段：") + strings.Join(c.MissingFields, ", "))
+		}
+	}
+	fmt.Printf(i18n.T("\n共 %d 个接口，%d 个失败\n"), len(checks), failed)
+	if failed > 0 {
+		os.Exit(3)
+	}
+}
+
+// checkResult describes one self-test outcome.
+func checkResult(status int, errText string) string {
+	switch {
+	case errText == "":
+		return i18n.T("正常")
+	case status == http.StatusUnauthorized:
+		return i18n.T("API Key 无效")
+	case status == http.StatusForbidden:
+		return i18n.T("当前套餐无权访问")
+	}
+	return i18n.T("失败：") + errText
+}
+
+// fixtureCheck is the outcome of checking one recorded response.
+type fixtureCheck struct {
+	File string `json:"file"`
+	api.Check
+}
+
+// runFixtureChecks checks every recorded response in dir against the
+// client's structs, exiting with status 3 if any no longer matches.
+func runFixtureChecks(dir string, asJSON bool) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		exitf("读取录制的响应失败: %v", err)
+	}
+	if len(files) == 0 {
+		exitf("目录 %s 中没有录制的响应", dir)
+	}
+
+	var checks []fixtureCheck
+	failed := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			exitf("读取录制的响应失败: %v", err)
+		}
+		name := filepath.Base(file)
+		kind, _, _ := strings.Cut(strings.TrimSuffix(name, ".json"), ".")
+		check, err := api.CheckContract(kind, data)
+		if err != nil {
+			exitf("%s: %v（可用类型：%s）", name, err, strings.Join(api.ContractKinds(), " / "))
+		}
+		// 录制的响应应与结构体完全一致，字段增减都说明接口已变化
+		if check.OK() && (len(check.UnknownFields) > 0 || len(check.MissingFields) > 0) {
+			check.Error = "fields differ from the client struct"
+		}
+		if !check.OK() {
+			failed++
+		}
+		checks = append(checks, fixtureCheck{File: name, Check: check})
+	}
+	if asJSON {
+		printJSON(checks)
+	} else {
+		for _, c := range checks {
+			fmt.Println(pad(c.File, -32), checkResult(0, c.Error))
+			if len(c.UnknownFields) > 0 {
+				fmt.Println(i18n.T("       响应中多出的字段：") + strings.Join(c.UnknownFields, ", "))
+			}
+			if len(c.MissingFields) > 0 {
+				fmt.Println(i18n.T("       响应中缺少的字段：") + strings.Join(c.MissingFields, ", "))
+			}
+		}
+		fmt.Printf(i18n.T("\n共 %d 个录制的响应，%d 个不匹配\n"), len(checks), failed)
+	}
+	if failed > 0 {
+		os.Exit(3)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/snapshot"
+)
+
+// runSnapshot implements `yc snapshot`, writing the account state as
+// canonical JSON or YAML, and `yc snapshot diff FILE`.
+func runSnapshot(args []string) {
+	if len(args) > 0 && args[0] == "diff" {
+		runSnapshotDiff(args[1:])
+		return
+	}
+
+	fs := flag.NewFlagSet("yc snapshot", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	var (
+		output = fs.String("o", "", i18n.T("输出文件路径（默认输出到标准输出）"))
+		format = fs.String("format", "", i18n.T("输出格式（json / yaml），默认按 -o 的扩展名判断，否则为 json"))
+		asJSON = fs.Bool("json", false, i18n.T("等同于 --format json"))
+	)
+	conn.parse(fs, args)
+
+	if *asJSON {
+		*format = "json"
+	}
+	if *format == "" {
+		*format = formatFromPath(*output)
+	}
+	if *format != "json" && *format != "yaml" {
+		exitf("--format 无效: %q", *format)
+	}
+	client := conn.newClient()
+	snap, err := snapshot.Capture(context.Background(), client)
+	if err != nil {
+		exitf("获取账户状态失败: %v", err)
+	}
+
+	if *output == "" {
+		if err := snapshot.Encode(os.Stdout, snap, *format); err != nil {
+			exitf("写入快照失败: %v", err)
+		}
+		return
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		exitf("创建快照文件失败: %v", err)
+	}
+	if err := snapshot.Encode(f, snap, *format); err != nil {
+		f.Close()
+		exitf("写入快照失败: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		exitf("写入快照失败: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, i18n.T("已写入 %s\n"), *output)
+}
+
+// runSnapshotDiff compares a saved snapshot with the live account state.
+func runSnapshotDiff(args []string) {
+	fs := flag.NewFlagSet("yc snapshot diff", flag.ExitOnError)
+	conn := registerClientFlags(fs)
+	asJSON := registerJSONFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("用法: yc snapshot diff [选项] <snapshot.json|snapshot.yaml>"))
+		fs.PrintDefaults()
+	}
+	conn.parse(fs, args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		exitf("打开快照文件失败: %v", err)
+	}
+	saved, err := snapshot.Decode(f)
+	f.Close()
+	if err != nil {
+		exitf("解析快照文件失败: %v", err)
+	}
+
+	client := conn.newClient()
+	live, err := snapshot.Capture(context.Background(), client)
+	if err != nil {
+		exitf("获取账户状态失败: %v", err)
+	}
+
+	changes := snapshot.Diff(saved, live)
+	if *asJSON {
+		if changes == nil {
+			changes = []snapshot.Change{}
+		}
+		printJSON(changes)
+		if len(changes) > 0 {
+			os.Exit(3)
+		}
+		return
+	}
+	if len(changes) == 0 {
+		fmt.Println(i18n.T("与当前状态一致"))
+		return
+	}
+	for _, c := range changes {
+		switch {
+		case c.Old == "":
+			fmt.Printf("+ %s: %s\n", c.Path, c.New)
+		case c.New == "":
+			fmt.Printf("- %s: %s\n", c.Path, c.Old)
+		default:
+			fmt.Printf("~ %s: %s → %s\n", c.Path, c.Old, c.New)
+		}
+	}
+	fmt.Printf(i18n.T("\n共 %d 处差异\n"), len(changes))
+	os.Exit(3)
+}
+
+// formatFromPath picks the snapshot format from a file extension.
+func formatFromPath(path string) string {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	return "json"
+}
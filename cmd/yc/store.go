@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"yescode-tui/internal/appdir"
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/localdb"
+	"yescode-tui/internal/recent"
+	"yescode-tui/internal/sealed"
+)
+
+// Local storage backends selectable with --store.
+const (
+	storeJSON   = "json"
+	storeSQLite = "sqlite"
+)
+
+// storeFlags selects how a command keeps local samples and usage.
+type storeFlags struct {
+	kind *string
+	*encryptFlag
+}
+
+// registerStoreFlags adds --store and --encrypt-state to a command that reads
+// or writes local samples and usage.
+func registerStoreFlags(fs *flag.FlagSet) *storeFlags {
+	return &storeFlags{
+		kind:        fs.String("store", storeJSON, i18n.T("本地记录（余额采样、最近使用的方案）的存储方式：json（每类一个文件）或 sqlite（单个 yc.db 数据库，首次使用时导入已有的 JSON 记录）")),
+		encryptFlag: registerEncryptFlag(fs),
+	}
+}
+
+// encryptFlag is --encrypt-state, which seals everything written to the
+// application directory with a key from the OS keyring.
+type encryptFlag struct {
+	encrypt *bool
+	box     *sealed.Box
+}
+
+func registerEncryptFlag(fs *flag.FlagSet) *encryptFlag {
+	return &encryptFlag{
+		encrypt: fs.Bool("encrypt-state", false, i18n.T("加密配置目录中的本地记录、回滚点、实例协调文件和日志，密钥保存在系统钥匙串中（仅支持 --store json）")),
+	}
+}
+
+// stateBox returns the box sealing local state, reading the key from the
+// keyring on first use, or nil without --encrypt-state.
+func (f *encryptFlag) stateBox() (*sealed.Box, error) {
+	if !*f.encrypt {
+		return nil, nil
+	}
+	if f.box == nil {
+		box, err := sealed.FromKeyring()
+		if err != nil {
+			return nil, err
+		}
+		f.box = box
+	}
+	return f.box, nil
+}
+
+// localState is the local history and recent-usage stores of one backend.
+type localState struct {
+	history *history.Store
+	recent  *recent.Store
+	db      *localdb.DB
+}
+
+// openLocalState opens the stores of the selected backend in the application
+// directory.
+func openLocalState(flags *storeFlags) (*localState, error) {
+	historyPath, err := appdir.Path("history.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	recentPath, err := appdir.Path("recent.json")
+	if err != nil {
+		return nil, err
+	}
+
+	switch *flags.kind {
+	case storeJSON:
+		if !*flags.encrypt {
+			usage, err := recent.Open(recentPath)
+			if err != nil {
+				return nil, err
+			}
+			return &localState{history: history.NewStore(historyPath), recent: usage}, nil
+		}
+		box, err := flags.stateBox()
+		if err != nil {
+			return nil, err
+		}
+		usage, err := recent.OpenSealed(recentPath, box)
+		if err != nil {
+			return nil, err
+		}
+		return &localState{history: history.NewSealedStore(historyPath, box), recent: usage}, nil
+	case storeSQLite:
+		if *flags.encrypt {
+			return nil, errors.New("--encrypt-state requires --store json")
+		}
+		path, err := appdir.Path("yc.db")
+		if err != nil {
+			return nil, err
+		}
+		db, err := localdb.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		var box *sealed.Box
+		if db.Created() && anySealed(historyPath, recentPath) {
+			// 之前用 --encrypt-state 写入的记录需要钥匙串中的密钥才能导入
+			if box, err = sealed.FromKeyring(); err != nil {
+				db.Close()
+				return nil, err
+			}
+		}
+		if err := db.ImportFiles(historyPath, recentPath, box); err != nil {
+			db.Close()
+			return nil, err
+		}
+		usage, err := recent.OpenWith(db.Recent())
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &localState{history: history.NewStoreWith(db.History()), recent: usage, db: db}, nil
+	}
+	return nil, fmt.Errorf("unknown store %q", *flags.kind)
+}
+
+// anySealed reports whether any of the files holds sealed data; missing or
+// unreadable files don't.
+func anySealed(paths ...string) bool {
+	for _, path := range paths {
+		if data, err := os.ReadFile(path); err == nil && sealed.HasSealed(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close releases the backend.
+func (s *localState) Close() {
+	if s.db != nil {
+		s.db.Close()
+	}
+}
+
+// runDecrypt implements `yc decrypt FILE`, printing a file from the
+// application directory that was written with --encrypt-state in the clear.
+func runDecrypt(args []string) {
+	fs := flag.NewFlagSet("yc decrypt", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("用法: yc decrypt <文件>（例如配置目录下的 debug.log 或 rollback/ 中的回滚点）"))
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		exitf("读取文件失败: %v", err)
+	}
+	box, err := sealed.FromKeyring()
+	if err != nil {
+		exitf("读取加密密钥失败: %v", err)
+	}
+	plain, err := openState(box, data)
+	if err != nil {
+		exitf("解密失败: %v", err)
+	}
+	os.Stdout.Write(plain)
+}
+
+// openState decrypts a whole sealed file, or else each sealed line of a
+// line-based one; plaintext lines pass through.
+func openState(box *sealed.Box, data []byte) ([]byte, error) {
+	if plain, err := box.Open(data); err == nil {
+		return plain, nil
+	}
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		body := bytes.TrimSuffix(line, []byte("\n"))
+		plain, err := box.OpenText(body)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(plain)
+		if len(body) < len(line) {
+			out.WriteByte('\n')
+		}
+	}
+	return out.Bytes(), nil
+}
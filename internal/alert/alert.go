@@ -0,0 +1,157 @@
+package alert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/notify"
+)
+
+// Snapshot is what rules are evaluated against: the profile plus values
+// derived from the local spend history.
+type Snapshot struct {
+	Profile *api.Profile
+	// SpendRatio is today's spend as a multiple of the trailing daily
+	// average, 0 while there is not enough history.
+	SpendRatio float64
+}
+
+// Rule fires when a profile metric crosses a threshold, e.g. "balance<5".
+type Rule struct {
+	Metric    string
+	Op        string
+	Threshold float64
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%s%s%s", r.Metric, r.Op, strconv.FormatFloat(r.Threshold, 'f', -1, 64))
+}
+
+func (r Rule) matches(value float64) bool {
+	switch r.Op {
+	case "<":
+		return value < r.Threshold
+	case "<=":
+		return value <= r.Threshold
+	case ">":
+		return value > r.Threshold
+	case ">=":
+		return value >= r.Threshold
+	}
+	return false
+}
+
+// metrics extracts the values rules can refer to.
+var metrics = map[string]func(s Snapshot) float64{
+	"balance":              func(s Snapshot) float64 { return s.Profile.Balance },
+	"subscription_balance": func(s Snapshot) float64 { return s.Profile.SubscriptionBalance },
+	"payg_balance":         func(s Snapshot) float64 { return s.Profile.PayAsYouGoBalance },
+	"week_spend":           func(s Snapshot) float64 { return s.Profile.CurrentWeekSpend },
+	"month_spend":          func(s Snapshot) float64 { return s.Profile.CurrentMonthSpend },
+	"week_pct": func(s Snapshot) float64 {
+		return percent(s.Profile.CurrentWeekSpend, s.Profile.SubscriptionPlan.WeeklyLimit)
+	},
+	"month_pct": func(s Snapshot) float64 {
+		return percent(s.Profile.CurrentMonthSpend, s.Profile.SubscriptionPlan.MonthlySpendLimit)
+	},
+	"spend_ratio": func(s Snapshot) float64 { return s.SpendRatio },
+}
+
+func percent(spend, limit float64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return spend / limit * 100
+}
+
+// ParseRules parses a comma-separated rule list such as "balance<5,week_pct>=90".
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		rule, err := parseRule(part)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseRule(s string) (Rule, error) {
+	// 先匹配两字符运算符，避免 "<=" 被拆成 "<"
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		idx := strings.Index(s, op)
+		if idx <= 0 {
+			continue
+		}
+		metric := strings.TrimSpace(s[:idx])
+		if _, ok := metrics[metric]; !ok {
+			return Rule{}, fmt.Errorf("unknown alert metric %q", metric)
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(s[idx+len(op):]), 64)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid alert threshold in %q: %w", s, err)
+		}
+		return Rule{Metric: metric, Op: op, Threshold: threshold}, nil
+	}
+	return Rule{}, fmt.Errorf("invalid alert rule %q", s)
+}
+
+// Engine evaluates rules against successive profiles. A rule fires once when it
+// starts matching and re-arms after it stops matching.
+type Engine struct {
+	rules  []Rule
+	firing map[string]bool
+}
+
+// NewEngine builds an Engine for the given rules.
+func NewEngine(rules []Rule) *Engine {
+	return &Engine{rules: rules, firing: make(map[string]bool)}
+}
+
+// Clone returns an Engine with the same rules and no firing state.
+func (e *Engine) Clone() *Engine {
+	if e == nil {
+		return nil
+	}
+	return NewEngine(e.rules)
+}
+
+// Evaluate returns events for rules that newly matched the snapshot.
+func (e *Engine) Evaluate(s Snapshot) []notify.Event {
+	p := s.Profile
+	if e == nil || p == nil {
+		return nil
+	}
+	var events []notify.Event
+	now := time.Now()
+	for _, rule := range e.rules {
+		key := rule.String()
+		value := metrics[rule.Metric](s)
+		if !rule.matches(value) {
+			e.firing[key] = false
+			continue
+		}
+		if e.firing[key] {
+			continue
+		}
+		e.firing[key] = true
+		events = append(events, notify.Event{
+			Rule:      key,
+			Metric:    rule.Metric,
+			Op:        rule.Op,
+			Value:     value,
+			Threshold: rule.Threshold,
+			Account:   p.Username,
+			Time:      now,
+		})
+	}
+	return events
+}
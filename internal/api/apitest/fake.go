@@ -0,0 +1,191 @@
+// Package apitest provides an in-memory api.Service for exercising the
+// interface without a live server.
+package apitest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"yescode-tui/internal/api"
+)
+
+// Fake serves fixed data and applies switches and preference updates to it.
+// Set Err to make every call fail. It is safe for concurrent use; lock Mu
+// when changing fields while calls may be running.
+type Fake struct {
+	Mu sync.Mutex
+
+	Profile      api.Profile
+	Providers    api.ProvidersResponse
+	Alternatives map[int][]api.AlternativeOption
+	Selections   map[int]api.ProviderSelection
+	Err          error
+	// Events, when set, is returned by Subscribe; otherwise Subscribe
+	// reports api.ErrStreamUnsupported.
+	Events chan api.StreamEvent
+	// PageSize, when positive, splits the providers into numbered pages
+	// of that size for GetProvidersPage.
+	PageSize int
+
+	// Calls counts the calls by method name, e.g. "SwitchProvider".
+	Calls map[string]int
+}
+
+var _ api.Service = (*Fake)(nil)
+
+func (f *Fake) begin(method string) error {
+	if f.Calls == nil {
+		f.Calls = make(map[string]int)
+	}
+	f.Calls[method]++
+	return f.Err
+}
+
+// GetProfile implements api.Service.
+func (f *Fake) GetProfile(ctx context.Context) (*api.Profile, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("GetProfile"); err != nil {
+		return nil, err
+	}
+	profile := f.Profile
+	return &profile, nil
+}
+
+// GetAvailableProviders implements api.Service.
+func (f *Fake) GetAvailableProviders(ctx context.Context) (*api.ProvidersResponse, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("GetAvailableProviders"); err != nil {
+		return nil, err
+	}
+	resp := f.Providers
+	resp.Providers = append([]api.ProviderBucket(nil), f.Providers.Providers...)
+	return &resp, nil
+}
+
+// GetProvidersPage implements api.Service.
+func (f *Fake) GetProvidersPage(ctx context.Context, page api.Page) (*api.ProvidersResponse, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("GetProvidersPage"); err != nil {
+		return nil, err
+	}
+	resp := f.Providers
+	all := f.Providers.Providers
+	if f.PageSize <= 0 {
+		resp.Providers = append([]api.ProviderBucket(nil), all...)
+		return &resp, nil
+	}
+	n := max(page.Number, 1)
+	start := min((n-1)*f.PageSize, len(all))
+	end := min(start+f.PageSize, len(all))
+	resp.Providers = append([]api.ProviderBucket(nil), all[start:end]...)
+	resp.Pagination = api.Pagination{HasMore: end < len(all), Page: n}
+	return &resp, nil
+}
+
+// GetProviderAlternatives implements api.Service.
+func (f *Fake) GetProviderAlternatives(ctx context.Context, providerID int) ([]api.AlternativeOption, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("GetProviderAlternatives"); err != nil {
+		return nil, err
+	}
+	alts, ok := f.Alternatives[providerID]
+	if !ok {
+		return nil, notFound(fmt.Sprintf("/api/v1/user/provider-alternatives/%d", providerID))
+	}
+	return append([]api.AlternativeOption(nil), alts...), nil
+}
+
+// GetProviderSelection implements api.Service.
+func (f *Fake) GetProviderSelection(ctx context.Context, providerID int) (*api.ProviderSelection, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("GetProviderSelection"); err != nil {
+		return nil, err
+	}
+	selection, ok := f.Selections[providerID]
+	if !ok {
+		return nil, notFound(fmt.Sprintf("/api/v1/user/provider-alternatives/%d/selection", providerID))
+	}
+	return &selection, nil
+}
+
+// SwitchProvider implements api.Service. The alternative must be one of the
+// provider's alternatives.
+func (f *Fake) SwitchProvider(ctx context.Context, providerID int, alternativeID int) (*api.ProviderSelection, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("SwitchProvider"); err != nil {
+		return nil, err
+	}
+	for _, alt := range f.Alternatives[providerID] {
+		if alt.Alternative.ID != alternativeID {
+			continue
+		}
+		if f.Selections == nil {
+			f.Selections = make(map[int]api.ProviderSelection)
+		}
+		selection := f.Selections[providerID]
+		selection.ProviderID = providerID
+		selection.SelectedAlternativeID = alternativeID
+		selection.SelectedAlternative = alt.Alternative
+		f.Selections[providerID] = selection
+		return &selection, nil
+	}
+	return nil, &api.APIError{StatusCode: 400, Message: "invalid alternative", Method: "PUT",
+		Path: fmt.Sprintf("/api/v1/user/provider-alternatives/%d/selection", providerID)}
+}
+
+// UpdateBalancePreference implements api.Service.
+func (f *Fake) UpdateBalancePreference(ctx context.Context, preference string) (*api.BalancePreferenceResponse, error) {
+	if preference == "" {
+		return nil, errors.New("preference is required")
+	}
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("UpdateBalancePreference"); err != nil {
+		return nil, err
+	}
+	f.Profile.BalancePreference = preference
+	return &api.BalancePreferenceResponse{BalancePreference: preference}, nil
+}
+
+// CheckConsistency implements api.Service.
+func (f *Fake) CheckConsistency(ctx context.Context) ([]api.Mismatch, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("CheckConsistency"); err != nil {
+		return nil, err
+	}
+	return api.CheckConsistency(&f.Profile, &f.Providers), nil
+}
+
+// Subscribe implements api.Service.
+func (f *Fake) Subscribe(ctx context.Context) (<-chan api.StreamEvent, error) {
+	f.Mu.Lock()
+	defer f.Mu.Unlock()
+	if err := f.begin("Subscribe"); err != nil {
+		return nil, err
+	}
+	if f.Events == nil {
+		return nil, api.ErrStreamUnsupported
+	}
+	return f.Events, nil
+}
+
+// BaseURL implements api.Service.
+func (f *Fake) BaseURL() string {
+	return "https://yescode.invalid"
+}
+
+// InvalidateCache implements api.Service; the fake caches nothing.
+func (f *Fake) InvalidateCache() {}
+
+func notFound(path string) error {
+	return &api.APIError{StatusCode: 404, Message: "not found", Method: "GET", Path: path}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTLs caches the provider endpoints briefly, so moving back and
+// forth between providers reuses their responses. The profile is left out
+// because it is polled for fresh balances.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/api/v1/user/available-providers":                  30 * time.Second,
+		"/api/v1/user/provider-alternatives/{id}":           30 * time.Second,
+		"/api/v1/user/provider-alternatives/{id}/selection": 10 * time.Second,
+	}
+}
+
+// WithResponseCache serves repeated GETs from memory while they are younger
+// than the TTL of their endpoint pattern, in which numeric path segments are
+// written {id}. Endpoints without a TTL are not cached. Any successful write
+// empties the cache.
+func WithResponseCache(ttls map[string]time.Duration) Option {
+	return func(c *Client) {
+		c.cache = &ttlCache{ttls: ttls, entries: make(map[string]ttlEntry)}
+	}
+}
+
+// InvalidateCache drops every response held by WithResponseCache, e.g. before
+// a manual refresh.
+func (c *Client) InvalidateCache() {
+	c.cache.clear()
+}
+
+type ttlEntry struct {
+	body    json.RawMessage
+	expires time.Time
+}
+
+// ttlCache holds decoded-ready GET bodies by path. A nil *ttlCache caches
+// nothing.
+type ttlCache struct {
+	ttls map[string]time.Duration
+
+	mu      sync.Mutex
+	entries map[string]ttlEntry
+}
+
+// lookup returns the cached body for path if it hasn't expired.
+func (tc *ttlCache) lookup(path string) (json.RawMessage, bool) {
+	if tc == nil {
+		return nil, false
+	}
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	entry, ok := tc.entries[path]
+	if !ok || !time.Now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// store caches body for path when its endpoint has a TTL.
+func (tc *ttlCache) store(path string, body json.RawMessage) {
+	if tc == nil {
+		return
+	}
+	ttl := tc.ttls[endpointPattern(path)]
+	if ttl <= 0 {
+		return
+	}
+	tc.mu.Lock()
+	tc.entries[path] = ttlEntry{body: body, expires: time.Now().Add(ttl)}
+	tc.mu.Unlock()
+}
+
+func (tc *ttlCache) clear() {
+	if tc == nil {
+		return
+	}
+	tc.mu.Lock()
+	clear(tc.entries)
+	tc.mu.Unlock()
+}
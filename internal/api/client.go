@@ -3,19 +3,21 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 const (
-	defaultBaseURL        = "https://co.yes.vg"
-	defaultTimeout        = 5 * time.Second
-	defaultUserAgent      = "yescode-tui/0.1"
-	defaultRequestTimeout = 10 * time.Second
+	defaultBaseURL   = "https://co.yes.vg"
+	defaultUserAgent = "yescode-tui/0.1"
 )
 
 // Client wraps HTTP access to the YesCode API.
@@ -23,6 +25,28 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+	failures   *ring[RequestError]
+	requests   *ring[RequestRecord]
+	counters   *sessionCounters
+	retry      RetryPolicy
+	timeouts   Timeouts
+	// conditional is nil unless WithConditionalRequests is used.
+	conditional *responseCache
+	// cache is nil unless WithResponseCache is used.
+	cache *ttlCache
+	// inflight coalesces concurrent GETs of the same path.
+	inflight singleflight.Group
+
+	connectAddr string
+	dnsServer   string
+	resolver    *net.Resolver
+	http3       bool
+	proxy       string
+	tlsConfig   *tls.Config
+	lang        string
+	tracer      Tracer
+	// faults is nil unless WithFaultInjection is used.
+	faults *Faults
 }
 
 // Option configures a Client.
@@ -42,6 +66,11 @@ func WithBaseURL(base string) Option {
 	}
 }
 
+// BaseURL returns the API base URL, which also hosts the web console.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
 // NewClient builds a Client with the provided API key.
 func NewClient(apiKey string, opts ...Option) (*Client, error) {
 	if apiKey == "" {
@@ -51,15 +80,25 @@ func NewClient(apiKey string, opts ...Option) (*Client, error) {
 	c := &Client{
 		apiKey:  apiKey,
 		baseURL: defaultBaseURL,
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
+		lang:    LangZH,
+		// 超时由 timeouts 按次尝试和按调用控制，不使用 http.Client.Timeout
+		httpClient: &http.Client{},
+		failures:   newRing[RequestError](defaultErrorRingSize),
+		requests:   newRing[RequestRecord](defaultRequestRingSize),
+		counters:   &sessionCounters{},
+		retry:      DefaultRetryPolicy(),
+		timeouts:   DefaultTimeouts(),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if err := c.configureTransport(); err != nil {
+		return nil, err
+	}
+	c.injectFaults()
+
 	return c, nil
 }
 
@@ -92,6 +131,7 @@ type ProvidersResponse struct {
 	HasPaygBalance  bool             `json:"has_payg_balance"`
 	HasSubscription bool             `json:"has_subscription"`
 	Providers       []ProviderBucket `json:"providers"`
+	Pagination
 }
 
 // ProviderBucket tracks a provider grouping.
@@ -113,6 +153,7 @@ type ProviderInfo struct {
 // AlternativeResponse is returned by provider-alternatives endpoints.
 type AlternativeResponse struct {
 	Data []AlternativeOption `json:"data"`
+	Pagination
 }
 
 // AlternativeOption describes one selectable alternative.
@@ -153,6 +194,18 @@ type APIError struct {
 	StatusCode int
 	Message    string
 	Body       string
+	Method     string
+	Path       string    // request path and query
+	Time       time.Time // when the request was sent
+	// RetryAfter is the wait the server asked for in a Retry-After header,
+	// 0 if it sent none.
+	RetryAfter time.Duration
+}
+
+// RateLimited reports whether the server rejected the request for being
+// sent too often.
+func (e *APIError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
 }
 
 func (e *APIError) Error() string {
@@ -169,9 +222,6 @@ type errorPayload struct {
 
 // GetProfile fetches /api/v1/auth/profile.
 func (c *Client) GetProfile(ctx context.Context) (*Profile, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
 	var profile Profile
 	if err := c.get(ctx, "/api/v1/auth/profile", &profile); err != nil {
 		return nil, err
@@ -179,36 +229,8 @@ func (c *Client) GetProfile(ctx context.Context) (*Profile, error) {
 	return &profile, nil
 }
 
-// GetAvailableProviders fetches /api/v1/user/available-providers.
-func (c *Client) GetAvailableProviders(ctx context.Context) (*ProvidersResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
-	var resp ProvidersResponse
-	if err := c.get(ctx, "/api/v1/user/available-providers", &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-// GetProviderAlternatives fetches /api/v1/user/provider-alternatives/{providerID}.
-func (c *Client) GetProviderAlternatives(ctx context.Context, providerID int) ([]AlternativeOption, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
-	path := fmt.Sprintf("/api/v1/user/provider-alternatives/%d", providerID)
-	var resp AlternativeResponse
-	if err := c.get(ctx, path, &resp); err != nil {
-		return nil, err
-	}
-	return resp.Data, nil
-}
-
 // GetProviderSelection fetches /api/v1/user/provider-alternatives/{providerID}/selection.
 func (c *Client) GetProviderSelection(ctx context.Context, providerID int) (*ProviderSelection, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
 	path := fmt.Sprintf("/api/v1/user/provider-alternatives/%d/selection", providerID)
 	var env selectionEnvelope
 	if err := c.get(ctx, path, &env); err != nil {
@@ -219,9 +241,6 @@ func (c *Client) GetProviderSelection(ctx context.Context, providerID int) (*Pro
 
 // SwitchProvider updates the selection for the provider group.
 func (c *Client) SwitchProvider(ctx context.Context, providerID int, alternativeID int) (*ProviderSelection, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
 	path := fmt.Sprintf("/api/v1/user/provider-alternatives/%d/selection", providerID)
 	payload := map[string]int{"selected_alternative_id": alternativeID}
 	var env selectionEnvelope
@@ -237,9 +256,6 @@ func (c *Client) UpdateBalancePreference(ctx context.Context, preference string)
 		return nil, errors.New("preference is required")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
 	payload := map[string]string{"balance_preference": preference}
 	var resp BalancePreferenceResponse
 	if err := c.put(ctx, "/api/v1/user/balance-preference", payload, &resp); err != nil {
@@ -249,36 +265,99 @@ func (c *Client) UpdateBalancePreference(ctx context.Context, preference string)
 }
 
 func (c *Client) get(ctx context.Context, path string, out any) error {
-	var lastErr error
-	for attempt := 0; attempt < 2; attempt++ {
-		req, err := c.newRequest(ctx, http.MethodGet, path, nil)
-		if err != nil {
-			return err
-		}
-		err = c.do(req, out)
-		if err == nil {
-			return nil
+	if body, ok := c.cache.lookup(path); ok {
+		return decodeBody(body, out)
+	}
+	body, err := c.fetch(ctx, path)
+	if err != nil {
+		return err
+	}
+	if err := decodeBody(body, out); err != nil {
+		return err
+	}
+	c.cache.store(path, body)
+	return nil
+}
+
+// fetch GETs path. Calls for a path that is already being fetched wait for
+// that request and share its outcome instead of sending their own. The shared
+// request is detached from the first caller's cancellation and bounded by the
+// call timeout alone, so one caller giving up doesn't fail the others; each
+// caller stops waiting when its own context is done.
+func (c *Client) fetch(ctx context.Context, path string) (json.RawMessage, error) {
+	shared := context.WithoutCancel(ctx)
+	ch := c.inflight.DoChan(path, func() (any, error) {
+		var body json.RawMessage
+		err := c.send(shared, ClassRead, http.MethodGet, path, nil, &body)
+		return body, err
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
 		}
-		lastErr = err
+		return res.Val.(json.RawMessage), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	return lastErr
 }
 
 func (c *Client) put(ctx context.Context, path string, body any, out any) error {
-	var buf *bytes.Buffer
+	var payload []byte
 	if body != nil {
-		buf = &bytes.Buffer{}
+		buf := &bytes.Buffer{}
 		if err := json.NewEncoder(buf).Encode(body); err != nil {
 			return fmt.Errorf("encode body: %w", err)
 		}
+		payload = buf.Bytes()
 	}
-
-	req, err := c.newRequest(ctx, http.MethodPut, path, buf)
-	if err != nil {
+	if err := c.send(ctx, ClassWrite, http.MethodPut, path, payload, out); err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	return c.do(req, out)
+	c.cache.clear()
+	return nil
+}
+
+// send performs the request, retrying according to the client's retry policy
+// within the call timeout of the class.
+func (c *Client) send(ctx context.Context, class EndpointClass, method, path string, body []byte, out any) error {
+	ctx, cancel := c.timeouts.callContext(ctx, class)
+	defer cancel()
+
+	attempts := c.retry.attempts(class)
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := c.retry.wait(ctx, attempt, lastErr); err != nil {
+				return lastErr
+			}
+		}
+
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		attemptCtx, cancelAttempt := c.timeouts.attemptContext(ctx)
+		req, err := c.newRequest(attemptCtx, method, path, reader)
+		if err != nil {
+			cancelAttempt()
+			return err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		err = c.do(req, out)
+		cancelAttempt()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !c.retry.shouldRetry(ctx, err) {
+			break
+		}
+	}
+	return lastErr
 }
 
 func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
@@ -290,38 +369,103 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 	req.Header.Set("X-API-Key", c.apiKey)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", defaultUserAgent)
+	req.Header.Set("Accept-Language", acceptLanguage(c.lang))
+	c.conditional.prepare(req)
 	return req, nil
 }
 
 func (c *Client) do(req *http.Request, out any) error {
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		c.recordTraffic(req, nil, 0, time.Since(start))
+		c.recordFailure(req, 0, err.Error(), "")
+		c.trace(req, start, 0, nil, err)
 		return err
 	}
 	defer resp.Body.Close()
 
 	bodyBytes, err := io.ReadAll(resp.Body)
+	c.recordTraffic(req, resp, len(bodyBytes), time.Since(start))
+	c.trace(req, start, resp.StatusCode, bodyBytes, err)
 	if err != nil {
 		return err
 	}
 
+	if resp.StatusCode == http.StatusNotModified {
+		if cached, ok := c.conditional.cached(req); ok {
+			return decodeBody(cached, out)
+		}
+	}
+
 	if resp.StatusCode >= 300 {
-		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Body:       string(bodyBytes),
+			Method:     req.Method,
+			Path:       req.URL.RequestURI(),
+			Time:       start,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 		var payload errorPayload
 		if err := json.Unmarshal(bodyBytes, &payload); err == nil {
-			if payload.Message != "" {
-				apiErr.Message = payload.Message
-			} else if payload.Error != "" {
-				apiErr.Message = payload.Error
-			}
+			// 服务端可能同时返回 error 和 message（有时是中英双语），取界面语言对应的一条
+			apiErr.Message = localize(c.lang, payload.Message, payload.Error)
 		}
+		c.recordFailure(req, resp.StatusCode, apiErr.Message, apiErr.Body)
 		return apiErr
 	}
 
-	if out != nil && len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, out); err != nil {
+	if err := decodeBody(bodyBytes, out); err != nil {
+		return err
+	}
+	c.conditional.store(req, resp, bodyBytes)
+	c.conditional.invalidate(req)
+	return nil
+}
+
+func decodeBody(body []byte, out any) error {
+	if out != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil {
 			return fmt.Errorf("decode response: %w", err)
 		}
 	}
 	return nil
 }
+
+func (c *Client) recordTraffic(req *http.Request, resp *http.Response, received int, latency time.Duration) {
+	record := RequestRecord{
+		Time:     time.Now(),
+		Method:   req.Method,
+		Endpoint: endpointPattern(req.URL.Path),
+		Latency:  latency,
+		Bytes:    received,
+	}
+	if resp != nil {
+		record.StatusCode = resp.StatusCode
+	}
+	c.requests.add(record)
+	c.counters.record(func(s *SessionStats) {
+		s.Requests++
+		s.BytesSent += req.ContentLength
+		s.BytesReceived += int64(received)
+		s.TotalLatency += latency
+		if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+			s.Conditional++
+			if resp != nil && resp.StatusCode == http.StatusNotModified {
+				s.CacheHits++
+			}
+		}
+	})
+}
+
+func (c *Client) recordFailure(req *http.Request, status int, message, body string) {
+	c.failures.add(RequestError{
+		Time:       time.Now(),
+		Method:     req.Method,
+		Endpoint:   endpointPattern(req.URL.Path),
+		StatusCode: status,
+		Message:    message,
+		Body:       body,
+	})
+}
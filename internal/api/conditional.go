@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+)
+
+// WithConditionalRequests caches GET responses and revalidates them with
+// If-None-Match / If-Modified-Since, so unchanged data costs a bodiless 304.
+func WithConditionalRequests() Option {
+	return func(c *Client) {
+		c.conditional = &responseCache{entries: make(map[string]cachedResponse)}
+	}
+}
+
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// responseCache stores validators and bodies of GET responses by URL.
+// A nil *responseCache disables conditional requests.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+// prepare adds validators of a cached response to req.
+func (rc *responseCache) prepare(req *http.Request) {
+	if rc == nil || req.Method != http.MethodGet {
+		return
+	}
+	rc.mu.Lock()
+	entry, ok := rc.entries[req.URL.String()]
+	rc.mu.Unlock()
+	if !ok {
+		return
+	}
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+}
+
+// cached returns the stored body for a request answered with 304.
+func (rc *responseCache) cached(req *http.Request) ([]byte, bool) {
+	if rc == nil || req.Method != http.MethodGet {
+		return nil, false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[req.URL.String()]
+	return entry.body, ok
+}
+
+// invalidate drops every cached response after a successful write, whose
+// effects a revalidation with a second-granular Last-Modified could miss.
+func (rc *responseCache) invalidate(req *http.Request) {
+	if rc == nil || req.Method == http.MethodGet {
+		return
+	}
+	rc.mu.Lock()
+	clear(rc.entries)
+	rc.mu.Unlock()
+}
+
+// store remembers a successful GET response that carries validators.
+func (rc *responseCache) store(req *http.Request, resp *http.Response, body []byte) {
+	if rc == nil || req.Method != http.MethodGet {
+		return
+	}
+	entry := cachedResponse{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		body:         body,
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+	rc.mu.Lock()
+	rc.entries[req.URL.String()] = entry
+	rc.mu.Unlock()
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Mismatch is a ProvidersResponse flag that disagrees with the profile.
+type Mismatch struct {
+	Flag    string  // has_payg_balance or has_subscription
+	Flagged bool    // value reported by /user/available-providers
+	Balance float64 // matching balance reported by /auth/profile
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s=%t but profile balance is %.2f", m.Flag, m.Flagged, m.Balance)
+}
+
+// CheckConsistency cross-validates the balance flags of providers against
+// profile. Disagreement usually means one of the endpoints serves stale data.
+func CheckConsistency(profile *Profile, providers *ProvidersResponse) []Mismatch {
+	var mismatches []Mismatch
+	if hasPayg := profile.PayAsYouGoBalance > 0; providers.HasPaygBalance != hasPayg {
+		mismatches = append(mismatches, Mismatch{
+			Flag:    "has_payg_balance",
+			Flagged: providers.HasPaygBalance,
+			Balance: profile.PayAsYouGoBalance,
+		})
+	}
+	if providers.HasSubscription != profile.SubscriptionPlan.IsActive {
+		mismatches = append(mismatches, Mismatch{
+			Flag:    "has_subscription",
+			Flagged: providers.HasSubscription,
+			Balance: profile.SubscriptionBalance,
+		})
+	}
+	return mismatches
+}
+
+// CheckConsistency fetches the profile and the provider list concurrently,
+// so both describe the same moment, and cross-validates them.
+func (c *Client) CheckConsistency(ctx context.Context) ([]Mismatch, error) {
+	var (
+		wg          sync.WaitGroup
+		profile     *Profile
+		providers   *ProvidersResponse
+		profileErr  error
+		providerErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		profile, profileErr = c.GetProfile(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		providers, providerErr = c.GetAvailableProviders(ctx)
+	}()
+	wg.Wait()
+
+	if profileErr != nil {
+		return nil, profileErr
+	}
+	if providerErr != nil {
+		return nil, providerErr
+	}
+	return CheckConsistency(profile, providers), nil
+}
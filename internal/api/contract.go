@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// contracts maps each kind of recorded response to the struct the client
+// decodes it into.
+var contracts = map[string]func() any{
+	"profile":            func() any { return &Profile{} },
+	"providers":          func() any { return &ProvidersResponse{} },
+	"alternatives":       func() any { return &AlternativeResponse{} },
+	"selection":          func() any { return &selectionEnvelope{} },
+	"balance-preference": func() any { return &BalancePreferenceResponse{} },
+	"error":              func() any { return &errorPayload{} },
+}
+
+// ContractKinds lists the kinds CheckContract accepts.
+func ContractKinds() []string {
+	kinds := make([]string, 0, len(contracts))
+	for kind := range contracts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// CheckContract checks a recorded response of the given kind against the
+// client: it must decode, have exactly the fields of the struct, and decode
+// to the same value after being encoded again.
+func CheckContract(kind string, data []byte) (Check, error) {
+	newValue, ok := contracts[kind]
+	if !ok {
+		return Check{}, fmt.Errorf("unknown response kind %q", kind)
+	}
+	result := Check{Endpoint: kind}
+	first := newValue()
+	result.compareBody(data, first)
+	if !result.OK() {
+		return result, nil
+	}
+
+	encoded, err := json.Marshal(first)
+	if err != nil {
+		result.Error = fmt.Sprintf("encode: %v", err)
+		return result, nil
+	}
+	second := newValue()
+	if err := json.Unmarshal(encoded, second); err != nil {
+		result.Error = fmt.Sprintf("decode re-encoded value: %v", err)
+		return result, nil
+	}
+	if !reflect.DeepEqual(first, second) {
+		result.Error = "value changed after an encode/decode round trip"
+	}
+	return result, nil
+}
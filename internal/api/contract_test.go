@@ -0,0 +1,58 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFixturesMatchContract decodes every recorded response in fixtures/
+// into the struct the client uses for it and round-trips the value, so a
+// struct change that drops or renames a field fails here before it reaches
+// a live account.
+func TestFixturesMatchContract(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("fixtures", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no recorded responses in fixtures/")
+	}
+	seen := map[string]bool{}
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			kind, _, _ := strings.Cut(strings.TrimSuffix(name, ".json"), ".")
+			seen[kind] = true
+			check, err := CheckContract(kind, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !check.OK() {
+				t.Fatalf("contract check failed: %s", check.Error)
+			}
+			if len(check.UnknownFields) > 0 {
+				t.Errorf("fields not in the client struct: %s", strings.Join(check.UnknownFields, ", "))
+			}
+			if len(check.MissingFields) > 0 {
+				t.Errorf("struct fields missing from the response: %s", strings.Join(check.MissingFields, ", "))
+			}
+		})
+	}
+	for _, kind := range ContractKinds() {
+		if !seen[kind] {
+			t.Errorf("no recorded response for kind %q", kind)
+		}
+	}
+}
+
+func TestCheckContractUnknownKind(t *testing.T) {
+	if _, err := CheckContract("nope", []byte(`{}`)); err == nil {
+		t.Fatal("expected an error for an unknown kind")
+	}
+}
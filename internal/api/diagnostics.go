@@ -0,0 +1,138 @@
+package api
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultErrorRingSize   = 200
+	defaultRequestRingSize = 50
+)
+
+// RequestError records one failed request attempt.
+type RequestError struct {
+	Time       time.Time
+	Method     string
+	Endpoint   string // path with numeric IDs replaced by {id}
+	StatusCode int    // 0 for transport errors (timeouts, DNS, refused connections)
+	Message    string // localized server message, or the transport error
+	Body       string // raw response payload; empty for transport errors
+}
+
+// RequestRecord is the metadata of one request attempt, kept for bug reports.
+type RequestRecord struct {
+	Time       time.Time
+	Method     string
+	Endpoint   string // path with numeric IDs replaced by {id}
+	StatusCode int    // 0 for transport errors
+	Latency    time.Duration
+	Bytes      int // response body size
+}
+
+// ring keeps the most recent entries of a fixed-size log.
+type ring[T any] struct {
+	mu   sync.Mutex
+	buf  []T
+	next int
+	full bool
+}
+
+func newRing[T any](size int) *ring[T] {
+	return &ring[T]{buf: make([]T, size)}
+}
+
+func (r *ring[T]) add(e T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buf[r.next] = e
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns the buffered entries, oldest first.
+func (r *ring[T]) snapshot() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]T(nil), r.buf[:r.next]...)
+	}
+	out := make([]T, 0, len(r.buf))
+	out = append(out, r.buf[r.next:]...)
+	return append(out, r.buf[:r.next]...)
+}
+
+// RecentErrors returns the most recent failed request attempts, oldest first.
+func (c *Client) RecentErrors() []RequestError {
+	return c.failures.snapshot()
+}
+
+// RecentRequests returns the metadata of the most recent request attempts,
+// oldest first.
+func (c *Client) RecentRequests() []RequestRecord {
+	return c.requests.snapshot()
+}
+
+// FailureStat aggregates failures of one endpoint and status code.
+type FailureStat struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Count      int
+	FirstSeen  time.Time
+	LastSeen   time.Time
+}
+
+// AggregateFailures groups errors by endpoint and status code, most frequent first.
+func AggregateFailures(errs []RequestError) []FailureStat {
+	type groupKey struct {
+		method, endpoint string
+		status           int
+	}
+	groups := make(map[groupKey]*FailureStat)
+	for _, e := range errs {
+		k := groupKey{e.Method, e.Endpoint, e.StatusCode}
+		stat, ok := groups[k]
+		if !ok {
+			stat = &FailureStat{Method: e.Method, Endpoint: e.Endpoint, StatusCode: e.StatusCode, FirstSeen: e.Time}
+			groups[k] = stat
+		}
+		stat.Count++
+		if e.Time.Before(stat.FirstSeen) {
+			stat.FirstSeen = e.Time
+		}
+		if e.Time.After(stat.LastSeen) {
+			stat.LastSeen = e.Time
+		}
+	}
+
+	out := make([]FailureStat, 0, len(groups))
+	for _, stat := range groups {
+		out = append(out, *stat)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].LastSeen.After(out[j].LastSeen)
+	})
+	return out
+}
+
+// endpointPattern replaces numeric path segments so per-provider paths aggregate together.
+func endpointPattern(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if seg != "" && strings.Trim(seg, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSlowDelay = 3 * time.Second
+
+// Faults sets how often injected failures replace or delay real responses.
+// Each probability is between 0 and 1; at most one fault hits a request.
+type Faults struct {
+	// Timeout is the probability that a request hangs until its attempt
+	// timeout expires.
+	Timeout float64
+	// ServerError is the probability of a synthetic 500 response.
+	ServerError float64
+	// Slow is the probability that a request is held back by SlowDelay
+	// before it is sent.
+	Slow float64
+	// SlowDelay defaults to 3s.
+	SlowDelay time.Duration
+}
+
+// WithFaultInjection makes the client fail or slow down requests at random,
+// so error toasts, retries and rate-limit handling can be exercised without
+// a misbehaving server.
+func WithFaultInjection(f Faults) Option {
+	return func(c *Client) {
+		c.faults = &f
+	}
+}
+
+// ParseFaults parses a comma-separated spec such as
+// "timeout=0.1,500=0.2,slow=0.3,delay=5s".
+func ParseFaults(spec string) (Faults, error) {
+	var f Faults
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return f, fmt.Errorf("%q: want name=value", part)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "delay" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return f, fmt.Errorf("%q: want a positive duration", part)
+			}
+			f.SlowDelay = d
+			continue
+		}
+		p, err := strconv.ParseFloat(value, 64)
+		if err != nil || p < 0 || p > 1 {
+			return f, fmt.Errorf("%q: want a probability between 0 and 1", part)
+		}
+		switch name {
+		case "timeout":
+			f.Timeout = p
+		case "500", "error":
+			f.ServerError = p
+		case "slow":
+			f.Slow = p
+		default:
+			return f, fmt.Errorf("unknown fault %q (use timeout, 500, slow or delay)", name)
+		}
+	}
+	if f.Timeout+f.ServerError+f.Slow > 1 {
+		return f, fmt.Errorf("fault probabilities add up to more than 1")
+	}
+	return f, nil
+}
+
+// faultTransport injects Faults in front of the real transport.
+type faultTransport struct {
+	next   http.RoundTripper
+	faults Faults
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	r := rand.Float64()
+	switch {
+	case r < t.faults.Timeout:
+		<-ctx.Done()
+		return nil, ctx.Err()
+	case r < t.faults.Timeout+t.faults.ServerError:
+		body := `{"error":"injected fault","message":"injected fault"}`
+		return &http.Response{
+			Status:        "500 Internal Server Error",
+			StatusCode:    http.StatusInternalServerError,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {"application/json"}},
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	case r < t.faults.Timeout+t.faults.ServerError+t.faults.Slow:
+		delay := t.faults.SlowDelay
+		if delay <= 0 {
+			delay = defaultSlowDelay
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// injectFaults wraps the client's transport when fault injection is on.
+func (c *Client) injectFaults() {
+	if c.faults == nil {
+		return
+	}
+	next := c.httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	hc := *c.httpClient
+	hc.Transport = &faultTransport{next: next, faults: *c.faults}
+	c.httpClient = &hc
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+)
+
+const (
+	http3HandshakeTimeout = 3 * time.Second
+	// http3RetryAfter is how long HTTP/3 stays disabled after a failure.
+	http3RetryAfter = 10 * time.Minute
+)
+
+// WithHTTP3 enables the experimental HTTP/3 (QUIC) transport. Requests fall
+// back to HTTP/1.1 or HTTP/2 over TCP when QUIC is unavailable.
+func WithHTTP3() Option {
+	return func(c *Client) {
+		c.http3 = true
+	}
+}
+
+// fallbackTransport tries HTTP/3 first and falls back to TCP on failure.
+type fallbackTransport struct {
+	h3       *http3.Transport
+	fallback http.RoundTripper
+
+	mu            sync.Mutex
+	disabledUntil time.Time
+}
+
+func (c *Client) newHTTP3Transport(fallback *http.Transport) *fallbackTransport {
+	var tlsConf *tls.Config
+	if fallback.TLSClientConfig != nil {
+		tlsConf = fallback.TLSClientConfig.Clone()
+	}
+	h3 := &http3.Transport{
+		TLSClientConfig: tlsConf,
+		QUICConfig:      &quic.Config{HandshakeIdleTimeout: http3HandshakeTimeout},
+		Dial: func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (*quic.Conn, error) {
+			conn, err := c.dialQUIC(ctx, addr, tlsCfg, cfg)
+			if err != nil {
+				return nil, &dialError{err: err}
+			}
+			return conn, nil
+		},
+	}
+	return &fallbackTransport{h3: h3, fallback: fallback}
+}
+
+func (c *Client) dialQUIC(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (*quic.Conn, error) {
+	target, err := c.dialTarget(addr)
+	if err != nil {
+		return nil, err
+	}
+	if c.resolver != nil {
+		host, port, err := net.SplitHostPort(target)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) == nil {
+			ips, err := c.resolver.LookupHost(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+			target = net.JoinHostPort(ips[0], port)
+		}
+	}
+	return quic.DialAddrEarly(ctx, target, tlsCfg, cfg)
+}
+
+// dialError marks a failure to set up the QUIC connection, before any part
+// of a request was sent.
+type dialError struct {
+	err error
+}
+
+func (e *dialError) Error() string { return e.err.Error() }
+func (e *dialError) Unwrap() error { return e.err }
+
+// RoundTrip implements http.RoundTripper.
+func (t *fallbackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" || !t.enabled() {
+		return t.fallback.RoundTrip(req)
+	}
+
+	resp, err := t.h3.RoundTrip(req)
+	if err == nil {
+		return resp, nil
+	}
+	if req.Context().Err() != nil || !canFallback(req, err) {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.disabledUntil = time.Now().Add(http3RetryAfter)
+	t.mu.Unlock()
+
+	retry := req.Clone(req.Context())
+	if req.Body != nil {
+		if req.GetBody == nil {
+			return nil, err
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return t.fallback.RoundTrip(retry)
+}
+
+func (t *fallbackTransport) enabled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Now().After(t.disabledUntil)
+}
+
+// canFallback reports whether repeating the request over TCP is safe: always for
+// idempotent reads, and for writes only when QUIC never got a connection up.
+// An idle timeout or a network error on an established connection may come
+// after the server already acted on the request, so writes don't fall back
+// on those.
+func canFallback(req *http.Request, err error) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	var handshakeErr *quic.HandshakeTimeoutError
+	var dialErr *dialError
+	return errors.As(err, &handshakeErr) || errors.As(err, &dialErr)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/quic-go/quic-go"
+)
+
+func TestCanFallback(t *testing.T) {
+	opErr := &net.OpError{Op: "read", Net: "udp", Err: errors.New("connection refused")}
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		want   bool
+	}{
+		{"read after idle timeout", http.MethodGet, &quic.IdleTimeoutError{}, true},
+		{"read after network error", http.MethodHead, opErr, true},
+		{"write after handshake timeout", http.MethodPut, fmt.Errorf("round trip: %w", &quic.HandshakeTimeoutError{}), true},
+		{"write after dial failure", http.MethodPost, &dialError{err: opErr}, true},
+		{"write after idle timeout", http.MethodPut, &quic.IdleTimeoutError{}, false},
+		{"write after network error", http.MethodPost, opErr, false},
+		{"write after other error", http.MethodDelete, errors.New("stream reset"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://example.com", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := canFallback(req, tt.err); got != tt.want {
+				t.Errorf("canFallback = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Supported languages for server messages.
+const (
+	LangZH = "zh"
+	LangEN = "en"
+)
+
+// WithLanguage sets the preferred language of server messages. It is sent
+// as Accept-Language and picks the matching text when an error payload
+// carries both languages. Unknown languages fall back to Chinese.
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.lang = normalizeLang(lang)
+	}
+}
+
+func normalizeLang(lang string) string {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(lang)), LangEN) {
+		return LangEN
+	}
+	return LangZH
+}
+
+// acceptLanguage is the Accept-Language header for lang.
+func acceptLanguage(lang string) string {
+	if lang == LangEN {
+		return "en,zh-CN;q=0.8"
+	}
+	return "zh-CN,zh;q=0.9,en;q=0.8"
+}
+
+// bilingualSeparators split a single field holding both languages, as in
+// "余额不足 / Insufficient balance".
+var bilingualSeparators = []string{"\n", " / ", " | "}
+
+// localize picks the text in lang among the error and message fields of a
+// payload, splitting bilingual fields. Without a match it returns the first
+// non-empty text, preferring message.
+func localize(lang string, fields ...string) string {
+	var parts []string
+	for _, field := range fields {
+		parts = append(parts, splitBilingual(field)...)
+	}
+	for _, part := range parts {
+		if hasHan(part) == (lang == LangZH) {
+			return part
+		}
+	}
+	if len(parts) > 0 {
+		return parts[0]
+	}
+	return ""
+}
+
+func splitBilingual(s string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	for _, sep := range bilingualSeparators {
+		if !strings.Contains(s, sep) {
+			continue
+		}
+		var parts []string
+		han := 0
+		for _, part := range strings.Split(s, sep) {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+				if hasHan(part) {
+					han++
+				}
+			}
+		}
+		// 只有中英文混合时才视为双语，避免拆开普通的 "a / b" 文本
+		if han > 0 && han < len(parts) {
+			return parts
+		}
+	}
+	return []string{s}
+}
+
+func hasHan(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
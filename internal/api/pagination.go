@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// maxPages bounds how many pages a list call follows, in case a server keeps
+// reporting has_more.
+const maxPages = 100
+
+// Page selects one page of a paginated list. The zero value is the first
+// page.
+type Page struct {
+	// Cursor is the next_cursor of the previous page. Servers that page by
+	// number return none, and Number is sent instead.
+	Cursor string
+	// Number is the 1-based page number; 0 means the first page.
+	Number int
+}
+
+// Pagination is the paging metadata of a list response. Servers that don't
+// paginate omit it, which reads as a single, complete page. The field names
+// aren't in the published API documentation, so a list is also taken as
+// complete when a later page brings nothing new, e.g. because the server
+// ignores the page parameters.
+type Pagination struct {
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Page       int    `json:"page,omitempty"`
+}
+
+// Next returns the page after cur, the page this response answered.
+func (p Pagination) Next(cur Page) Page {
+	if p.NextCursor != "" {
+		return Page{Cursor: p.NextCursor}
+	}
+	n := max(cur.Number, 1)
+	if p.Page > 0 {
+		n = p.Page
+	}
+	return Page{Number: n + 1}
+}
+
+// path appends the page parameters to path. The first page is requested
+// without any, as servers that don't paginate expect.
+func (p Page) path(path string) string {
+	query := url.Values{}
+	switch {
+	case p.Cursor != "":
+		query.Set("cursor", p.Cursor)
+	case p.Number > 1:
+		query.Set("page", strconv.Itoa(p.Number))
+	}
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// GetProvidersPage fetches one page of /api/v1/user/available-providers.
+// The response's Pagination tells whether more pages follow.
+func (c *Client) GetProvidersPage(ctx context.Context, page Page) (*ProvidersResponse, error) {
+	var resp ProvidersResponse
+	if err := c.get(ctx, page.path("/api/v1/user/available-providers"), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAvailableProviders fetches /api/v1/user/available-providers, following
+// every page.
+func (c *Client) GetAvailableProviders(ctx context.Context) (*ProvidersResponse, error) {
+	var all *ProvidersResponse
+	page := Page{}
+	for range maxPages {
+		resp, err := c.GetProvidersPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		if all == nil {
+			all = resp
+		} else {
+			n := len(all.Providers)
+			all.Providers = AppendProviders(all.Providers, resp.Providers)
+			if len(all.Providers) == n {
+				// 后续页没有新的提供商，服务端多半忽略了分页参数，按单页处理
+				all.Pagination = Pagination{}
+				return all, nil
+			}
+		}
+		if !resp.HasMore {
+			all.Pagination = Pagination{}
+			return all, nil
+		}
+		page = resp.Next(page)
+	}
+	return nil, fmt.Errorf("available providers: more than %d pages", maxPages)
+}
+
+// GetProviderAlternatives fetches /api/v1/user/provider-alternatives/{providerID},
+// following every page.
+func (c *Client) GetProviderAlternatives(ctx context.Context, providerID int) ([]AlternativeOption, error) {
+	path := fmt.Sprintf("/api/v1/user/provider-alternatives/%d", providerID)
+	var options []AlternativeOption
+	seen := make(map[int]bool)
+	page := Page{}
+	for range maxPages {
+		var resp AlternativeResponse
+		if err := c.get(ctx, page.path(path), &resp); err != nil {
+			return nil, err
+		}
+		added := false
+		for _, option := range resp.Data {
+			if !seen[option.Alternative.ID] {
+				seen[option.Alternative.ID] = true
+				options = append(options, option)
+				added = true
+			}
+		}
+		if !resp.HasMore || (!added && page != Page{}) {
+			return options, nil
+		}
+		page = resp.Next(page)
+	}
+	return nil, fmt.Errorf("provider %d alternatives: more than %d pages", providerID, maxPages)
+}
+
+// AppendProviders appends the providers of a later page to list, skipping
+// any already in it: entries shift between pages when the catalog changes
+// while it is being paged through.
+func AppendProviders(list, page []ProviderBucket) []ProviderBucket {
+	seen := make(map[int]bool, len(list))
+	for _, bucket := range list {
+		seen[bucket.Provider.ID] = true
+	}
+	for _, bucket := range page {
+		if !seen[bucket.Provider.ID] {
+			seen[bucket.Provider.ID] = true
+			list = append(list, bucket)
+		}
+	}
+	return list
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// pagedServer answers each request to the provider and alternative lists with
+// the next body from pages, recording the query it was sent.
+func pagedServer(t *testing.T, pages ...string) (*Client, *[]string) {
+	t.Helper()
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		body := pages[min(len(queries), len(pages))-1]
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	client, err := NewClient("key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &queries
+}
+
+func providersPage(ids []int, paging string) string {
+	body := `{"providers":[`
+	for i, id := range ids {
+		if i > 0 {
+			body += ","
+		}
+		body += fmt.Sprintf(`{"provider":{"id":%d}}`, id)
+	}
+	return body + "]" + paging + "}"
+}
+
+func alternativesPage(ids []int, paging string) string {
+	body := `{"data":[`
+	for i, id := range ids {
+		if i > 0 {
+			body += ","
+		}
+		body += fmt.Sprintf(`{"alternative":{"id":%d}}`, id)
+	}
+	return body + "]" + paging + "}"
+}
+
+func TestGetAvailableProvidersPaging(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       []string
+		wantIDs     []int
+		wantQueries []string
+	}{
+		{
+			name:        "no paging fields",
+			pages:       []string{providersPage([]int{1, 2}, "")},
+			wantIDs:     []int{1, 2},
+			wantQueries: []string{""},
+		},
+		{
+			name: "cursor",
+			pages: []string{
+				providersPage([]int{1, 2}, `,"has_more":true,"next_cursor":"c2"`),
+				providersPage([]int{3}, `,"has_more":false`),
+			},
+			wantIDs:     []int{1, 2, 3},
+			wantQueries: []string{"", "cursor=c2"},
+		},
+		{
+			name: "page number",
+			pages: []string{
+				providersPage([]int{1}, `,"has_more":true,"page":1`),
+				providersPage([]int{2}, `,"has_more":false,"page":2`),
+			},
+			wantIDs:     []int{1, 2},
+			wantQueries: []string{"", "page=2"},
+		},
+		{
+			name:        "page parameter ignored",
+			pages:       []string{providersPage([]int{1, 2}, `,"has_more":true`)},
+			wantIDs:     []int{1, 2},
+			wantQueries: []string{"", "page=2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, queries := pagedServer(t, tt.pages...)
+			resp, err := client.GetAvailableProviders(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int
+			for _, bucket := range resp.Providers {
+				ids = append(ids, bucket.Provider.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("providers = %v, want %v", ids, tt.wantIDs)
+			}
+			if fmt.Sprintf("%q", *queries) != fmt.Sprintf("%q", tt.wantQueries) {
+				t.Errorf("queries = %q, want %q", *queries, tt.wantQueries)
+			}
+			if resp.HasMore {
+				t.Error("merged response still reports more pages")
+			}
+		})
+	}
+}
+
+func TestGetProviderAlternativesPaging(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       []string
+		wantIDs     []int
+		wantQueries []string
+	}{
+		{
+			name:        "no paging fields",
+			pages:       []string{alternativesPage([]int{11, 12}, "")},
+			wantIDs:     []int{11, 12},
+			wantQueries: []string{""},
+		},
+		{
+			name: "cursor",
+			pages: []string{
+				alternativesPage([]int{11}, `,"has_more":true,"next_cursor":"c2"`),
+				alternativesPage([]int{12}, ""),
+			},
+			wantIDs:     []int{11, 12},
+			wantQueries: []string{"", "cursor=c2"},
+		},
+		{
+			name:        "page parameter ignored",
+			pages:       []string{alternativesPage([]int{11, 12}, `,"has_more":true`)},
+			wantIDs:     []int{11, 12},
+			wantQueries: []string{"", "page=2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, queries := pagedServer(t, tt.pages...)
+			options, err := client.GetProviderAlternatives(context.Background(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int
+			for _, option := range options {
+				ids = append(ids, option.Alternative.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("alternatives = %v, want %v", ids, tt.wantIDs)
+			}
+			if fmt.Sprintf("%q", *queries) != fmt.Sprintf("%q", tt.wantQueries) {
+				t.Errorf("queries = %q, want %q", *queries, tt.wantQueries)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// WithProxy sends every request through the proxy at rawURL, which may use
+// the http, https, socks5 or socks5h (resolve names on the proxy) scheme.
+// Without it the HTTPS_PROXY, HTTP_PROXY, ALL_PROXY and NO_PROXY environment
+// variables apply.
+func WithProxy(rawURL string) Option {
+	return func(c *Client) {
+		c.proxy = rawURL
+	}
+}
+
+// proxyFunc returns the transport's proxy selector.
+func (c *Client) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if c.proxy == "" {
+		return proxyFromEnvironment(), nil
+	}
+	u, err := url.Parse(c.proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https, socks5 or socks5h)", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", c.proxy)
+	}
+	return http.ProxyURL(u), nil
+}
+
+// proxyFromEnvironment is http.ProxyFromEnvironment with ALL_PROXY (or
+// all_proxy) as the fallback for schemes without their own variable, as curl
+// does.
+func proxyFromEnvironment() func(*http.Request) (*url.URL, error) {
+	cfg := httpproxy.FromEnvironment()
+	all := os.Getenv("ALL_PROXY")
+	if all == "" {
+		all = os.Getenv("all_proxy")
+	}
+	if cfg.HTTPSProxy == "" {
+		cfg.HTTPSProxy = all
+	}
+	if cfg.HTTPProxy == "" {
+		cfg.HTTPProxy = all
+	}
+	proxy := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
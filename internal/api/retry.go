@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EndpointClass groups endpoints that share a retry cap.
+type EndpointClass string
+
+const (
+	// ClassRead covers GET endpoints, which are safe to repeat.
+	ClassRead EndpointClass = "read"
+	// ClassWrite covers PUT endpoints that change account state.
+	ClassWrite EndpointClass = "write"
+)
+
+// RetryPolicy controls which failed attempts are retried and how often.
+type RetryPolicy struct {
+	// MaxAttempts caps the total attempts (first try included) per endpoint class.
+	// Classes without an entry are attempted once.
+	MaxAttempts map[EndpointClass]int
+	// RetryStatus lists HTTP status codes worth retrying. Transport errors
+	// (timeouts, resets) are always retried while attempts remain.
+	RetryStatus map[int]bool
+	// Delay is the wait before the first retry; each further retry waits
+	// twice as long as the one before.
+	Delay time.Duration
+	// MaxDelay caps the doubled wait; 0 leaves it uncapped.
+	MaxDelay time.Duration
+	// Jitter randomizes each wait by ±Jitter×Delay (0 to 1).
+	Jitter float64
+}
+
+// DefaultRetryPolicy retries reads once on transport errors and on the
+// transient gateway statuses the upstream proxy tends to return.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: map[EndpointClass]int{ClassRead: 2, ClassWrite: 1},
+		RetryStatus: map[int]bool{502: true, 503: true, 504: true},
+		Delay:       300 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+// WithRetryPolicy overrides the default retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+func (p RetryPolicy) attempts(class EndpointClass) int {
+	if n := p.MaxAttempts[class]; n > 0 {
+		return n
+	}
+	return 1
+}
+
+// shouldRetry reports whether err is worth another attempt.
+func (p RetryPolicy) shouldRetry(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.RetryAfter > 0 && p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+			// 服务器要求等待的时间过长，交给调用方决定何时再试
+			return false
+		}
+		if apiErr.RateLimited() && apiErr.RetryAfter > 0 {
+			return true
+		}
+		return p.RetryStatus[apiErr.StatusCode]
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// backoff returns the wait before the given retry (1 for the first),
+// without jitter.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.Delay
+	for i := 1; i < retry && d > 0; i++ {
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// wait sleeps before the given retry of a request that failed with err, or
+// until ctx is done. A Retry-After from the server is honored as is;
+// otherwise the backoff is jittered.
+func (p RetryPolicy) wait(ctx context.Context, retry int, err error) error {
+	d := p.backoff(retry)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		d = apiErr.RetryAfter
+	} else if p.Jitter > 0 && d > 0 {
+		spread := float64(d) * p.Jitter
+		d += time.Duration((rand.Float64()*2 - 1) * spread)
+	}
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given as delay seconds or as an
+// HTTP date; an absent, malformed or past value yields 0.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
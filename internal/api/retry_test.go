@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"gateway status", context.Background(), &APIError{StatusCode: 503}, true},
+		{"unlisted server error", context.Background(), &APIError{StatusCode: 500}, false},
+		{"client error", context.Background(), &APIError{StatusCode: 400}, false},
+		{"transport error", context.Background(), &url.Error{Op: "Get", URL: "https://x", Err: errors.New("connection reset")}, true},
+		{"other error", context.Background(), errors.New("decode response"), false},
+		{"cancelled", cancelled, &APIError{StatusCode: 503}, false},
+	}
+	p := DefaultRetryPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.shouldRetry(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("shouldRetry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	p := DefaultRetryPolicy()
+	tests := []struct {
+		class EndpointClass
+		want  int
+	}{
+		{ClassRead, 2},
+		{ClassWrite, 1},
+		{"other", 1},
+	}
+	for _, tt := range tests {
+		if got := p.attempts(tt.class); got != tt.want {
+			t.Errorf("attempts(%s) = %d, want %d", tt.class, got, tt.want)
+		}
+	}
+}
+
+// TestClientRetries runs requests against a server that fails the first
+// `fail` attempts with 503 and counts the attempts that reach it.
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		fail    int
+		write   bool
+		want    int
+		wantErr bool
+	}{
+		{"read recovers", 1, false, 2, false},
+		{"read gives up", 5, false, 3, true},
+		{"write is not repeated", 1, true, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				if hits <= tt.fail {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"data":{"provider_id":1}}`))
+			}))
+			defer srv.Close()
+
+			policy := DefaultRetryPolicy()
+			policy.MaxAttempts = map[EndpointClass]int{ClassRead: 3}
+			policy.Delay = time.Millisecond
+			policy.Jitter = 0
+			client, err := NewClient("key", WithBaseURL(srv.URL), WithRetryPolicy(policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.write {
+				_, err = client.SwitchProvider(context.Background(), 1, 2)
+			} else {
+				_, err = client.GetProviderSelection(context.Background(), 1)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if hits != tt.want {
+				t.Errorf("server saw %d attempts, want %d", hits, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		maxDelay time.Duration
+		retry    int
+		want     time.Duration
+	}{
+		{"first retry", 100 * time.Millisecond, time.Second, 1, 100 * time.Millisecond},
+		{"doubles", 100 * time.Millisecond, time.Second, 3, 400 * time.Millisecond},
+		{"capped", 100 * time.Millisecond, time.Second, 5, time.Second},
+		{"far past the cap", 100 * time.Millisecond, time.Second, 100, time.Second},
+		{"uncapped", 100 * time.Millisecond, 0, 5, 1600 * time.Millisecond},
+		{"no delay", 0, time.Second, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RetryPolicy{Delay: tt.delay, MaxDelay: tt.maxDelay}
+			if got := p.backoff(tt.retry); got != tt.want {
+				t.Errorf("backoff(%d) = %s, want %s", tt.retry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"7", 7 * time.Second},
+		{" 7 ", 7 * time.Second},
+		{"-3", 0},
+		{"Tue, 10 Mar 2026 12:00:30 GMT", 30 * time.Second},
+		{"Tue, 10 Mar 2026 11:59:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestShouldRetryRetryAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want bool
+	}{
+		{"rate limited with a wait", &APIError{StatusCode: 429, RetryAfter: 2 * time.Second}, true},
+		{"rate limited without a wait", &APIError{StatusCode: 429}, false},
+		{"wait beyond the cap", &APIError{StatusCode: 429, RetryAfter: time.Minute}, false},
+		{"gateway status with a long wait", &APIError{StatusCode: 503, RetryAfter: time.Minute}, false},
+	}
+	p := DefaultRetryPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.shouldRetry(context.Background(), tt.err); got != tt.want {
+				t.Errorf("shouldRetry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitHonorsRetryAfter(t *testing.T) {
+	// 退避时间远长于服务器要求的等待，按 Retry-After 等待时测试很快结束
+	p := RetryPolicy{Delay: time.Hour, Jitter: 0.5}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := p.wait(ctx, 1, &APIError{StatusCode: 429, RetryAfter: 10 * time.Millisecond}); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("waited %s, want at least the 10ms Retry-After", elapsed)
+	}
+}
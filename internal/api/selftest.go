@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Check is the outcome of calling one endpoint during a self-test.
+type Check struct {
+	Method     string        `json:"method"`
+	Endpoint   string        `json:"endpoint"` // path with numeric IDs replaced by {id}
+	StatusCode int           `json:"status_code"`
+	Latency    time.Duration `json:"latency_ns"`
+	Error      string        `json:"error,omitempty"`
+	// UnknownFields are response fields the client's structs don't have.
+	UnknownFields []string `json:"unknown_fields,omitempty"`
+	// MissingFields are struct fields the response didn't include.
+	MissingFields []string `json:"missing_fields,omitempty"`
+}
+
+// OK reports whether the endpoint answered and decoded cleanly.
+func (c Check) OK() bool {
+	return c.Error == ""
+}
+
+// SelfTest calls every GET endpoint with the client's key, using the first
+// provider for the per-provider ones, and compares each response with the
+// struct it decodes into. With write set it also re-applies the current
+// balance preference and the first provider's current selection, which
+// change nothing but exercise the PUT endpoints.
+func (c *Client) SelfTest(ctx context.Context, write bool) []Check {
+	var checks []Check
+
+	var profile Profile
+	checks = append(checks, c.check(ctx, http.MethodGet, "/api/v1/auth/profile", nil, &profile))
+	var providers ProvidersResponse
+	checks = append(checks, c.check(ctx, http.MethodGet, "/api/v1/user/available-providers", nil, &providers))
+
+	var selection selectionEnvelope
+	if len(providers.Providers) > 0 {
+		base := fmt.Sprintf("/api/v1/user/provider-alternatives/%d", providers.Providers[0].Provider.ID)
+		checks = append(checks, c.check(ctx, http.MethodGet, base, nil, &AlternativeResponse{}))
+		checks = append(checks, c.check(ctx, http.MethodGet, base+"/selection", nil, &selection))
+	}
+	if !write {
+		return checks
+	}
+
+	if profile.BalancePreference != "" {
+		payload := map[string]string{"balance_preference": profile.BalancePreference}
+		checks = append(checks, c.check(ctx, http.MethodPut, "/api/v1/user/balance-preference", payload, &BalancePreferenceResponse{}))
+	}
+	if sel := selection.Data; sel.ProviderID != 0 && sel.SelectedAlternativeID != 0 {
+		path := fmt.Sprintf("/api/v1/user/provider-alternatives/%d/selection", sel.ProviderID)
+		payload := map[string]int{"selected_alternative_id": sel.SelectedAlternativeID}
+		checks = append(checks, c.check(ctx, http.MethodPut, path, payload, &selectionEnvelope{}))
+	}
+	return checks
+}
+
+// check calls one endpoint, decoding the response into out and comparing
+// its fields with out's type.
+func (c *Client) check(ctx context.Context, method, path string, body any, out any) Check {
+	result := Check{Method: method, Endpoint: endpointPattern(path)}
+	var raw json.RawMessage
+	start := time.Now()
+	var err error
+	if method == http.MethodPut {
+		err = c.put(ctx, path, body, &raw)
+	} else {
+		err = c.get(ctx, path, &raw)
+	}
+	result.Latency = time.Since(start)
+
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		result.StatusCode = apiErr.StatusCode
+		result.Error = err.Error()
+		return result
+	case err != nil:
+		result.Error = err.Error()
+		return result
+	}
+	result.StatusCode = http.StatusOK
+	result.compareBody(raw, out)
+	return result
+}
+
+// compareBody decodes raw into out and records where their fields differ.
+func (c *Check) compareBody(raw []byte, out any) {
+	if err := json.Unmarshal(raw, out); err != nil {
+		c.Error = fmt.Sprintf("decode response: %v", err)
+		return
+	}
+	diff := fieldDiff{unknown: map[string]bool{}, missing: map[string]bool{}}
+	diff.compare(raw, reflect.TypeOf(out), "")
+	c.UnknownFields = sortedKeys(diff.unknown)
+	c.MissingFields = sortedKeys(diff.missing)
+}
+
+// fieldDiff collects the dotted paths where a JSON document and a Go type
+// disagree on which object fields exist.
+type fieldDiff struct {
+	unknown map[string]bool
+	missing map[string]bool
+}
+
+func (d fieldDiff) compare(raw json.RawMessage, t reflect.Type, prefix string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil || obj == nil {
+			return
+		}
+		known := make(map[string]bool)
+		d.compareFields(obj, t, prefix, known, false)
+		for name := range obj {
+			if !known[name] {
+				d.unknown[prefix+name] = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return
+		}
+		for _, item := range items {
+			d.compare(item, t.Elem(), strings.TrimSuffix(prefix, ".")+"[].")
+		}
+	}
+}
+
+// compareFields checks the fields of struct type t against obj, marking
+// each field name it knows in known. Untagged embedded structs are inlined
+// the way encoding/json does; when optional is set, as it is for those, the
+// fields only count as missing if the response has at least one of them.
+func (d fieldDiff) compareFields(obj map[string]json.RawMessage, t reflect.Type, prefix string, known map[string]bool, optional bool) {
+	var missing []string
+	present := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			d.compareFields(obj, field.Type, prefix, known, true)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = true
+		value, ok := obj[name]
+		if !ok {
+			missing = append(missing, prefix+name)
+			continue
+		}
+		present = true
+		d.compare(value, field.Type, prefix+name+".")
+	}
+	if optional && !present {
+		return
+	}
+	for _, name := range missing {
+		d.missing[name] = true
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
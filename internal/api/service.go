@@ -0,0 +1,38 @@
+package api
+
+import "context"
+
+// Service is what the interface and the snapshot tools need from the API.
+// *Client implements it; apitest.Fake implements it in memory for tests.
+type Service interface {
+	GetProfile(ctx context.Context) (*Profile, error)
+	GetAvailableProviders(ctx context.Context) (*ProvidersResponse, error)
+	GetProvidersPage(ctx context.Context, page Page) (*ProvidersResponse, error)
+	GetProviderAlternatives(ctx context.Context, providerID int) ([]AlternativeOption, error)
+	GetProviderSelection(ctx context.Context, providerID int) (*ProviderSelection, error)
+	SwitchProvider(ctx context.Context, providerID int, alternativeID int) (*ProviderSelection, error)
+	UpdateBalancePreference(ctx context.Context, preference string) (*BalancePreferenceResponse, error)
+	CheckConsistency(ctx context.Context) ([]Mismatch, error)
+	// Subscribe streams live updates; it returns ErrStreamUnsupported when
+	// the server has no event stream.
+	Subscribe(ctx context.Context) (<-chan StreamEvent, error)
+
+	// BaseURL returns the API base URL, which also hosts the web console.
+	BaseURL() string
+	// InvalidateCache drops cached responses before a manual refresh.
+	InvalidateCache()
+}
+
+// Diagnostics is the request history behind the diagnostics views. It is
+// optional: the interface type-asserts a Service for it and shows empty
+// views when the service keeps no history.
+type Diagnostics interface {
+	Stats() SessionStats
+	RecentErrors() []RequestError
+	RecentRequests() []RequestRecord
+}
+
+var (
+	_ Service     = (*Client)(nil)
+	_ Diagnostics = (*Client)(nil)
+)
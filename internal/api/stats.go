@@ -0,0 +1,50 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// SessionStats summarizes the HTTP traffic of a client since it was created.
+type SessionStats struct {
+	Requests      int   // HTTP attempts, including retries
+	BytesSent     int64 // request bodies
+	BytesReceived int64 // response bodies after decompression
+	Conditional   int   // GET requests sent with cache validators
+	CacheHits     int   // conditional requests answered with 304
+	TotalLatency  time.Duration
+}
+
+// AverageLatency is the mean round-trip time per request.
+func (s SessionStats) AverageLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Requests)
+}
+
+// CacheHitRate is the share of conditional requests served from the cache.
+func (s SessionStats) CacheHitRate() float64 {
+	if s.Conditional == 0 {
+		return 0
+	}
+	return float64(s.CacheHits) / float64(s.Conditional)
+}
+
+type sessionCounters struct {
+	mu    sync.Mutex
+	stats SessionStats
+}
+
+func (sc *sessionCounters) record(update func(*SessionStats)) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	update(&sc.stats)
+}
+
+// Stats returns the traffic counters of this session.
+func (c *Client) Stats() SessionStats {
+	c.counters.mu.Lock()
+	defer c.counters.mu.Unlock()
+	return c.counters.stats
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// streamPath is the server-sent events endpoint pushing account updates.
+// The public API doesn't document it; servers without it answer 404 and the
+// interface keeps polling.
+const streamPath = "/api/v1/user/events"
+
+// streamIdleTimeout ends a stream that has sent nothing, not even a
+// keep-alive comment, for this long. A connection dropped without a FIN
+// would otherwise look connected forever while polling stays paused.
+const streamIdleTimeout = 90 * time.Second
+
+// ErrStreamUnsupported is returned by Subscribe when the server has no event
+// stream, so callers keep polling instead.
+var ErrStreamUnsupported = errors.New("event stream not supported by the server")
+
+// Stream event types.
+const (
+	// EventProfile carries the whole profile after a balance or
+	// preference change.
+	EventProfile = "profile"
+	// EventSelection carries a provider's selection after it changed,
+	// e.g. from another client.
+	EventSelection = "selection"
+)
+
+// StreamEvent is one update pushed by the server. Exactly one of Profile and
+// Selection is set, according to Type.
+type StreamEvent struct {
+	Type      string
+	Profile   *Profile
+	Selection *ProviderSelection
+}
+
+// Subscribe opens the server-sent events stream of account updates. The
+// channel is closed when the stream ends or ctx is cancelled; callers
+// reconnect as they see fit. The stream also ends after streamIdleTimeout
+// without any data. Events of unknown types are skipped.
+func (c *Client) Subscribe(ctx context.Context) (<-chan StreamEvent, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, streamPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.recordTraffic(req, nil, 0, time.Since(start))
+		c.recordFailure(req, 0, err.Error(), "")
+		return nil, err
+	}
+	c.recordTraffic(req, resp, 0, time.Since(start))
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, ErrStreamUnsupported
+	}
+	if resp.StatusCode >= 300 {
+		resp.Body.Close()
+		c.recordFailure(req, resp.StatusCode, "", "")
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Method:     req.Method,
+			Path:       req.URL.RequestURI(),
+			Time:       start,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		resp.Body.Close()
+		return nil, ErrStreamUnsupported
+	}
+
+	events := make(chan StreamEvent)
+	body := resp.Body
+	idle := time.AfterFunc(streamIdleTimeout, func() { body.Close() })
+	resp.Body = idleBody{ReadCloser: body, timer: idle}
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		defer idle.Stop()
+		readEvents(resp, func(name, data string) bool {
+			ev, err := decodeEvent(name, data)
+			if err != nil {
+				c.recordFailure(req, resp.StatusCode, err.Error(), data)
+				return true
+			}
+			if ev.Type == "" {
+				return true
+			}
+			select {
+			case events <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return events, nil
+}
+
+// idleBody pushes the idle deadline back whenever the stream delivers data.
+type idleBody struct {
+	io.ReadCloser
+	timer *time.Timer
+}
+
+func (b idleBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.timer.Reset(streamIdleTimeout)
+	}
+	return n, err
+}
+
+// readEvents parses the text/event-stream body, calling dispatch for every
+// complete event until the body ends or dispatch returns false. Comments
+// (keep-alives) and the id and retry fields are ignored.
+func readEvents(resp *http.Response, dispatch func(name, data string) bool) {
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	var name string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 && !dispatch(name, strings.Join(data, "\n")) {
+				return
+			}
+			name, data = "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
+
+// decodeEvent turns one event into a StreamEvent; unknown types yield an
+// event with an empty Type.
+func decodeEvent(name, data string) (StreamEvent, error) {
+	switch name {
+	case EventProfile:
+		var profile Profile
+		if err := json.Unmarshal([]byte(data), &profile); err != nil {
+			return StreamEvent{}, fmt.Errorf("decode %s event: %w", name, err)
+		}
+		return StreamEvent{Type: name, Profile: &profile}, nil
+	case EventSelection:
+		var selection ProviderSelection
+		if err := json.Unmarshal([]byte(data), &selection); err != nil {
+			return StreamEvent{}, fmt.Errorf("decode %s event: %w", name, err)
+		}
+		return StreamEvent{Type: name, Selection: &selection}, nil
+	}
+	return StreamEvent{}, nil
+}
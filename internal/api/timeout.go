@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultAttemptTimeout = 10 * time.Second
+	defaultCallTimeout    = 30 * time.Second
+)
+
+// Timeouts bounds how long API calls may take.
+type Timeouts struct {
+	// Attempt bounds one HTTP exchange, body included.
+	Attempt time.Duration
+	// Call bounds a whole method call, retries and the waits between them
+	// included, per endpoint class. Classes without an entry use the
+	// default.
+	Call map[EndpointClass]time.Duration
+}
+
+// DefaultTimeouts leaves room for every default read attempt to use its
+// full attempt timeout.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Attempt: defaultAttemptTimeout,
+		Call:    map[EndpointClass]time.Duration{ClassRead: defaultCallTimeout, ClassWrite: defaultCallTimeout},
+	}
+}
+
+// WithTimeout bounds every call, retries included, to d.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts.Call = map[EndpointClass]time.Duration{ClassRead: d, ClassWrite: d}
+	}
+}
+
+// WithOperationTimeout bounds the calls of one endpoint class to d.
+func WithOperationTimeout(class EndpointClass, d time.Duration) Option {
+	return func(c *Client) {
+		calls := make(map[EndpointClass]time.Duration, len(c.timeouts.Call)+1)
+		for k, v := range c.timeouts.Call {
+			calls[k] = v
+		}
+		calls[class] = d
+		c.timeouts.Call = calls
+	}
+}
+
+// WithAttemptTimeout bounds each HTTP attempt to d.
+func WithAttemptTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts.Attempt = d
+	}
+}
+
+type callTimeoutKey struct{}
+
+// WithCallTimeout returns a context that makes the calls made with it use d
+// instead of the client's call timeout, e.g. to give one slow operation more
+// time.
+func WithCallTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutKey{}, d)
+}
+
+// callContext applies the call timeout for class to ctx.
+func (t Timeouts) callContext(ctx context.Context, class EndpointClass) (context.Context, context.CancelFunc) {
+	d, ok := ctx.Value(callTimeoutKey{}).(time.Duration)
+	if !ok {
+		d = t.Call[class]
+	}
+	if d <= 0 {
+		d = defaultCallTimeout
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// attemptContext applies the attempt timeout to ctx.
+func (t Timeouts) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.Attempt <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, t.Attempt)
+}
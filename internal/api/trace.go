@@ -0,0 +1,58 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// Trace is one request attempt as reported to a Tracer.
+type Trace struct {
+	Time         time.Time // when the request was sent
+	Method       string
+	Path         string // request path and query
+	StatusCode   int    // 0 for transport errors
+	Duration     time.Duration
+	Err          string // transport error, if any
+	RequestBody  string
+	ResponseBody string
+}
+
+// Tracer receives every request attempt the client makes, retries included.
+// Trace is called from the goroutine that made the request.
+type Tracer interface {
+	Trace(Trace)
+}
+
+// WithTracer reports each request attempt to t. The API key header is never
+// part of a trace.
+func WithTracer(t Tracer) Option {
+	return func(c *Client) {
+		c.tracer = t
+	}
+}
+
+func (c *Client) trace(req *http.Request, start time.Time, status int, body []byte, err error) {
+	if c.tracer == nil {
+		return
+	}
+	t := Trace{
+		Time:         start,
+		Method:       req.Method,
+		Path:         req.URL.RequestURI(),
+		StatusCode:   status,
+		Duration:     time.Since(start),
+		ResponseBody: string(body),
+	}
+	if err != nil {
+		t.Err = err.Error()
+	}
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			raw, _ := io.ReadAll(rc)
+			rc.Close()
+			t.RequestBody = string(raw)
+		}
+	}
+	c.tracer.Trace(t)
+}
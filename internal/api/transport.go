@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+// WithConnectAddress dials addr ("ip" or "ip:port") for every request while the
+// URL host is still used for the Host header and TLS SNI. This lets users behind
+// DNS pollution reach co.yes.vg through a known-good IP.
+func WithConnectAddress(addr string) Option {
+	return func(c *Client) {
+		c.connectAddr = addr
+	}
+}
+
+// WithResolver resolves hostnames through the given DNS server ("ip" or "ip:port")
+// instead of the system resolver.
+func WithResolver(server string) Option {
+	return func(c *Client) {
+		c.dnsServer = server
+	}
+}
+
+// configureTransport installs a transport honoring the proxy, the TLS
+// config, the dial overrides and the optional HTTP/3 mode. A custom
+// transport is left alone when none of them is set.
+func (c *Client) configureTransport() error {
+	custom := c.httpClient.Transport != nil
+	if custom && c.connectAddr == "" && c.dnsServer == "" && !c.http3 && c.proxy == "" && c.tlsConfig == nil {
+		return nil
+	}
+
+	var transport *http.Transport
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return errors.New("proxy, TLS, connect address, resolver and http3 options require an *http.Transport")
+	}
+	if c.tlsConfig != nil {
+		transport.TLSClientConfig = c.tlsConfig.Clone()
+	}
+
+	switch {
+	case c.proxy != "" && c.connectAddr != "":
+		return errors.New("a proxy cannot be combined with a connect address")
+	case c.proxy != "" && c.http3:
+		return errors.New("a proxy cannot be combined with http3")
+	case c.connectAddr != "":
+		// 直连指定 IP 时绕开代理，环境变量中的代理不适用
+		transport.Proxy = nil
+	default:
+		proxy, err := c.proxyFunc()
+		if err != nil {
+			return err
+		}
+		transport.Proxy = proxy
+	}
+
+	if c.connectAddr == "" && c.dnsServer == "" && !c.http3 {
+		hc := *c.httpClient
+		hc.Transport = transport
+		c.httpClient = &hc
+		return nil
+	}
+
+	if c.dnsServer != "" {
+		server := withDefaultPort(c.dnsServer, "53")
+		c.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				d := net.Dialer{Timeout: dialTimeout}
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second, Resolver: c.resolver}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		target, err := c.dialTarget(addr)
+		if err != nil {
+			return nil, err
+		}
+		return dialer.DialContext(ctx, network, target)
+	}
+
+	hc := *c.httpClient
+	hc.Transport = transport
+	if c.http3 {
+		hc.Transport = c.newHTTP3Transport(transport)
+	}
+	c.httpClient = &hc
+	return nil
+}
+
+// dialTarget applies the connect address override to addr.
+func (c *Client) dialTarget(addr string) (string, error) {
+	if c.connectAddr == "" {
+		return addr, nil
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	return withDefaultPort(c.connectAddr, port), nil
+}
+
+// withDefaultPort appends port to addr when addr has none.
+func withDefaultPort(addr, port string) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	return net.JoinHostPort(addr, port)
+}
@@ -0,0 +1,27 @@
+// Package appdir locates the per-user directory that holds yescode-tui files.
+package appdir
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const name = "yescode-tui"
+
+// Dir returns the application directory, e.g. ~/.config/yescode-tui on Linux.
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, name), nil
+}
+
+// Path joins file onto the application directory.
+func Path(file string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, file), nil
+}
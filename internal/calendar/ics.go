@@ -0,0 +1,187 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+const prodID = "-//yescode-tui//calendar//ZH"
+
+// Options tunes the generated calendar.
+type Options struct {
+	// RemindDays is how many days before expiry the reminder fires.
+	RemindDays int
+	// Now anchors recurring reset events, and its location is the time zone
+	// the expiry date is given in; zero means time.Now().
+	Now time.Time
+}
+
+// expiryFormats lists the layouts the API is known to use for subscription_expiry.
+var expiryFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z",
+	time.DateOnly,
+}
+
+// Build renders an iCalendar document with the subscription expiry and the
+// weekly/monthly spend limit resets of the given profile.
+//
+// Weekly limits are assumed to reset on Monday and monthly limits on the 1st,
+// matching how current_week_spend / current_month_spend are reported; the
+// API doesn't say when limits reset, so the event descriptions state it.
+func Build(p *api.Profile, opts Options) string {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	account := p.Username
+	if account == "" {
+		account = p.Email
+	}
+
+	w := &writer{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + prodID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("X-WR-CALNAME:YesCode")
+
+	plan := p.SubscriptionPlan
+	if expiry, ok := parseExpiry(p.SubscriptionExpiry, now.Location()); ok {
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:yescode-expiry-%s-%s@yescode-tui", uidPart(account), expiry.Format("20060102")))
+		w.line("DTSTAMP:" + stamp)
+		w.line("DTSTART;VALUE=DATE:" + expiry.Format("20060102"))
+		w.line("SUMMARY:" + escape(i18n.Tf("YesCode 订阅到期：%s", planName(plan))))
+		w.line("DESCRIPTION:" + escape(i18n.Tf("账户 %s 的订阅计划 %s ($%.2f) 将于当日到期，请及时续费。", account, planName(plan), plan.Price)))
+		w.line("TRANSP:TRANSPARENT")
+		if opts.RemindDays > 0 {
+			w.alarm(fmt.Sprintf("-P%dD", opts.RemindDays), i18n.T("YesCode 订阅即将到期"))
+		}
+		w.alarm("-PT0M", i18n.T("YesCode 订阅今日到期"))
+		w.line("END:VEVENT")
+	}
+
+	if plan.WeeklyLimit > 0 {
+		start := nextWeekday(now, time.Monday)
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:yescode-weekly-reset-%s@yescode-tui", uidPart(account)))
+		w.line("DTSTAMP:" + stamp)
+		w.line("DTSTART;VALUE=DATE:" + start.Format("20060102"))
+		w.line("RRULE:FREQ=WEEKLY;BYDAY=MO")
+		w.line("SUMMARY:" + escape(i18n.T("YesCode 周额度重置")))
+		w.line("DESCRIPTION:" + escape(i18n.Tf("每周额度 $%.2f 重置。按每周一重置推算，服务端未提供重置时间，请以控制台为准。", plan.WeeklyLimit)))
+		w.line("TRANSP:TRANSPARENT")
+		w.line("END:VEVENT")
+	}
+
+	if plan.MonthlySpendLimit > 0 {
+		start := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:yescode-monthly-reset-%s@yescode-tui", uidPart(account)))
+		w.line("DTSTAMP:" + stamp)
+		w.line("DTSTART;VALUE=DATE:" + start.Format("20060102"))
+		w.line("RRULE:FREQ=MONTHLY;BYMONTHDAY=1")
+		w.line("SUMMARY:" + escape(i18n.T("YesCode 月额度重置")))
+		w.line("DESCRIPTION:" + escape(i18n.Tf("每月额度 $%.2f 重置。按每月 1 日重置推算，服务端未提供重置时间，请以控制台为准。", plan.MonthlySpendLimit)))
+		w.line("TRANSP:TRANSPARENT")
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return w.String()
+}
+
+// parseExpiry reads subscription_expiry as a time in loc, so the all-day
+// expiry event falls on the local date. A bare date is taken as is.
+func parseExpiry(s string, loc *time.Location) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range expiryFormats {
+		if layout == time.DateOnly {
+			if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+				return t, true
+			}
+			continue
+		}
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.In(loc), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func planName(plan api.PlanInfo) string {
+	if plan.Name == "" {
+		return i18n.T("订阅")
+	}
+	return plan.Name
+}
+
+// nextWeekday returns the date of the next given weekday strictly after now.
+func nextWeekday(now time.Time, day time.Weekday) time.Time {
+	delta := (int(day) - int(now.Weekday()) + 7) % 7
+	if delta == 0 {
+		delta = 7
+	}
+	d := now.AddDate(0, 0, delta)
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, now.Location())
+}
+
+func uidPart(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "account"
+	}
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '-'
+	}, s)
+}
+
+// escape applies RFC 5545 TEXT escaping.
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+type writer struct {
+	b strings.Builder
+}
+
+func (w *writer) alarm(trigger, description string) {
+	w.line("BEGIN:VALARM")
+	w.line("ACTION:DISPLAY")
+	w.line("TRIGGER:" + trigger)
+	w.line("DESCRIPTION:" + escape(description))
+	w.line("END:VALARM")
+}
+
+// line writes a content line, folding it at 75 octets without splitting runes.
+func (w *writer) line(s string) {
+	const limit = 75
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			w.b.WriteString("\r\n ")
+			width = 1
+		}
+		w.b.WriteRune(r)
+		width += size
+	}
+	w.b.WriteString("\r\n")
+}
+
+func (w *writer) String() string {
+	return w.b.String()
+}
@@ -0,0 +1,36 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExpiry(t *testing.T) {
+	east := time.FixedZone("UTC+8", 8*60*60)
+	west := time.FixedZone("UTC-5", -5*60*60)
+	tests := []struct {
+		value string
+		loc   *time.Location
+		want  string
+	}{
+		{"2026-11-01T16:00:00Z", east, "20261102"},
+		{"2026-11-01T16:00:00Z", west, "20261101"},
+		{"2026-11-01T03:00:00Z", west, "20261031"},
+		{"2026-11-01T16:00:00+08:00", west, "20261101"},
+		{"2026-11-01", east, "20261101"},
+		{"2026-11-01", west, "20261101"},
+	}
+	for _, tt := range tests {
+		got, ok := parseExpiry(tt.value, tt.loc)
+		if !ok {
+			t.Errorf("parseExpiry(%q) failed", tt.value)
+			continue
+		}
+		if date := got.Format("20060102"); date != tt.want {
+			t.Errorf("parseExpiry(%q) in %s = %s, want %s", tt.value, tt.loc, date, tt.want)
+		}
+	}
+	if _, ok := parseExpiry("next month", time.UTC); ok {
+		t.Error("parseExpiry of an unknown format: want false")
+	}
+}
@@ -0,0 +1,256 @@
+// Package debuglog records API request traces to a size-rotated file and
+// keeps the most recent ones in memory for the in-app log viewer.
+package debuglog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/sealed"
+)
+
+const (
+	// MaxSize is the size at which the log file is rotated.
+	MaxSize = 1 << 20
+	// Keep is how many rotated files are kept next to the current one
+	// (debug.log.1 … debug.log.3).
+	Keep = 3
+	// recentSize bounds the traces kept in memory.
+	recentSize = 200
+	// timeLayout stamps each line of the file.
+	timeLayout = "2006-01-02T15:04:05.000Z07:00"
+	// maxBodyBytes caps each logged body.
+	maxBodyBytes = 4096
+)
+
+// secretWords mark JSON fields whose values never reach the log.
+var secretWords = []string{"key", "token", "secret", "password", "email"}
+
+// Log writes traces to a file and remembers the latest ones. It implements
+// api.Tracer and is safe for concurrent use.
+type Log struct {
+	path   string
+	bodies bool
+	box    *sealed.Box
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	recent []api.Trace
+}
+
+// Open appends to the log at path. With bodies, request and response bodies
+// are logged too, with secret fields redacted; otherwise only method, path,
+// status and duration are.
+func Open(path string, bodies bool) (*Log, error) {
+	return OpenSealed(path, bodies, nil)
+}
+
+// OpenSealed is Open with every trace written to the file encrypted by box,
+// one sealed line per trace; `yc decrypt` prints the file in the clear.
+func OpenSealed(path string, bodies bool, box *sealed.Box) (*Log, error) {
+	l := &Log{path: path, bodies: bodies, box: box}
+	if err := l.openFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Path returns the current log file.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Bodies reports whether bodies are logged.
+func (l *Log) Bodies() bool {
+	return l.bodies
+}
+
+// Trace records one request attempt.
+func (l *Log) Trace(t api.Trace) {
+	if l.bodies {
+		t.RequestBody = Redact(t.RequestBody)
+		t.ResponseBody = Redact(t.ResponseBody)
+	} else {
+		t.RequestBody, t.ResponseBody = "", ""
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recent = append(l.recent, t)
+	if len(l.recent) > recentSize {
+		l.recent = l.recent[len(l.recent)-recentSize:]
+	}
+
+	l.write(Format(t))
+}
+
+// Logf records a note about a problem outside the requests, such as a local
+// state file that couldn't be read. Notes only go to the file. A nil Log
+// drops them.
+func (l *Log) Logf(format string, args ...any) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.write(fmt.Sprintf("%s note %s\n", time.Now().Format(timeLayout), fmt.Sprintf(format, args...)))
+}
+
+// write appends line to the file, sealing it first when the log is
+// encrypted. l.mu must be held.
+func (l *Log) write(line string) {
+	if l.box != nil {
+		blob, err := l.box.SealText([]byte(strings.TrimSuffix(line, "\n")))
+		if err != nil {
+			return
+		}
+		line = string(blob) + "\n"
+	}
+	if l.size+int64(len(line)) > MaxSize {
+		// 轮转失败时丢弃这条记录，不影响界面
+		l.rotate()
+	}
+	if l.file == nil {
+		return
+	}
+	n, _ := l.file.WriteString(line)
+	l.size += int64(n)
+}
+
+// Recent returns the latest traces, oldest first.
+func (l *Log) Recent() []api.Trace {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]api.Trace(nil), l.recent...)
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Format renders t as it appears in the log file: one summary line, then
+// the bodies indented below it when present.
+func Format(t api.Trace) string {
+	var b strings.Builder
+	status := "---"
+	if t.StatusCode != 0 {
+		status = fmt.Sprint(t.StatusCode)
+	}
+	fmt.Fprintf(&b, "%s %-4s %s %s %s", t.Time.Format(timeLayout), t.Method, t.Path, status, t.Duration.Round(time.Millisecond))
+	if t.Err != "" {
+		fmt.Fprintf(&b, " error=%q", t.Err)
+	}
+	b.WriteString("\n")
+	if t.RequestBody != "" {
+		fmt.Fprintf(&b, "  > %s\n", t.RequestBody)
+	}
+	if t.ResponseBody != "" {
+		fmt.Fprintf(&b, "  < %s\n", t.ResponseBody)
+	}
+	return b.String()
+}
+
+// Redact masks secret fields of a JSON body, compacts it onto one line and
+// caps its length. Bodies that aren't JSON are only capped.
+func Redact(body string) string {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err == nil {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(redactValue(v)); err == nil {
+			body = strings.TrimSpace(buf.String())
+		}
+	} else {
+		body = strings.Join(strings.Fields(body), " ")
+	}
+	if len(body) > maxBodyBytes {
+		// 在字符边界截断，避免切开多字节字符
+		cut := maxBodyBytes
+		for cut > 0 && !utf8.RuneStart(body[cut]) {
+			cut--
+		}
+		body = body[:cut] + "…"
+	}
+	return body
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if isSecret(k) {
+				v[k] = "<已移除>"
+				continue
+			}
+			v[k] = redactValue(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+	}
+	return v
+}
+
+func isSecret(field string) bool {
+	lower := strings.ToLower(field)
+	for _, word := range secretWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Log) openFile() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file, l.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts debug.log to debug.log.1, dropping the oldest file, and
+// starts a new one.
+func (l *Log) rotate() {
+	if l.file != nil {
+		l.file.Close()
+		l.file = nil
+	}
+	for i := Keep - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+	}
+	os.Rename(l.path, l.path+".1")
+	l.openFile()
+}
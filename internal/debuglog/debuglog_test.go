@@ -0,0 +1,67 @@
+package debuglog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", "  ", ""},
+		{"secret fields", `{"api_key":"sk-1","data":{"token":"t","name":"x"}}`, `{"api_key":"<已移除>","data":{"name":"x","token":"<已移除>"}}`},
+		{"not json", "bad\n  gateway", "bad gateway"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.body); got != tt.want {
+				t.Errorf("Redact = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactTruncatesOnRuneBoundary(t *testing.T) {
+	// 每个汉字 3 字节，maxBodyBytes 处落在字符中间
+	for _, prefix := range []string{"", "a", "ab"} {
+		body := prefix + strings.Repeat("余", maxBodyBytes)
+		got := Redact(body)
+		if !utf8.ValidString(got) {
+			t.Fatalf("prefix %q: Redact cut a character in half", prefix)
+		}
+		if !strings.HasSuffix(got, "…") || len(got)-len("…") > maxBodyBytes {
+			t.Errorf("prefix %q: Redact kept %d bytes, want at most %d and an ellipsis", prefix, len(got)-len("…"), maxBodyBytes)
+		}
+		if len(got)-len("…") <= maxBodyBytes-utf8.UTFMax {
+			t.Errorf("prefix %q: Redact kept only %d bytes", prefix, len(got)-len("…"))
+		}
+	}
+}
+
+func TestLogf(t *testing.T) {
+	var nilLog *Log
+	nilLog.Logf("dropped %d", 1)
+
+	l, err := Open(filepath.Join(t.TempDir(), "debug.log"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Logf("board moved to %s", "instances.json.bad")
+	l.Close()
+	raw, err := os.ReadFile(l.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line := string(raw); !strings.HasSuffix(line, " note board moved to instances.json.bad\n") {
+		t.Errorf("log file = %q, want the note", line)
+	}
+	if len(l.Recent()) != 0 {
+		t.Error("note kept among the recent traces")
+	}
+}
@@ -0,0 +1,155 @@
+// Package demo serves built-in sample data for `yc --demo`, so the interface
+// can be previewed and recorded without an API key.
+package demo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/api/apitest"
+	"yescode-tui/internal/history"
+)
+
+//go:embed data.json
+var dataJSON []byte
+
+type data struct {
+	Profile      api.Profile                     `json:"profile"`
+	Providers    api.ProvidersResponse           `json:"providers"`
+	Alternatives map[int][]api.AlternativeOption `json:"alternatives"`
+	// Selected maps provider IDs to their selected alternative.
+	Selected map[int]int `json:"selected"`
+}
+
+const (
+	// historyDays is how much spend history the demo starts with.
+	historyDays = 14
+	// subscriptionBalance and paygStart are the balances before the
+	// generated history's spend.
+	subscriptionBalance = 30
+	paygStart           = 40
+)
+
+// New returns a client serving the sample data and a history store seeded
+// with two weeks of hourly samples ending at now. The profile's counters match
+// the latest sample, and switches and preference changes apply in memory.
+func New(now time.Time) (*apitest.Fake, *history.Store) {
+	var d data
+	if err := json.Unmarshal(dataJSON, &d); err != nil {
+		panic("demo: invalid data.json: " + err.Error())
+	}
+
+	fake := &apitest.Fake{
+		Profile:      d.Profile,
+		Providers:    d.Providers,
+		Alternatives: d.Alternatives,
+		Selections:   make(map[int]api.ProviderSelection),
+	}
+	for providerID, alternativeID := range d.Selected {
+		for _, alt := range d.Alternatives[providerID] {
+			if alt.Alternative.ID == alternativeID {
+				fake.Selections[providerID] = api.ProviderSelection{
+					ProviderID:            providerID,
+					SelectedAlternativeID: alternativeID,
+					SelectedAlternative:   alt.Alternative,
+				}
+			}
+		}
+	}
+
+	samples := generateHistory(history.AccountOf(&d.Profile), now)
+	last := samples[len(samples)-1]
+	fake.Profile.Balance = last.Balance
+	fake.Profile.SubscriptionBalance = last.SubscriptionBalance
+	fake.Profile.PayAsYouGoBalance = last.PaygBalance
+	fake.Profile.CurrentWeekSpend = last.WeekSpend
+	fake.Profile.CurrentMonthSpend = last.MonthSpend
+	fake.Profile.SubscriptionExpiry = now.AddDate(0, 0, 24).UTC().Format(time.RFC3339)
+
+	return fake, history.NewStoreWith(&memory{samples: samples})
+}
+
+// generateHistory produces hourly samples with a workday rhythm. The seed is
+// fixed so every demo run, and every recording made from it, looks the same.
+func generateHistory(account string, now time.Time) []history.Sample {
+	rng := rand.New(rand.NewPCG(2024, 11))
+	start := now.Add(-historyDays * 24 * time.Hour).Truncate(time.Hour)
+	var (
+		samples            []history.Sample
+		today, week, month float64
+		payg               = float64(paygStart)
+	)
+	for t := start; !t.After(now); t = t.Add(time.Hour) {
+		if t.Hour() == 0 {
+			today = 0
+		}
+		if t.Weekday() == time.Monday && t.Hour() == 0 {
+			week = 0
+		}
+		if t.Day() == 1 && t.Hour() == 0 {
+			month = 0
+		}
+		// 工作时间消费较多，夜间和周末很少
+		spend := rng.Float64() * 0.2
+		if t.Hour() >= 9 && t.Hour() < 19 && t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			spend = 0.5 + rng.Float64()*2.5
+		}
+		today += spend
+		week += spend
+		month += spend
+		// 订阅余额每天重置，超出部分由按需余额支付
+		daily := float64(subscriptionBalance) - today
+		if daily < 0 {
+			payg = max(payg-min(spend, -daily), 0)
+			daily = 0
+		}
+		samples = append(samples, history.Sample{
+			Time:                t,
+			Account:             account,
+			Balance:             daily + payg,
+			SubscriptionBalance: daily,
+			PaygBalance:         payg,
+			WeekSpend:           round2(week),
+			MonthSpend:          round2(month),
+		})
+	}
+	return samples
+}
+
+func round2(v float64) float64 {
+	return float64(int(v*100+0.5)) / 100
+}
+
+// memory is a history.Backend that keeps samples in memory, so the demo
+// never touches the real history file.
+type memory struct {
+	mu      sync.Mutex
+	samples []history.Sample
+}
+
+func (m *memory) Append(sample history.Sample) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.samples = append(m.samples, sample)
+	return nil
+}
+
+func (m *memory) Load(account string, since time.Time) ([]history.Sample, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []history.Sample
+	for _, s := range m.samples {
+		if s.Account == account && !s.Time.Before(since) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *memory) Prune(cutoff time.Time) error {
+	return nil
+}
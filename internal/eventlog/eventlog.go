@@ -0,0 +1,156 @@
+// Package eventlog records the messages the interface handles to a
+// size-rotated file and keeps the most recent ones in memory for the in-app
+// event viewer.
+package eventlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"yescode-tui/internal/sealed"
+)
+
+const (
+	// MaxSize is the size at which the log file is rotated.
+	MaxSize = 1 << 20
+	// Keep is how many rotated files are kept next to the current one
+	// (events.log.1 … events.log.3).
+	Keep = 3
+	// recentSize bounds the events kept in memory.
+	recentSize = 500
+)
+
+// Event is one handled message.
+type Event struct {
+	Time time.Time
+	// Type is the message's Go type, e.g. tea.KeyMsg.
+	Type string
+	// Detail holds the message's key fields, e.g. the key pressed or the
+	// mouse position.
+	Detail string
+}
+
+// Log writes events to a file and remembers the latest ones. It is safe for
+// concurrent use.
+type Log struct {
+	path string
+	box  *sealed.Box
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	recent []Event
+}
+
+// Open appends to the log at path.
+func Open(path string) (*Log, error) {
+	return OpenSealed(path, nil)
+}
+
+// OpenSealed is Open with every event written to the file encrypted by box,
+// one sealed line per event; `yc decrypt` prints the file in the clear.
+func OpenSealed(path string, box *sealed.Box) (*Log, error) {
+	l := &Log{path: path, box: box}
+	if err := l.openFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Path returns the current log file.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record logs one event.
+func (l *Log) Record(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recent = append(l.recent, e)
+	if len(l.recent) > recentSize {
+		l.recent = l.recent[len(l.recent)-recentSize:]
+	}
+
+	line := Format(e)
+	if l.box != nil {
+		blob, err := l.box.SealText([]byte(strings.TrimSuffix(line, "\n")))
+		if err != nil {
+			return
+		}
+		line = string(blob) + "\n"
+	}
+	if l.size+int64(len(line)) > MaxSize {
+		// 轮转失败时丢弃这条记录，不影响界面
+		l.rotate()
+	}
+	if l.file == nil {
+		return
+	}
+	n, _ := l.file.WriteString(line)
+	l.size += int64(n)
+}
+
+// Recent returns the latest events, oldest first.
+func (l *Log) Recent() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Event(nil), l.recent...)
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Format renders e as one line of the log file.
+func Format(e Event) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", e.Time.Format("2006-01-02T15:04:05.000Z07:00"), e.Type)
+	if e.Detail != "" {
+		b.WriteString(" " + e.Detail)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (l *Log) openFile() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file, l.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts events.log to events.log.1, dropping the oldest file, and
+// starts a new one.
+func (l *Log) rotate() {
+	if l.file != nil {
+		l.file.Close()
+		l.file = nil
+	}
+	for i := Keep - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+	}
+	os.Rename(l.path, l.path+".1")
+	l.openFile()
+}
@@ -0,0 +1,150 @@
+// Package format renders money and dates for the configured locale, so the
+// profile, stats and CLI output all agree on separators and date order.
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale names accepted by SetLocale.
+const (
+	LocaleZH = "zh-CN"
+	LocaleEN = "en-US"
+	LocaleDE = "de-DE"
+)
+
+// Clock settings accepted by SetClock.
+const (
+	ClockAuto = "auto"
+	Clock12   = "12"
+	Clock24   = "24"
+)
+
+// locale holds the separators, date layouts and clock of one locale.
+type locale struct {
+	decimal   string
+	group     string
+	longDate  string // e.g. 2024年1月2日
+	shortDate string // month and day only, for chart axes
+	hour12    bool
+	am, pm    string // 12-hour markers, placed before the time in zh-CN
+	amFirst   bool
+}
+
+var locales = map[string]locale{
+	LocaleZH: {decimal: ".", group: ",", longDate: "2006年1月2日", shortDate: "01/02", am: "上午", pm: "下午", amFirst: true},
+	LocaleEN: {decimal: ".", group: ",", longDate: "Jan 2, 2006", shortDate: "01/02", hour12: true, am: " AM", pm: " PM"},
+	LocaleDE: {decimal: ",", group: ".", longDate: "2.1.2006", shortDate: "02.01.", am: " AM", pm: " PM"},
+}
+
+var (
+	current = locales[LocaleZH]
+	// clock overrides the locale's 12/24-hour default when set.
+	clock = ClockAuto
+)
+
+// Locales lists the supported locale names.
+func Locales() []string {
+	return []string{LocaleZH, LocaleEN, LocaleDE}
+}
+
+// SetLocale selects the locale used by every formatter. Names are matched
+// case-insensitively and "_" may replace "-"; a bare language such as "en"
+// picks its default region.
+func SetLocale(name string) error {
+	name = strings.ReplaceAll(strings.TrimSpace(name), "_", "-")
+	for _, known := range Locales() {
+		if strings.EqualFold(name, known) || strings.EqualFold(name, known[:2]) {
+			current = locales[known]
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown locale %q", name)
+}
+
+// SetClock selects 12- or 24-hour times; ClockAuto (or "") follows the locale.
+func SetClock(name string) error {
+	switch name = strings.TrimSpace(strings.ToLower(name)); name {
+	case "", ClockAuto:
+		clock = ClockAuto
+	case Clock12, "12h":
+		clock = Clock12
+	case Clock24, "24h":
+		clock = Clock24
+	default:
+		return fmt.Errorf("unknown clock %q", name)
+	}
+	return nil
+}
+
+func hour12() bool {
+	if clock == ClockAuto {
+		return current.hour12
+	}
+	return clock == Clock12
+}
+
+// Money renders a dollar amount with two decimals, e.g. $1,234.56.
+func Money(v float64) string {
+	if v < 0 {
+		return "-$" + Number(-v, 2)
+	}
+	return "$" + Number(v, 2)
+}
+
+// Number renders v with the given decimals and grouped thousands.
+func Number(v float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if v < 0 && s != strconv.FormatFloat(0, 'f', decimals, 64) {
+		b.WriteByte('-')
+	}
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(current.group)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString(current.decimal + frac)
+	}
+	return b.String()
+}
+
+// Date renders a calendar date, e.g. 2024年1月2日 or Jan 2, 2024.
+func Date(t time.Time) string {
+	return t.Format(current.longDate)
+}
+
+// ShortDate renders month and day only, e.g. 01/02 or 02.01.
+func ShortDate(t time.Time) string {
+	return t.Format(current.shortDate)
+}
+
+// Time renders the local time of day with seconds, e.g. 15:04:05,
+// 3:04:05 PM or 下午3:04:05.
+func Time(t time.Time) string {
+	t = t.Local()
+	if !hour12() {
+		return t.Format("15:04:05")
+	}
+	marker := current.am
+	if t.Hour() >= 12 {
+		marker = current.pm
+	}
+	if current.amFirst {
+		return marker + t.Format("3:04:05")
+	}
+	return t.Format("3:04:05") + marker
+}
+
+// DateTime renders the local date and time, e.g. 2024年1月2日 15:04:05.
+func DateTime(t time.Time) string {
+	return Date(t.Local()) + " " + Time(t)
+}
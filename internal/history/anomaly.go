@@ -0,0 +1,43 @@
+package history
+
+// AnomalyBaselineDays is how many days before today the trailing average
+// covers.
+const AnomalyBaselineDays = 14
+
+// minBaselineDays is the fewest days of known spend the average needs
+// before today's spend is compared with it.
+const minBaselineDays = 3
+
+// Anomaly compares today's spend with the trailing daily average.
+type Anomaly struct {
+	Today   float64
+	Average float64
+	// Ratio is Today / Average, or 0 without enough days of known spend.
+	Ratio float64
+}
+
+// DetectAnomaly compares the last day of days (today) with the average of
+// the days before it whose spend is known, measured or estimated. Days no
+// pair of samples spans are left out of the average so stretches before the
+// program first ran don't drag it down.
+func DetectAnomaly(days []DaySpend) Anomaly {
+	if len(days) == 0 {
+		return Anomaly{}
+	}
+	today := days[len(days)-1]
+	a := Anomaly{Today: today.Amount}
+
+	total, known := 0.0, 0
+	for _, day := range days[max(0, len(days)-1-AnomalyBaselineDays) : len(days)-1] {
+		if day.Known() {
+			total += day.Amount
+			known++
+		}
+	}
+	if known < minBaselineDays || total <= 0 {
+		return a
+	}
+	a.Average = total / float64(known)
+	a.Ratio = a.Today / a.Average
+	return a
+}
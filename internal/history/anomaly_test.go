@@ -0,0 +1,28 @@
+package history
+
+import "testing"
+
+func TestDetectAnomaly(t *testing.T) {
+	sampled := func(amount float64) DaySpend { return DaySpend{Amount: amount, Sampled: true} }
+	estimated := func(amount float64) DaySpend { return DaySpend{Amount: amount, Estimated: true} }
+	unknown := DaySpend{}
+	tests := []struct {
+		name string
+		days []DaySpend
+		want Anomaly
+	}{
+		{"no days", nil, Anomaly{}},
+		{"sampled baseline", []DaySpend{sampled(2), sampled(4), sampled(3), sampled(9)}, Anomaly{Today: 9, Average: 3, Ratio: 3}},
+		{"estimated days count", []DaySpend{sampled(2), estimated(4), estimated(3), sampled(9)}, Anomaly{Today: 9, Average: 3, Ratio: 3}},
+		{"unknown days left out", []DaySpend{unknown, sampled(2), unknown, sampled(4), sampled(3), sampled(9)}, Anomaly{Today: 9, Average: 3, Ratio: 3}},
+		{"too few known days", []DaySpend{unknown, sampled(2), sampled(4), sampled(9)}, Anomaly{Today: 9}},
+		{"no spend before today", []DaySpend{sampled(0), sampled(0), sampled(0), sampled(9)}, Anomaly{Today: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectAnomaly(tt.days); got != tt.want {
+				t.Errorf("DetectAnomaly = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package history
+
+import "time"
+
+// DaySpend is the spend attributed to one local calendar day.
+type DaySpend struct {
+	Date   time.Time
+	Amount float64
+	// Sampled is true when a sample was taken on the day.
+	Sampled bool
+	// Estimated is true for a day without samples of its own whose Amount is
+	// its share of the spend between the samples on either side.
+	Estimated bool
+}
+
+// Known reports whether Amount is the day's spend, measured or estimated.
+// It is false for days no pair of samples spans, whose spend is unknown.
+func (d DaySpend) Known() bool {
+	return d.Sampled || d.Estimated
+}
+
+// DailySpend derives per-day spend for the last n days (ending today) from the
+// growth of the monthly spend counter between consecutive samples. A drop in the
+// counter is treated as a monthly reset. Spend between samples on different
+// days, e.g. across days the program didn't run, is spread over those days in
+// proportion to time, so it doesn't all land on the day of the later sample;
+// the days in between are marked Estimated.
+func DailySpend(samples []Sample, n int, now time.Time) []DaySpend {
+	today := startOfDay(now)
+	days := make([]DaySpend, n)
+	for i := range days {
+		days[i].Date = today.AddDate(0, 0, i-n+1)
+	}
+
+	index := func(t time.Time) int {
+		d := startOfDay(t)
+		diff := int(today.Sub(d).Hours()/24 + 0.5)
+		return n - 1 - diff
+	}
+	add := func(t time.Time, amount float64, estimated bool) {
+		if idx := index(t); idx >= 0 && idx < n {
+			days[idx].Amount += amount
+			days[idx].Estimated = days[idx].Estimated || estimated
+		}
+	}
+
+	for i, sample := range samples {
+		if idx := index(sample.Time); idx >= 0 && idx < n {
+			days[idx].Sampled = true
+		}
+		if i == 0 {
+			continue
+		}
+		prev := samples[i-1]
+		delta := spendDelta(prev, sample)
+		total := sample.Time.Sub(prev.Time)
+		if total <= 0 || startOfDay(prev.Time).Equal(startOfDay(sample.Time)) {
+			add(sample.Time, delta, false)
+			continue
+		}
+		// 跨天的增量按时间比例分摊到经过的每一天，两次采样之间的日期标记为估算
+		for start := prev.Time; start.Before(sample.Time); {
+			end := startOfDay(start).AddDate(0, 0, 1)
+			if end.After(sample.Time) {
+				end = sample.Time
+			}
+			day := startOfDay(start)
+			between := day.After(startOfDay(prev.Time)) && day.Before(startOfDay(sample.Time))
+			add(start, delta*float64(end.Sub(start))/float64(total), between)
+			start = end
+		}
+	}
+	return days
+}
+
+// spendDelta is the spend between two consecutive samples. A drop in the
+// monthly counter means it was reset, so everything since counts.
+func spendDelta(prev, cur Sample) float64 {
+	if delta := cur.MonthSpend - prev.MonthSpend; delta >= 0 {
+		return delta
+	}
+	return cur.MonthSpend
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
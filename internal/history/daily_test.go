@@ -0,0 +1,93 @@
+package history
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDailySpend(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+	now := at(10, 15)
+	tests := []struct {
+		name    string
+		samples []Sample
+		// amounts, sampled and estimated cover March 8, 9 and 10.
+		amounts   []float64
+		sampled   []bool
+		estimated []bool
+	}{
+		{
+			name:      "no samples",
+			amounts:   []float64{0, 0, 0},
+			sampled:   []bool{false, false, false},
+			estimated: []bool{false, false, false},
+		},
+		{
+			name:      "same day",
+			samples:   []Sample{{Time: at(10, 9), MonthSpend: 5}, {Time: at(10, 12), MonthSpend: 7.5}},
+			amounts:   []float64{0, 0, 2.5},
+			sampled:   []bool{false, false, true},
+			estimated: []bool{false, false, false},
+		},
+		{
+			name:      "monthly reset",
+			samples:   []Sample{{Time: at(9, 9), MonthSpend: 40}, {Time: at(9, 23), MonthSpend: 3}},
+			amounts:   []float64{0, 3, 0},
+			sampled:   []bool{false, true, false},
+			estimated: []bool{false, false, false},
+		},
+		{
+			name:      "gap spread over the days between samples",
+			samples:   []Sample{{Time: at(8, 12), MonthSpend: 10}, {Time: at(10, 12), MonthSpend: 16}},
+			amounts:   []float64{1.5, 3, 1.5},
+			sampled:   []bool{true, false, true},
+			estimated: []bool{false, true, false},
+		},
+		{
+			name:      "multi-day gap",
+			samples:   []Sample{{Time: at(7, 18), MonthSpend: 0}, {Time: at(10, 6), MonthSpend: 60}},
+			amounts:   []float64{24, 24, 6},
+			sampled:   []bool{false, false, true},
+			estimated: []bool{true, true, false},
+		},
+		{
+			name:      "gap ending at midnight",
+			samples:   []Sample{{Time: at(8, 12), MonthSpend: 0}, {Time: at(10, 0), MonthSpend: 36}},
+			amounts:   []float64{12, 24, 0},
+			sampled:   []bool{true, false, true},
+			estimated: []bool{false, true, false},
+		},
+		{
+			name:      "sample before the window",
+			samples:   []Sample{{Time: at(7, 12), MonthSpend: 0}, {Time: at(8, 12), MonthSpend: 2}},
+			amounts:   []float64{1, 0, 0},
+			sampled:   []bool{true, false, false},
+			estimated: []bool{false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := DailySpend(tt.samples, 3, now)
+			if len(days) != 3 {
+				t.Fatalf("got %d days, want 3", len(days))
+			}
+			for i, day := range days {
+				if want := at(8+i, 0); !day.Date.Equal(want) {
+					t.Errorf("day %d date = %s, want %s", i, day.Date, want)
+				}
+				if math.Abs(day.Amount-tt.amounts[i]) > 1e-9 {
+					t.Errorf("day %d amount = %v, want %v", i, day.Amount, tt.amounts[i])
+				}
+				if day.Sampled != tt.sampled[i] {
+					t.Errorf("day %d sampled = %v, want %v", i, day.Sampled, tt.sampled[i])
+				}
+				if day.Estimated != tt.estimated[i] {
+					t.Errorf("day %d estimated = %v, want %v", i, day.Estimated, tt.estimated[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"yescode-tui/internal/sealed"
+)
+
+// jsonlFile keeps samples in a JSON Lines file, one sample per line, each
+// line sealed by box when set.
+type jsonlFile struct {
+	path string
+	box  *sealed.Box
+}
+
+func (f *jsonlFile) Append(sample Sample) error {
+	line, err := json.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("encode sample: %w", err)
+	}
+	if line, err = f.box.SealText(line); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func (f *jsonlFile) Load(account string, since time.Time) ([]Sample, error) {
+	all, _, err := readFile(f.path, f.box)
+	if err != nil {
+		return nil, err
+	}
+	var out []Sample
+	for _, sample := range all {
+		if sample.Account == account && !sample.Time.Before(since) {
+			out = append(out, sample)
+		}
+	}
+	return out, nil
+}
+
+func (f *jsonlFile) Prune(cutoff time.Time) error {
+	samples, plain, err := readFile(f.path, f.box)
+	if err != nil || len(samples) == 0 {
+		return err
+	}
+	// 启用加密后顺带把之前写入的明文行改写为密文
+	if !samples[0].Time.Before(cutoff) && !(f.box != nil && plain) {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, sample := range samples {
+		if sample.Time.Before(cutoff) {
+			continue
+		}
+		line, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		if line, err = f.box.SealText(line); err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// ReadFile returns every sample of the JSON Lines file at path, in file
+// order, opening sealed lines with box; a missing file has none.
+func ReadFile(path string, box *sealed.Box) ([]Sample, error) {
+	samples, _, err := readFile(path, box)
+	return samples, err
+}
+
+// readFile is ReadFile that also reports whether any line was plaintext.
+func readFile(path string, box *sealed.Box) (samples []Sample, plain bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var opened int
+	var openErr error
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if sealed.IsSealed(line) {
+			var err error
+			if line, err = box.OpenText(line); err != nil {
+				openErr = err
+				continue
+			}
+			opened++
+		} else if len(line) > 0 {
+			plain = true
+		}
+		var sample Sample
+		// 跳过损坏的行（例如进程在写入时被中断）
+		if err := json.Unmarshal(line, &sample); err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	if openErr != nil && opened == 0 {
+		// 没有一行能解密，说明密钥不对而不是个别行损坏
+		return nil, false, openErr
+	}
+	return samples, plain, scanner.Err()
+}
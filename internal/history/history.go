@@ -0,0 +1,129 @@
+package history
+
+import (
+	"sync"
+	"time"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/sealed"
+)
+
+const (
+	defaultMinInterval = 5 * time.Minute
+	defaultRetention   = 90 * 24 * time.Hour
+)
+
+// Sample is one recorded snapshot of the account's balance and spend counters.
+type Sample struct {
+	Time                time.Time `json:"time"`
+	Account             string    `json:"account"`
+	Balance             float64   `json:"balance"`
+	SubscriptionBalance float64   `json:"subscription_balance"`
+	PaygBalance         float64   `json:"payg_balance"`
+	WeekSpend           float64   `json:"week_spend"`
+	MonthSpend          float64   `json:"month_spend"`
+}
+
+// NewSample captures the profile's counters at now.
+func NewSample(p *api.Profile, now time.Time) Sample {
+	return Sample{
+		Time:                now,
+		Account:             AccountOf(p),
+		Balance:             p.Balance,
+		SubscriptionBalance: p.SubscriptionBalance,
+		PaygBalance:         p.PayAsYouGoBalance,
+		WeekSpend:           p.CurrentWeekSpend,
+		MonthSpend:          p.CurrentMonthSpend,
+	}
+}
+
+// Backend persists samples for a Store.
+type Backend interface {
+	// Append adds one sample.
+	Append(sample Sample) error
+	// Load returns the account's samples recorded at or after since, oldest first.
+	Load(account string, since time.Time) ([]Sample, error)
+	// Prune drops the samples recorded before cutoff.
+	Prune(cutoff time.Time) error
+}
+
+// Store records samples at most once per minimum interval and account, and
+// prunes those past the retention period.
+type Store struct {
+	backend     Backend
+	minInterval time.Duration
+	retention   time.Duration
+
+	mu     sync.Mutex
+	last   map[string]time.Time
+	pruned bool
+}
+
+// NewStore builds a Store writing to the JSON Lines file at path.
+func NewStore(path string) *Store {
+	return NewStoreWith(&jsonlFile{path: path})
+}
+
+// NewSealedStore builds a Store writing to the JSON Lines file at path with
+// every line sealed by box. Plaintext lines already in the file stay readable
+// and are sealed the next time the file is compacted.
+func NewSealedStore(path string, box *sealed.Box) *Store {
+	return NewStoreWith(&jsonlFile{path: path, box: box})
+}
+
+// NewStoreWith builds a Store keeping samples in backend.
+func NewStoreWith(backend Backend) *Store {
+	return &Store{
+		backend:     backend,
+		minInterval: defaultMinInterval,
+		retention:   defaultRetention,
+		last:        make(map[string]time.Time),
+	}
+}
+
+// Record appends a sample for the profile unless one was written for the same
+// account within the minimum interval.
+func (s *Store) Record(p *api.Profile, now time.Time) error {
+	if s == nil || p == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := AccountOf(p)
+	if last, ok := s.last[account]; ok && now.Sub(last) < s.minInterval {
+		return nil
+	}
+
+	if !s.pruned {
+		// 每次启动首次写入前清理过期样本，避免记录无限增长
+		s.pruned = true
+		if err := s.backend.Prune(now.Add(-s.retention)); err != nil {
+			return err
+		}
+	}
+
+	if err := s.backend.Append(NewSample(p, now)); err != nil {
+		return err
+	}
+	s.last[account] = now
+	return nil
+}
+
+// Load returns the account's samples recorded at or after since, oldest first.
+func (s *Store) Load(account string, since time.Time) ([]Sample, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backend.Load(account, since)
+}
+
+// AccountOf returns the key samples of the profile are stored under.
+func AccountOf(p *api.Profile) string {
+	if p.Username != "" {
+		return p.Username
+	}
+	return p.Email
+}
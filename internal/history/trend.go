@@ -0,0 +1,39 @@
+package history
+
+import "time"
+
+// SpendBucket is the spend attributed to one fixed-width slice of time.
+type SpendBucket struct {
+	Start  time.Time
+	Amount float64
+	// Sampled is false when no sample falls into the bucket.
+	Sampled bool
+}
+
+// SpendTrend splits the window ending at now into n buckets and attributes
+// the growth of the monthly spend counter to the bucket of the later sample.
+// Unlike DailySpend it doesn't spread growth across buckets without samples,
+// so a gap in sampling shows as empty buckets followed by one tall bar.
+func SpendTrend(samples []Sample, window time.Duration, n int, now time.Time) []SpendBucket {
+	if n <= 0 || window <= 0 {
+		return nil
+	}
+	width := window / time.Duration(n)
+	start := now.Add(-window)
+	buckets := make([]SpendBucket, n)
+	for i := range buckets {
+		buckets[i].Start = start.Add(time.Duration(i) * width)
+	}
+
+	for i, sample := range samples {
+		if sample.Time.Before(start) || sample.Time.After(now) {
+			continue
+		}
+		idx := min(int(sample.Time.Sub(start)/width), n-1)
+		buckets[idx].Sampled = true
+		if i > 0 {
+			buckets[idx].Amount += spendDelta(samples[i-1], sample)
+		}
+	}
+	return buckets
+}
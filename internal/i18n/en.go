@@ -0,0 +1,566 @@
+package i18n
+
+// en is the English catalog, keyed by the Chinese source text.
+var en = map[string]string{
+	"只显示将要执行的变更，不实际应用":                          "Show the changes without applying them",
+	"跳过确认提示直接应用":                                "Apply without asking for confirmation",
+	"用法: yc apply [选项] <state.yaml|state.json>": "Usage: yc apply [options] <state.yaml|state.json>",
+	"回滚点：%s\n":                                  "Rollback point: %s\n",
+	"当前状态已与文件一致，无需变更":                           "Current state already matches the file, nothing to change",
+	"将执行以下变更：":                                  "The following changes will be made:",
+	"确认应用？[y/N] ":                               "Apply these changes? [y/N] ",
+	"已取消":                                       "Cancelled",
+	"\n已保存回滚点，可使用 yc rollback 恢复":               "\nRollback point saved, restore it with yc rollback",
+	"打开状态文件失败: %v":                              "Failed to open state file: %v",
+	"解析状态文件失败: %v":                              "Failed to parse state file: %v",
+	"获取账户状态失败: %v":                              "Failed to fetch account state: %v",
+	"定位回滚点失败: %v":                               "Failed to locate rollback point: %v",
+	"没有可用的回滚点":                                  "No rollback point available",
+	"读取回滚点失败: %v":                               "Failed to read rollback point: %v",
+	"生成变更计划失败: %v":                              "Failed to build change plan: %v",
+	"保存回滚点失败: %v":                               "Failed to save rollback point: %v",
+	"\n部分变更应用失败，可使用 yc rollback 恢复":             "\nSome changes failed, restore with yc rollback",
+	"输出 .ics 文件路径（默认输出到标准输出）":                   "Output .ics file path (default: standard output)",
+	"订阅到期前提前提醒的天数":                              "Days before subscription expiry to remind",
+	"YesCode 订阅到期：%s":                           "YesCode subscription expires: %s",
+	"账户 %s 的订阅计划 %s ($%.2f) 将于当日到期，请及时续费。": "The %[2]s plan ($%.2[3]f) of account %[1]s expires today; renew it in time.",
+	"YesCode 订阅即将到期": "YesCode subscription expires soon",
+	"YesCode 订阅今日到期": "YesCode subscription expires today",
+	"YesCode 周额度重置":  "YesCode weekly limit resets",
+	"YesCode 月额度重置":  "YesCode monthly limit resets",
+	"每周额度 $%.2f 重置。按每周一重置推算，服务端未提供重置时间，请以控制台为准。":    "The weekly limit of $%.2f resets. Assumed to reset every Monday since the API doesn't say when; check the console.",
+	"每月额度 $%.2f 重置。按每月 1 日重置推算，服务端未提供重置时间，请以控制台为准。": "The monthly limit of $%.2f resets. Assumed to reset on the 1st since the API doesn't say when; check the console.",
+	"已写入 %s\n":     "Wrote %s\n",
+	"获取用户资料失败: %v": "Failed to fetch profile: %v",
+	"写入日历文件失败: %v": "Failed to write calendar file: %v",
+	"告警规则，逗号分隔（例如 balance<5,week_pct>=90）":                                                   "Alert rules, comma-separated (e.g. balance<5,week_pct>=90)",
+	"通知消息语言（zh / en），默认跟随 --lang":                                                            "Notification language (zh / en); defaults to the --lang language",
+	"省流模式：延长自动刷新间隔、不预取提供商详情":                                                                 "Low-bandwidth mode: slower auto refresh, no provider detail prefetch",
+	"焦点面板的额外提示，逗号分隔：marker（[焦点] 标记）、inverse（反色光标行）或 none":                                    "Extra cues for the focused panel, comma-separated: marker ([focus] label), inverse (reversed cursor row) or none",
+	"界面主题（dark / light / solarized / dracula / high-contrast，或配置文件中 [themes.NAME] 定义的自定义主题）": "UI theme (dark / light / solarized / dracula / high-contrast, or a custom theme defined in [themes.NAME] in the config file)",
+	"不使用颜色（设置环境变量 NO_COLOR 时同样生效）":                                                           "Disable colors (also enabled by the NO_COLOR environment variable)",
+	"图标集（unicode / nerd / ascii），nerd 需要终端使用 Nerd Font":                                      "Icon set (unicode / nerd / ascii); nerd requires a Nerd Font in the terminal",
+	"列表密度：compact（单行）或 comfortable（在每行下方显示说明）":                                               "List density: compact (one line) or comfortable (description under each row)",
+	"顶部布局：full（标题、提示和标签页各占一行）、minimal（合并为一行）或 auto（终端不足 30 行时使用 minimal）":                    "Header layout: full (title, hints and tabs on separate rows), minimal (one row) or auto (minimal on terminals under 30 rows)",
+	"固定的提供商顺序，逗号分隔的 ID 或名称（例如 3,Claude），未列出的按 API 顺序排在后面":                                    "Fixed provider order, comma-separated IDs or names (e.g. 3,Claude); unlisted providers follow in API order",
+	"期望状态文件（yc snapshot 的输出或手写），启动后在“计划”界面中选择要应用的变更":                                         "Desired-state file (output of yc snapshot or hand-written); pick the changes to apply in the Plan screen after startup",
+	"用户资料自动刷新间隔（0 表示默认：5s，省流模式下 60s）":                                                        "Profile auto-refresh interval (0 means the default: 5s, 60s in low-bandwidth mode)",
+	"用户资料页消费趋势图覆盖的时间范围":                                                                      "Time range covered by the spend trend chart on the profile tab",
+	"YesCode API Key（可使用环境变量 YESCODE_API_KEY）":                                               "YesCode API key (or set the YESCODE_API_KEY environment variable)",
+	"自定义 API Base URL（默认 https://co.yes.vg）":                                                 "Custom API base URL (default https://co.yes.vg)",
+	"需要重试的 HTTP 状态码，逗号分隔（网络错误总会重试）":                                                          "HTTP status codes to retry, comma-separated (network errors are always retried)",
+	"读取类请求（GET）的最大尝试次数":                                                                      "Maximum attempts for read requests (GET)",
+	"写入类请求（PUT）的最大尝试次数":                                                                      "Maximum attempts for write requests (PUT)",
+	"直接连接的地址（IP 或 IP:端口），Host/SNI 仍使用 API 域名":                                                "Address to connect to directly (IP or IP:port); Host/SNI still use the API domain",
+	"自定义 DNS 服务器（例如 223.5.5.5），替代系统解析":                                                       "Custom DNS server (e.g. 223.5.5.5) instead of the system resolver",
+	"实验性：优先使用 HTTP/3 (QUIC)，不可用时自动回退到 HTTP/1.1/2":                                            "Experimental: prefer HTTP/3 (QUIC), falling back to HTTP/1.1/2 when unavailable",
+	"配置文件路径（默认 ~/.config/yescode-tui/config.toml，可使用环境变量 YESCODE_CONFIG）":                    "Config file path (default ~/.config/yescode-tui/config.toml, or set YESCODE_CONFIG)",
+	"使用配置文件中 [accounts.NAME] 定义的账户":                                                          "Use an account defined in [accounts.NAME] in the config file",
+	"界面语言（zh / en），auto 表示按 LC_ALL、LC_MESSAGES 或 LANG 环境变量检测；同时决定服务端错误信息的语言":                 "Interface language (zh / en); auto detects it from LC_ALL, LC_MESSAGES or LANG. Also picks the language of server error messages",
+	"金额与日期的格式（%s），默认跟随 --lang":                                                               "Money and date format (%s), follows --lang by default",
+	"时间显示为 12 或 24 小时制（auto 表示按 --locale：en-US 为 12 小时制，其余为 24 小时制）":                         "Show times in 12- or 24-hour format (auto follows --locale: 12-hour for en-US, 24-hour otherwise)",
+	"配置无效: %v":                 "Invalid config: %v",
+	"--theme 无效: %v":           "Invalid --theme: %v",
+	"--icons 无效: %v":           "Invalid --icons: %v",
+	"--focus-indicator 无效: %v": "Invalid --focus-indicator: %v",
+	"--density 无效: %v":         "Invalid --density: %v",
+	"--chrome 无效: %v":          "Invalid --chrome: %v",
+	"--refresh-interval 不能为负数": "--refresh-interval must not be negative",
+	"--trend-window 必须大于 0":    "--trend-window must be greater than 0",
+	"打开本地记录失败: %v":             "Failed to open local records: %v",
+	"告警规则无效: %v":               "Invalid alert rules: %v",
+	"初始化通知渠道失败: %v":            "Failed to set up notification channels: %v",
+	"已配置告警规则但没有可用的通知渠道，请设置 YESCODE_TELEGRAM_BOT_TOKEN 或 YESCODE_DINGTALK_TOKEN": "Alert rules are configured but no notification channel is available; set YESCODE_TELEGRAM_BOT_TOKEN or YESCODE_DINGTALK_TOKEN",
+	"程序运行失败: %v":                 "Program failed: %v",
+	"--lang 无效: %v":              "Invalid --lang: %v",
+	"--locale 无效: %v":            "Invalid --locale: %v",
+	"--clock 无效: %v":             "Invalid --clock: %v",
+	"--inject-faults 无效: %v":     "Invalid --inject-faults: %v",
+	"脚本 %s 执行失败: %v":             "Script %s failed: %v",
+	"TLS 证书配置无效: %v":             "Invalid TLS certificate settings: %v",
+	"读取配置文件失败: %v":               "Failed to read config file: %v",
+	"--account 无效: 配置文件中没有账户 %q": "Invalid --account: no account %q in the config file",
+	"缺少 API Key，请使用 --api-key、环境变量 YESCODE_API_KEY 或配置文件中的 api_key": "Missing API key; use --api-key, the YESCODE_API_KEY environment variable or api_key in the config file",
+	"--retry-status 无效: %v": "Invalid --retry-status: %v",
+	"初始化 API 客户端失败: %v":     "Failed to create API client: %v",
+	"以 JSON 输出结构化结果（便于通过 jq 处理），提示信息改为输出到标准错误": "Print structured JSON (handy with jq); messages go to standard error",
+	"输出 JSON 失败: %v":      "Failed to write JSON: %v",
+	"用户名：%s\n":            "Username: %s\n",
+	"邮箱：%s\n":             "Email: %s\n",
+	"订阅余额：%s\n":           "Subscription balance: %s\n",
+	"按需余额：%s\n":           "Pay-as-you-go balance: %s\n",
+	"总余额：%s\n":            "Total balance: %s\n",
+	"余额偏好：%s\n":           "Balance preference: %s\n",
+	"订阅计划：%s (%s)\n":      "Plan: %s (%s)\n",
+	"订阅到期：%s\n":           "Plan expires: %s\n",
+	"本周消费：%s / %s\n":      "Spent this week: %s / %s\n",
+	"本月消费：%s / %s\n":      "Spent this month: %s / %s\n",
+	"名称":                  "Name",
+	"来源":                  "Source",
+	"类型":                  "Type",
+	"倍率":                  "Rate",
+	" (默认)":               " (default)",
+	"\n订阅：%s · 按需余额：%s\n": "\nSubscription: %s · Pay-as-you-go balance: %s\n",
+	"用法: yc selection [选项] [提供商 ID 或名称]": "Usage: yc selection [options] [provider ID or name]",
+	"有":                 "yes",
+	"无":                 "no",
+	"获取提供商列表失败: %v":     "Failed to fetch providers: %v",
+	"没有提供商 %q":          "No provider %q",
+	"获取 %s 的当前方案失败: %v": "Failed to fetch the current alternative of %s: %v",
+	"外部费用换算倍率（例如当前方案的倍率 ×0.8）":                     "Conversion rate for external costs (e.g. the current alternative's ×0.8)",
+	"允许的相对差异，超过即标记为异常（0.2 = 20%）":                  "Allowed relative difference; larger ones are flagged (0.2 = 20%)",
+	"用法: yc reconcile [选项] <usage.csv|usage.json>": "Usage: yc reconcile [options] <usage.csv|usage.json>",
+	"日期":           "Date",
+	"外部记录":         "External",
+	"差额":           "Difference",
+	" (未采样)":       " (not sampled)",
+	"  ⚠ 差异超出容差":   "  ⚠ difference over tolerance",
+	"  (估算，不参与比对)": "  (estimated, not compared)",
+	"\n共 %d 天，%d 天差异超出 %.0f%% 容差\n":                           "\n%d days, %d days differ by more than the %.0f%% tolerance\n",
+	"打开用量文件失败: %v":                                            "Failed to open usage file: %v",
+	"解析用量文件失败: %v":                                            "Failed to parse usage file: %v",
+	"用量文件中没有数据":                                               "The usage file has no data",
+	"读取本地采样失败: %v":                                            "Failed to read local samples: %v",
+	"输出文件路径（默认输出到标准输出）":                                       "Output file path (default: standard output)",
+	"输出格式（json / yaml），默认按 -o 的扩展名判断，否则为 json":                "Output format (json / yaml); defaults to the extension of -o, otherwise json",
+	"等同于 --format json":                                       "Same as --format json",
+	"用法: yc snapshot diff [选项] <snapshot.json|snapshot.yaml>": "Usage: yc snapshot diff [options] <snapshot.json|snapshot.yaml>",
+	"与当前状态一致":                                                 "Matches the current state",
+	"\n共 %d 处差异\n":                                            "\n%d differences\n",
+	"--format 无效: %q":                                         "Invalid --format: %q",
+	"写入快照失败: %v":                                              "Failed to write snapshot: %v",
+	"创建快照文件失败: %v":                                            "Failed to create snapshot file: %v",
+	"打开快照文件失败: %v":                                            "Failed to open snapshot file: %v",
+	"解析快照文件失败: %v":                                            "Failed to parse snapshot file: %v",
+	"默认":                                                      "default",
+	"未配置其他账户，请在配置文件中添加 [accounts.NAME]":                       "No other accounts configured; add [accounts.NAME] to the config file",
+	"已切换到账户 %s":                                               "Switched to account %s",
+	"切换账户":                                                    "Switch account",
+	"Enter 切换 · Esc 取消":                                       "Enter switch · Esc cancel",
+	"通知发送失败: %v":                                              "Failed to send notification: %v",
+	"已复制到剪贴板，但保存文件失败: %v":                                     "Copied to the clipboard, but saving the file failed: %v",
+	"已复制到剪贴板并保存到 ":                                            "Copied to the clipboard and saved to ",
+	"重试":                                                      "Retry",
+	" YesCode · ? 帮助":                                         " YesCode · ? help",
+	" · 省流模式":                                                 " · low-bandwidth",
+	" · 账户：":                                                  " · account: ",
+	"数据不一致：%s，服务器缓存可能已过期":                                     "Data mismatch: %s; the server cache may be stale",
+	"提供商列表显示有按需余额，但用户资料中按需余额为 ":     "The provider list shows a pay-as-you-go balance, but the profile's pay-as-you-go balance is ",
+	"提供商列表显示无按需余额，但用户资料中按需余额为 ":     "The provider list shows no pay-as-you-go balance, but the profile's pay-as-you-go balance is ",
+	"提供商列表显示有订阅，但用户资料中订阅未激活":        "The provider list shows a subscription, but the profile's subscription is inactive",
+	"提供商列表显示无订阅，但用户资料中订阅已激活（余额 %s）": "The provider list shows no subscription, but the profile's subscription is active (balance %s)",
+	"  %s 数据不一致，服务器缓存可能已过期：":        "  %s Data mismatch, the server cache may be stale:",
+	"网络错误":         "Network error",
+	"诊断信息":         "Diagnostics",
+	"本次会话":         "This session",
+	"请求失败统计":       "Failed requests",
+	"  本次会话暂无失败请求": "  No failed requests this session",
+	"次数":           "Count",
+	"状态":           "Status",
+	" 首次 → 最近  端点": " First    → Last      Endpoint",
+	"最近错误":         "Recent errors",
+	"    原始响应：":    "    Raw response: ",
+	"诊断包会复制到剪贴板并保存到文件，其中的密钥已移除": "The bundle is copied to the clipboard and saved to a file, with secrets removed",
+	"暂无条件请求":                "No conditional requests yet",
+	"%.0f%%（%d/%d）":         "%.0f%% (%d/%d)",
+	"  API 调用：%d 次（含重试）":    "  API calls: %d (including retries)",
+	"  传输数据：上行 %s · 下行 %s":  "  Transferred: up %s · down %s",
+	"  缓存命中率：%s":            "  Cache hit rate: %s",
+	"  平均延迟：%s":             "  Average latency: %s",
+	"没有匹配“%s”的提供商":          "No providers match \"%s\"",
+	"按 Esc 清除筛选":            "Press Esc to clear the filter",
+	"所有提供商都已隐藏":             "All providers are hidden",
+	"按 X 显示全部 %d 个提供商":      "Press X to show all %d providers",
+	"账户暂无订阅和按需余额，因此没有可用提供商": "The account has no subscription or pay-as-you-go balance, so no providers are available",
+	"在控制台订阅套餐或充值：":          "Subscribe or top up in the console: ",
+	"完成后按 r 重新加载":           "Press r to reload when done",
+	"暂无可用提供商":               "No providers available",
+	"服务端没有为该账户开放任何提供商":      "The server has not enabled any providers for this account",
+	"按 r 重新加载 · 使用说明：":      "Press r to reload · Guide: ",
+	"开始使用":                  "Getting started",
+	"  账户暂无订阅和余额，完成以下任一步骤后即可发起请求：": "  The account has no subscription or balance yet. Do either of the following to start making requests:",
+	"  %s 订阅套餐：在控制台选择订阅计划":         "  %s Subscribe: pick a plan in the console",
+	"  %s 按需充值：在控制台充值按需余额":         "  %s Pay as you go: top up your balance in the console",
+	"  控制台：": "  Console: ",
+	"  完成后按 r 刷新 · 使用说明：":                          "  Press r to refresh when done · Guide: ",
+	"%s 余额已用完":                                     "%s Balance exhausted",
+	"  订阅余额和按需余额均为 $0，新的请求将被拒绝：":                   "  Both subscription and pay-as-you-go balances are $0, so new requests will be rejected:",
+	"  %s 等待订阅额度在下个周期重置，或":                         "  %s wait for the subscription quota to reset next period, or",
+	"  %s 在控制台充值按需余额：%s":                           "  %s top up your pay-as-you-go balance in the console: %s",
+	"  充值后按 r 刷新":                                  "  Press r to refresh after topping up",
+	"  未订阅套餐，当前仅使用按需余额 · 订阅：":                      "  No subscription, only the pay-as-you-go balance is used · Subscribe: ",
+	"API Key 无效或已过期":                               "API key is invalid or expired",
+	"检查 --api-key、YESCODE_API_KEY 或配置文件中的 api_key": "Check --api-key, YESCODE_API_KEY or api_key in the config file",
+	"可在控制台重新生成：":                                   "Generate a new one in the console: ",
+	"当前套餐无权执行此操作":                                  "Your plan does not allow this operation",
+	"该功能可能需要订阅或更高等级的套餐":                            "This feature may need a subscription or a higher plan",
+	"查看套餐：":                                        "View plans: ",
+	"接口不存在":                                        "Endpoint not found",
+	"检查 --base-url 是否指向 YesCode 服务":                "Check that --base-url points to the YesCode service",
+	"请求过于频繁":                                       "Too many requests",
+	"稍后重试，或使用 --low-bandwidth 降低刷新频率":              "Retry later, or use --low-bandwidth to refresh less often",
+	"YesCode 服务暂时不可用（HTTP %d）":                     "YesCode service temporarily unavailable (HTTP %d)",
+	"通常很快恢复，请稍后重试":                                 "It usually recovers quickly, please retry later",
+	"服务状态：":                                        "Service status: ",
+	"连接超时":                                         "Connection timed out",
+	"检查网络或代理设置（--proxy 或 HTTPS_PROXY）":             "Check the network or proxy settings (--proxy or HTTPS_PROXY)",
+	"网络受限时可尝试 --connect-to、--dns 或 --http3":        "On restricted networks, try --connect-to, --dns or --http3",
+	"无法连接到服务器":                                     "Cannot connect to the server",
+	"检查网络、代理设置和 --base-url":                        "Check the network, proxy settings and --base-url",
+	"请求失败":                     "Request failed",
+	"详情：":                      "Details: ",
+	"当前方案":                     "Current alternative",
+	"用量费用估算":                   "Usage cost estimate",
+	"提供商：%s":                   "Provider: %s",
+	"  %s ×%.2f：请输入有效数字":       "  %s ×%.2f: enter valid numbers",
+	"  %s ×%.2f：每日 %s · 每月 %s": "  %s ×%.2f: %s per day · %s per month",
+	"最便宜 ":                     "Cheapest ",
+	"  切换后每月可省 ":               "  Switching saves per month ",
+	"  当前方案已是最低倍率":             "  The current alternative already has the lowest rate",
+	"Tab/↑↓ 切换输入项 · Esc 关闭":    "Tab/↑↓ switch field · Esc close",
+	"每日输入 tokens":              "Daily input tokens",
+	"每日输出 tokens":              "Daily output tokens",
+	"输入单价（$/百万）":               "Input price ($/M)",
+	"输出单价（$/百万）":               "Output price ($/M)",
+	"按名称、类型或来源筛选提供商和方案":        "Filter providers and alternatives by name, type or source",
+	"筛选“%s”：提供商 %d/%d（/ 修改 · Esc 清除）": "Filter \"%s\": providers %d/%d (/ edit · Esc clear)",
+	"筛选“%s”：方案 %d/%d":                 "Filter \"%s\": alternatives %d/%d",
+	"筛选“%s”：没有匹配的方案，显示全部 %d 个":        "Filter \"%s\": no matching alternatives, showing all %d",
+	"[焦点]":   "[focus]",
+	"需要按需余额": "needs pay-as-you-go balance",
+	"需要订阅":   "needs subscription",
+	"    当前没有订阅和按需余额，请求将无法计费":    "    No subscription or pay-as-you-go balance, requests cannot be billed",
+	"    当前没有订阅，将直接使用按需余额":       "    No subscription, the pay-as-you-go balance will be used",
+	"    当前没有按需余额，选择后请求将失败，请先充值": "    No pay-as-you-go balance, requests will fail after selecting this; top up first",
+	"提供商 ID 或序号":            "Provider ID or position",
+	"无效的提供商编号：%s":           "Invalid provider number: %s",
+	"未找到 ID 或序号为 %d 的提供商":   "No provider with ID or position %d",
+	"无法打开浏览器（%v），链接已复制到剪贴板": "Cannot open the browser (%v); the link was copied to the clipboard",
+	"已在浏览器中打开新 issue":       "Opened a new issue in the browser",
+	"按名称排序":                 "Sort by name",
+	"恢复默认排序":                "Restore default order",
+	"至少保留一个提供商":             "At least one provider must stay visible",
+	"隐藏 %s":                 "Hide %s",
+	"显示 %d 个已隐藏的提供商":        "Show %d hidden providers",
+	"面板比例 %d:%d":            "Panel ratio %d:%d",
+	"已隐藏 %d 个（X 全部显示）":      "%d hidden (X to show all)",
+	"导出诊断包":                 "Export bundle",
+	"提交 issue":              "File issue",
+	"关闭":                    "Close",
+	"上移":                    "up",
+	"下移":                    "down",
+	"切换焦点":                  "switch focus",
+	"下一标签页":                 "next tab",
+	"上一标签页":                 "previous tab",
+	"选择":                    "select",
+	"刷新":                    "refresh",
+	"用户资料":                  "profile",
+	"提供商":                   "providers",
+	"余额使用偏好":                "balance preference",
+	"统计":                    "stats",
+	"帮助":                    "help",
+	"费用估算":                  "cost estimate",
+	"恢复默认":                  "restore default",
+	"退出":                    "quit",
+	"加载提供商列表中":              "Loading provider list",
+	"加载提供商":                 "Loading provider",
+	"切换完成":                  "Switch complete",
+	"已切换到 %s":               "Switched to %s",
+	"未知":                    "Unknown",
+	"余额偏好已更新":               "Balance preference updated",
+	"余额偏好已切换为 %s":           "Balance preference switched to %s",
+	"余额偏好切换失败: %s":          "Failed to switch balance preference: %s",
+	"余额偏好切换失败":              "Balance preference switch failed",
+	"切换失败":                  "Switch failed",
+	"  ↑↓ 选择 · Enter 确定 · Esc 清除": "  ↑↓ select · Enter confirm · Esc clear",
+	"提示模式：按标签字母激活 · 其他键退出":        "Hint mode: press a label letter to activate · any other key exits",
+	"刷新中... %s":     "Refreshing... %s",
+	"中...":          "...",
+	"加载":            "Loading",
+	"加载更多提供商... %s": "Loading more providers... %s",
+	"%s 还有更多提供商，移到列表底部时加载":   "%s More providers load when you reach the bottom of the list",
+	"加载提供商列表中...":            "Loading provider list...",
+	"已在使用 %s":                "Already using %s",
+	"切换到 %s 中...":            "Switching to %s...",
+	"备选方案尚未加载":               "Alternatives not loaded yet",
+	"该提供商没有官方方案":             "This provider has no official alternative",
+	"切换余额偏好到 %s...":          "Switching balance preference to %s...",
+	"加载提供商 %d 详情中...":        "Loading provider %d details...",
+	"加载中... %s":              "Loading... %s",
+	"请先选择提供商":                "Select a provider first",
+	"省流模式：按 → 或 Enter 加载方案":  "Low-bandwidth mode: press → or Enter to load alternatives",
+	"无可切换方案":                 "No alternatives to switch to",
+	"官方":                     "official",
+	"订阅":                     "subscription",
+	"按需":                     "pay-as-you-go",
+	"账户信息":                   "Account",
+	"  用户名：%s":               "  Username: %s",
+	"  邮箱：%s":                "  Email: %s",
+	"  更新于 ":                 "  Updated ",
+	"余额概览":                   "Balance",
+	"  %s 订阅余额：%s":           "  %s Subscription balance: %s",
+	"  %s 按需余额：%s":           "  %s Pay-as-you-go balance: %s",
+	"  %s 总余额：%s":            "  %s Total balance: %s",
+	"  %s 余额偏好：%s":           "  %s Balance preference: %s",
+	"订阅计划":                   "Subscription plan",
+	"  %s 计划：%s (%s)":        "  %s Plan: %s (%s)",
+	"  %s 到期：%s":             "  %s Expires: %s",
+	"  %s 每日额度：%s":           "  %s Daily quota: %s",
+	"  %s 本周：%s / %s (%s%%)": "  %s This week: %s / %s (%s%%)",
+	"  %s 本月：%s / %s (%s%%)": "  %s This month: %s / %s (%s%%)",
+	"消费统计":                   "Spending",
+	"  %s 本周消费：%s":           "  %s Spent this week: %s",
+	"  %s 本月消费：%s":           "  %s Spent this month: %s",
+	" 更多内容":                  " More",
+	"加载中...":                 "Loading...",
+	"优先订阅":                   "Subscription first",
+	"    先使用订阅余额，然后使用按需付费":      "    Use the subscription balance first, then pay as you go",
+	"    OPUS 使用限制适用":           "    OPUS usage limits apply",
+	"仅按需付费":                     "Pay-as-you-go only",
+	"    始终使用按需付费余额":            "    Always use the pay-as-you-go balance",
+	"    无 OPUS 使用限制":           "    No OPUS usage limits",
+	"操作帮助":                      "Help",
+	"鼠标操作":                      "Mouse",
+	"  点击标签页        直接切换标签":     "  Click a tab       switch to it",
+	"  点击列表项        选择提供商或备选方案": "  Click a list row  select a provider or alternative",
+	"  滚轮滚动         滚动内容或移动选择":  "  Scroll wheel      scroll content or move the selection",
+	"标签页切换":                     "Tabs",
+	"  Tab / Shift+Tab  在面板间切换焦点，越过首尾时切换标签页": "  Tab / Shift+Tab  cycle focus between panels, moving to the next tab past either end",
+	"  1 / 2 / 3 / 4    直接跳转到指定标签页":          "  1 / 2 / 3 / 4    jump to a tab",
+	"导航操作":                   "Navigation",
+	"  ↑↓ 或 k/j        上下移动": "  ↑↓ or k/j        move up and down",
+	"  ←→ 或 h/l        切换焦点（提供商标签页）/ 选择日期（统计标签页）": "  ←→ or h/l        switch focus (providers tab) / pick a day (stats tab)",
+	"  Enter           确认选择":               "  Enter           confirm selection",
+	"  c               复制选中的字段（资料标签页）":     "  c               copy the selected field (profile tab)",
+	"  r               刷新当前视图":             "  r               refresh the current view",
+	"  e               估算用量费用（提供商标签页）":     "  e               estimate usage cost (providers tab)",
+	"  d               恢复默认（官方）方案（提供商标签页）": "  d               restore the default (official) alternative (providers tab)",
+	"  D               全部提供商恢复默认（预览后确认）":   "  D               restore defaults for all providers (preview first)",
+	"  Alt+1/2/3       切换到最近使用的方案（提供商标签页）": "  Alt+1/2/3       switch to a recently used alternative (providers tab)",
+	"  /               同时模糊筛选提供商和方案列表":     "  /               fuzzy-filter providers and alternatives together",
+	"  s / x / X       排序 / 隐藏 / 显示全部提供商":  "  s / x / X       sort / hide / show all providers",
+	"  < / >           调整左右面板比例":           "  < / >           resize the left and right panels",
+	"  Ctrl+Z / Ctrl+Y 撤销 / 重做上述显示调整":      "  Ctrl+Z / Ctrl+Y undo / redo the view changes above",
+	"其他": "Other",
+	"  Ctrl+F          全局搜索提供商、方案和每日消费":          "  Ctrl+F          search providers, alternatives and daily spend",
+	"  f               提示模式：按字母激活标签或列表项":         "  f               hint mode: activate tabs or rows by letter",
+	"  :               按提供商 ID 或序号跳转":            "  :               jump to a provider by ID or position",
+	"  P               计划：对比 --plan 文件并选择要应用的变更": "  P               plan: compare with the --plan file and pick changes to apply",
+	"  R               回滚到上次批量操作之前":              "  R               roll back to before the last batch operation",
+	"  Ctrl+A          切换账户":                     "  Ctrl+A          switch account",
+	"  ?               显示/隐藏帮助":                  "  ?               show/hide help",
+	"  Ctrl+D          显示/隐藏诊断信息":                "  Ctrl+D          show/hide diagnostics",
+	"按 Esc 或 ? 键关闭此帮助":                           "Press Esc or ? to close this help",
+	"1. 用户资料":                                    "1. Profile",
+	"2. 提供商":                                     "2. Providers",
+	"3. 余额使用偏好":                                  "3. Balance preference",
+	"4. 统计":                                      "4. Stats",
+	"未加载期望状态文件，请使用 --plan 指定":                    "No desired-state file loaded; pass one with --plan",
+	"计划":                 "Plan",
+	"应用 %d 项":            "Apply %d",
+	"取消":                 "Cancel",
+	"正在对比当前状态... %s":     "Comparing with the current state... %s",
+	"正在应用，请稍候...":        "Applying, please wait...",
+	"↑↓ 移动 · 空格 选择/取消":   "↑↓ move · Space select/deselect",
+	"      %s → %s（跳过）":  "      %s → %s (skipped)",
+	"解析状态文件失败: %w":       "Failed to parse state file: %w",
+	"最近使用":               "Recently used",
+	" · 已选 %d 次":         " · picked %d×",
+	"保存最近使用记录失败: %v":     "Failed to save recent usage: %v",
+	"加载失败，已跳过":           "Failed to load, skipped",
+	"无官方方案，已跳过":          "No official alternative, skipped",
+	"已是官方方案":             "Already on the official alternative",
+	"恢复 %d 个提供商":         "Restore %d providers",
+	"已取消选择":              "Deselected",
+	"全部恢复默认":             "Restore all defaults",
+	"：":                  ": ",
+	"%s%s → %s（跳过）":      "%s%s → %s (skipped)",
+	"%s切换到 %s 中... %s":   "%sSwitching to %s... %s",
+	"%s%s %s → %s 失败：%v": "%s%s %s → %s failed: %v",
+	"正在恢复，请稍候...":        "Restoring, please wait...",
+	"正在加载提供商详情...":       "Loading provider details...",
+	"未选择任何提供商 · 空格 选择":   "No providers selected · Space to select",
+	"所有提供商均已使用官方方案":      "All providers already use their official alternative",
+	"回滚":           "Rollback",
+	"搜索：":          "Search: ",
+	"提供商、方案或日期":    "provider, alternative or date",
+	"备选方案":         "Alternatives",
+	"%s ×%.2f（%s）": "%s ×%.2f (%s)",
+	"1月2日":         "Jan 2",
+	"消费记录":         "Spending",
+	"%s（%s）%s":     "%s (%s) %s",
+	"全局搜索":         "Search",
+	"  输入关键字搜索提供商、已加载的备选方案和每日消费": "  Type to search providers, loaded alternatives and daily spend",
+	"  无匹配结果":                   "  No matches",
+	"  共 %d 条结果":                "  %d results",
+	"↑↓ 选择 · Enter 跳转 · Esc 关闭": "↑↓ select · Enter go · Esc close",
+	"读取本地统计失败: %v":              "Failed to read local stats: %v",
+	"记录本地采样失败: %v":              "Failed to record local sample: %v",
+	"本地采样未启用，无法显示消费统计":          "Local sampling is disabled, so spending stats are unavailable",
+	"近 %d 天每日消费":                "Daily spend, last %d days",
+	"数据来自本地采样（程序运行时每 5 分钟记录一次）· ←→ 选择日期 · r 刷新": "From local samples (recorded every 5 minutes while running) · ←→ pick a day · r refresh",
+	"%s（%s）":    "%s (%s)",
+	"%s %s：未采样": "%s %s: not sampled",
+	"%s %s：%s":  "%s %s: %s",
+	"  合计 %s · 日均 %s · 最高 %s · 已采样 %d/%d 天": "  Total %s · daily average %s · highest %s · sampled %d/%d days",
+	"（另有 %d 天为估算）":                          " (plus %d estimated)",
+	"%s %s：约 %s（当天未采样，按前后两次采样估算）":           "%s %s: about %s (not sampled that day, estimated from the samples either side)",
+	"周日":             "Sun",
+	"周一":             "Mon",
+	"周二":             "Tue",
+	"周三":             "Wed",
+	"周四":             "Thu",
+	"周五":             "Fri",
+	"周六":             "Sat",
+	"提供商 %d":         "Provider %d",
+	"    %s %s（未生效）": "    %s %s (not applied)",
+	"%d 项失败":         "%d failed",
+	"消费趋势（近 %s）":     "Spend trend (last %s)",
+	"  正在采集数据，刷新几次后显示": "  Collecting data, shown after a few refreshes",
+	"前":                        " ago",
+	"现在":                       "now",
+	"  %s  合计 %s · 峰值 %s / %s": "  %s  total %s · peak %s / %s",
+	"%d 小时":                    "%dh",
+	"%d 分钟":                    "%dm",
+	"%s 分钟":                    "%sm",
+	"没有可撤销的操作":                 "Nothing to undo",
+	"已撤销：%s":                   "Undone: %s",
+	"没有可重做的操作":                 "Nothing to redo",
+	"已重做：%s":                   "Redone: %s",
+	"再按 Esc 或 q 退出":            "Press Esc or q again to quit",
+	"确定退出？":                    "Quit?",
+	"退出前弹出“确定退出？”确认框":                                   "Ask \"Quit?\" in a dialog before quitting",
+	"支持鼠标操作 · Enter 确认 · Esc 返回 · q 退出 · 输入 ? 查看完整操作帮助": "Mouse supported · Enter confirm · Esc back · q quit · ? for full help",
+	"  Esc             关闭对话框、清除筛选或焦点，连按两次退出":            "  Esc             close dialogs, clear the filter or focus; press twice to quit",
+	"  q / Ctrl+Q      退出程序":                            "  q               quit",
+	"  Ctrl+C          立即退出程序":                          "  Ctrl+C          quit immediately",
+	"▼ 还有 %d 行":                                         "▼ %d more lines",
+	" 还有 %d 行":                                          " %d more lines",
+	"更新于 ":                                              "Updated ",
+	"服务异常":                                              "Service error",
+	"离线":                                                "Offline",
+	"连接中":                                               "Connecting",
+	"在线":                                                "Online",
+	"↑↓ 选择":                                             "↑↓ select",
+	"→ 方案":                                              "→ alternatives",
+	"/ 筛选":                                              "/ filter",
+	"s 排序":                                              "s sort",
+	"r 刷新":                                              "r refresh",
+	"Enter 切换":                                          "Enter switch",
+	"d 恢复默认":                                            "d restore default",
+	"← 提供商":                                             "← providers",
+	"←→ 选择日期":                                           "←→ pick a day",
+	"↑↓ 滚动":                                             "↑↓ scroll",
+	"? 帮助":                                              "? help",
+	"PIN：":                                              "PIN: ",
+	" YesCode 已锁定":                                      " YesCode locked",
+	"按任意键继续":                                            "Press any key to continue",
+	"PIN 错误":                                            "Wrong PIN",
+	"输入 PIN 后按 Enter 解锁 · Ctrl+C 退出":                      "Type the PIN and press Enter to unlock · Ctrl+C quit",
+	"无操作多久后锁定屏幕并暂停刷新（例如 10m，0 表示不锁定）":                     "Lock the screen and pause refreshing after this long without input (e.g. 10m, 0 disables)",
+	"解锁屏幕所需的 PIN（也可设置环境变量 YESCODE_LOCK_PIN），不设置时按任意键即可解锁": "PIN required to unlock the screen (or set YESCODE_LOCK_PIN); without one any key unlocks",
+	"E 详情":               "E details",
+	"本次会话暂无 API 错误":      "No API errors this session",
+	"已复制到剪贴板":            "Copied to the clipboard",
+	"Enter 修改偏好":         "Enter change preference",
+	"Enter 在控制台查看订阅计划":   "Enter view plans in the console",
+	"Enter 充值":           "Enter top up",
+	"在控制台充值按需余额：%s（已复制）": "Top up your pay-as-you-go balance in the console: %s (copied)",
+	"已在浏览器中打开控制台":        "Opened the console in the browser",
+	"  Enter（资料）    选中余额偏好、订阅计划或按需余额时执行对应操作":      "  Enter (profile)  act on the preference, plan or pay-as-you-go row",
+	"在后续页中查找提供商 %d...":                            "Looking for provider %d on later pages...",
+	"提供商 %d 已隐藏或不在当前筛选结果中":                        "Provider %d is hidden or filtered out",
+	"加载提供商列表失败：%v":                                "Failed to load the provider list: %v",
+	"正在加载完整的提供商列表... %s":                          "Loading the full provider list... %s",
+	"%s 今日消费异常：%s× 平均":                            "%s Unusual spend today: %s× the average",
+	"  今日 %s，近 %d 天日均 %s，请检查是否有失控的任务或泄露的 API Key": "  %s today vs. a %d-day daily average of %s; check for runaway jobs or a leaked API key",
+	"今日消费达到近 14 天日均的多少倍时在用户资料页顶部提示异常（0 表示不提示）":    "Warn at the top of the profile tab when today's spend reaches this multiple of the 14-day daily average (0 disables it)",
+	" · 实时推送":     " · live",
+	"近 %d 周消费热力图": "Daily spend, last %d weeks",
+	" 起，每列一周":     " onwards, one week per column",
+	"少 ":          "Less ",
+	" 多 · 空白为未采样": " More · blank: not sampled",
+	"订阅服务端事件流（实验性，该接口不在公开的 API 文档中），连接期间暂停轮询用户资料；默认始终定时轮询": "Subscribe to the server's event stream (experimental; the endpoint isn't in the published API documentation) and pause profile polling while connected; by default the profile is always polled",
+	"已复制：%s": "Copied: %s",
+	"复制":     "Copy",
+	"错误详情":   "Error details",
+	"状态码":    "Status",
+	"请求":     "Request",
+	"时间":     "Time",
+	"消息":     "Message",
+	"原始响应":   "Raw response",
+	"（空）":    "(empty)",
+	"… 另有 %d 行，复制后查看完整内容":                                  "… %d more lines; copy to see everything",
+	"  E               查看最近一次 API 错误的完整响应":                 "  E               show the full response of the latest API error",
+	"另一终端已将 %s 切换到 %s":                                     "Another terminal switched %s to %s",
+	"另一终端已将余额偏好切换为 %s":                                     "Another terminal changed the balance preference to %s",
+	"另一个 yc 实例（PID %d）正在切换该提供商":                            "Another yc instance (PID %d) is switching this provider",
+	"另一个 yc 实例（PID %d）正在切换余额偏好":                            "Another yc instance (PID %d) is changing the balance preference",
+	"记录每个 API 请求的方法、路径、状态码和耗时到日志文件，并可按 Ctrl+L 查看":          "log each API request's method, path, status and duration to a file, viewable with Ctrl+L",
+	"调试日志同时记录请求和响应内容（密钥、令牌和邮箱等字段会被移除），需配合 --debug":         "also log request and response bodies, with keys, tokens, emails and similar fields removed (requires --debug)",
+	"调试日志文件路径（默认为配置目录下的 debug.log，超过 1 MiB 时轮转，保留 3 个旧文件）": "debug log path (default debug.log in the config directory; rotated at 1 MiB, keeping 3 old files)",
+	"请求日志需要使用 --debug 启动":                                  "The request log requires starting with --debug",
+	"请求日志":  "Request log",
+	"写入 %s": "Writing to %s",
+	"还没有请求": "No requests yet",
+	"事件日志需要使用 --debug-ui 启动": "The event log requires starting with --debug-ui",
+	"无法确定事件日志路径: %v":         "Cannot determine the event log path: %v",
+	"打开事件日志失败: %v":           "Failed to open the event log: %v",
+	"记录界面处理的每条消息（按键、鼠标、窗口大小、加载结果）到配置目录下的 events.log，并可按 Ctrl+E 查看": "Record every message the interface handles (keys, mouse, window size, load results) to events.log in the config directory, viewable with Ctrl+E",
+	"  Ctrl+P          显示/隐藏性能信息（渲染耗时、消息速率、协程数）":                   "  Ctrl+P          Toggle performance info (render time, message rate, goroutines)",
+	"渲染":    "Render",
+	"平均渲染":  "Avg render",
+	"最长渲染":  "Max render",
+	"消息/秒":  "Msgs/s",
+	"协程":    "Goroutines",
+	"事件日志":  "Event log",
+	"还没有事件": "No events yet",
+	"内容":    "Detail",
+	"错误":    "Error",
+	"请求内容":  "Request body",
+	"响应内容":  "Response body",
+	"使用 --debug-bodies 启动可记录请求和响应内容":     "Start with --debug-bodies to record request and response bodies",
+	"  Ctrl+L          请求日志（需要 --debug）": "  Ctrl+L          Request log (requires --debug)",
+	"打开调试日志失败: %v":                       "Failed to open the debug log: %v",
+	"无法确定调试日志路径: %v":                     "Cannot determine the debug log path: %v",
+	"读取实例协调文件失败: %v":                     "Failed to read the instance coordination file: %v",
+	"本地记录（余额采样、最近使用的方案）的存储方式：json（每类一个文件）或 sqlite（单个 yc.db 数据库，首次使用时导入已有的 JSON 记录）": "Storage for local records (balance samples, recently used alternatives): json (one file each) or sqlite (a single yc.db database that imports the existing JSON records on first use)",
+	"第一次重试前的等待时间，之后每次重试翻倍（另加 ±50% 随机抖动）":                                            "Wait before the first retry, doubled for each further retry (plus ±50% random jitter)",
+	"两次重试之间的最长等待时间（0 表示不限制）":                                                        "Longest wait between two retries (0 means no limit)",
+	"--retry-backoff 和 --retry-max-delay 不能为负数":                                     "--retry-backoff and --retry-max-delay must not be negative",
+	"加密配置目录中的本地记录、回滚点、实例协调文件和日志，密钥保存在系统钥匙串中（仅支持 --store json）":                      "Encrypt the local records, rollback points, instance coordination file and logs in the config directory with a key kept in the OS keyring (--store json only)",
+	"读取加密密钥失败: %v": "Failed to read the encryption key: %v",
+	"用法: yc decrypt <文件>（例如配置目录下的 debug.log 或 rollback/ 中的回滚点）": "Usage: yc decrypt <file> (e.g. debug.log or a rollback point under rollback/ in the config directory)",
+	"读取文件失败: %v":     "Failed to read the file: %v",
+	"解密失败: %v":       "Decryption failed: %v",
+	"请求过于频繁，%d 秒后重试": "Rate limited, retrying in %ds",
+	"限流中":            "Rate limited",
+	"每次 API 调用的总时限，包括重试和重试前的等待":                  "Time limit for each API call, retries and the waits before them included",
+	"单次 HTTP 请求（含读取响应）的时限，超时后按重试策略重试":            "Time limit for one HTTP request, reading the response included; timed-out requests are retried per the retry policy",
+	"--timeout 和 --attempt-timeout 必须大于 0":       "--timeout and --attempt-timeout must be greater than 0",
+	"同时测试写入接口：重新提交当前的余额偏好和第一个提供商的当前方案（不会改变账户状态）": "Also test the write endpoints by resubmitting the current balance preference and the first provider's current alternative (the account does not change)",
+	"方法":                  "Method",
+	"接口":                  "Endpoint",
+	"耗时":                  "Time",
+	"结果":                  "Result",
+	"       响应中多出的字段：":    "       Fields not in the client: ",
+	"       响应中缺少的字段：":    "       Fields missing from the response: ",
+	"\n共 %d 个接口，%d 个失败\n": "\n%d endpoints, %d failed\n",
+	"正常":                  "OK",
+	"API Key 无效":          "Invalid API key",
+	"当前套餐无权访问":            "Not available on the current plan",
+	"失败：":                 "Failed: ",
+	"代理地址，支持 http://、https://、socks5:// 和 socks5h://（默认读取 HTTPS_PROXY、HTTP_PROXY、ALL_PROXY 和 NO_PROXY 环境变量）": "Proxy URL: http://, https://, socks5:// or socks5h:// (defaults to the HTTPS_PROXY, HTTP_PROXY, ALL_PROXY and NO_PROXY environment variables)",
+	"额外信任的 CA 证书文件（PEM），用于使用私有 CA 的自建或企业网关端点":                                                                "Extra CA certificate file (PEM) to trust, for self-hosted or corporate-gateway endpoints with a private CA",
+	"双向 TLS 的客户端证书文件（PEM），需配合 --client-key":                                                                  "Client certificate file (PEM) for mutual TLS; requires --client-key",
+	"不在内存中缓存提供商列表和方案（默认缓存 10–30 秒，切换方案或按 r 刷新时清空）":                                                           "Don't cache the provider list and alternatives in memory (cached for 10–30s by default, cleared on switching or pressing r)",
+	"演示模式：使用内置的示例数据运行，不需要 API Key，也不读写本地记录":                                                                  "Demo mode: run on built-in sample data without an API key, leaving local records untouched",
+	"从文件或命名管道逐行读取按键脚本驱动界面（忽略键盘输入，脚本结束时退出），用于录制演示和端到端测试":                                                      "Drive the interface from a key script read line by line from a file or named pipe (keyboard input is ignored, exits when the script ends), for demo recordings and end-to-end tests",
+	"调试用：随机注入故障以测试错误处理，例如 timeout=0.1,500=0.2,slow=0.3,delay=5s（概率 0–1）":                                     "Debugging: inject random faults to exercise error handling, e.g. timeout=0.1,500=0.2,slow=0.3,delay=5s (probabilities 0–1)",
+	"双向 TLS 的客户端私钥文件（PEM），需配合 --client-cert":                                                                 "Client private key file (PEM) for mutual TLS; requires --client-cert",
+	"不访问 API，改为检查目录中录制的响应（文件名为 类型[.说明].json，例如 internal/api/fixtures）":                                       "Check the recorded responses in a directory instead of calling the API (files named KIND[.NOTE].json, e.g. internal/api/fixtures)",
+	"读取录制的响应失败: %v":           "Failed to read recorded responses: %v",
+	"目录 %s 中没有录制的响应":          "No recorded responses in %s",
+	"%s: %v（可用类型：%s）":         "%s: %v (kinds: %s)",
+	"\n共 %d 个录制的响应，%d 个不匹配\n": "\n%d recorded responses, %d do not match\n",
+}
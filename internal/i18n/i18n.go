@@ -0,0 +1,87 @@
+// Package i18n translates the user interface. Messages are written in Chinese
+// in the source and double as catalog keys; other languages map them to their
+// translation, and a message missing from a catalog is shown in Chinese.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Language names accepted by SetLang.
+const (
+	LangZH = "zh-CN"
+	LangEN = "en-US"
+)
+
+// catalogs maps each non-Chinese language to its translations.
+var catalogs = map[string]map[string]string{
+	LangEN: en,
+}
+
+var (
+	lang    = LangZH
+	catalog map[string]string // nil for Chinese
+)
+
+// Langs lists the supported language names.
+func Langs() []string {
+	return []string{LangZH, LangEN}
+}
+
+// Lang returns the active language.
+func Lang() string {
+	return lang
+}
+
+// SetLang selects the interface language. Names are matched like locales:
+// case-insensitively, "_" may replace "-", and a bare language such as "en"
+// picks its default region. An empty name or "auto" uses Detect.
+func SetLang(name string) error {
+	name = strings.ReplaceAll(strings.TrimSpace(name), "_", "-")
+	if name == "" || strings.EqualFold(name, "auto") {
+		name = Detect()
+	}
+	for _, known := range Langs() {
+		if strings.EqualFold(name, known) || strings.EqualFold(name, known[:2]) {
+			lang, catalog = known, catalogs[known]
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown language %q", name)
+}
+
+// Detect picks the language from LC_ALL, LC_MESSAGES and LANG, in the order
+// POSIX gives them precedence. Chinese locales select Chinese, other
+// languages English; an unset or C/POSIX locale keeps Chinese.
+func Detect() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := strings.TrimSpace(os.Getenv(key))
+		if value == "" {
+			continue
+		}
+		switch code := strings.ToLower(value); {
+		case code == "c" || code == "posix" || strings.HasPrefix(code, "c."):
+			return LangZH
+		case strings.HasPrefix(code, "zh"):
+			return LangZH
+		default:
+			return LangEN
+		}
+	}
+	return LangZH
+}
+
+// T translates msg.
+func T(msg string) string {
+	if s, ok := catalog[msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Tf translates format and formats it with args like fmt.Sprintf.
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
@@ -0,0 +1,57 @@
+package localdb
+
+import (
+	"database/sql"
+	"time"
+
+	"yescode-tui/internal/history"
+)
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// historyBackend stores samples in the samples table, times as Unix
+// nanoseconds.
+type historyBackend struct {
+	db *sql.DB
+}
+
+func (b historyBackend) Append(sample history.Sample) error {
+	return insertSample(b.db, sample)
+}
+
+func (b historyBackend) Load(account string, since time.Time) ([]history.Sample, error) {
+	rows, err := b.db.Query(`SELECT time, balance, subscription_balance, payg_balance, week_spend, month_spend
+		FROM samples WHERE account = ? AND time >= ? ORDER BY time`, account, since.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []history.Sample
+	for rows.Next() {
+		sample := history.Sample{Account: account}
+		var nanos int64
+		if err := rows.Scan(&nanos, &sample.Balance, &sample.SubscriptionBalance, &sample.PaygBalance, &sample.WeekSpend, &sample.MonthSpend); err != nil {
+			return nil, err
+		}
+		sample.Time = time.Unix(0, nanos)
+		out = append(out, sample)
+	}
+	return out, rows.Err()
+}
+
+func (b historyBackend) Prune(cutoff time.Time) error {
+	_, err := b.db.Exec(`DELETE FROM samples WHERE time < ?`, cutoff.UnixNano())
+	return err
+}
+
+func insertSample(db execer, sample history.Sample) error {
+	_, err := db.Exec(`INSERT INTO samples (time, account, balance, subscription_balance, payg_balance, week_spend, month_spend)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		sample.Time.UnixNano(), sample.Account, sample.Balance, sample.SubscriptionBalance,
+		sample.PaygBalance, sample.WeekSpend, sample.MonthSpend)
+	return err
+}
@@ -0,0 +1,164 @@
+// Package localdb keeps the locally recorded balance samples and provider
+// usage in one SQLite database, as an alternative to the JSON files the
+// history and recent packages write by default.
+package localdb
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	_ "modernc.org/sqlite" // 纯 Go 驱动，无需 cgo
+
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/recent"
+	"yescode-tui/internal/sealed"
+)
+
+// migrations upgrade the schema one version at a time; the database's
+// user_version counts the ones applied. Append only, never edit.
+var migrations = []string{
+	`CREATE TABLE samples (
+		time                 INTEGER NOT NULL,
+		account              TEXT    NOT NULL,
+		balance              REAL    NOT NULL,
+		subscription_balance REAL    NOT NULL,
+		payg_balance         REAL    NOT NULL,
+		week_spend           REAL    NOT NULL,
+		month_spend          REAL    NOT NULL
+	);
+	CREATE INDEX samples_account_time ON samples (account, time);
+	CREATE INDEX samples_time ON samples (time);
+
+	CREATE TABLE recent_usage (
+		account        TEXT    NOT NULL,
+		provider_id    INTEGER NOT NULL,
+		alternative_id INTEGER NOT NULL,
+		count          INTEGER NOT NULL,
+		last_used      INTEGER NOT NULL,
+		PRIMARY KEY (account, provider_id, alternative_id)
+	);`,
+}
+
+// DB is an open local database.
+type DB struct {
+	db *sql.DB
+	// created is set when Open made the schema from scratch.
+	created bool
+}
+
+// Open opens the database at path, creating it and applying any pending
+// migrations.
+func Open(path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	// 多个实例可能同时写入：WAL 允许读写并发，busy_timeout 让写入排队而不是立即失败
+	dsn := "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	d := &DB{db: db}
+	if err := d.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *DB) migrate() error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var version int
+	if err := tx.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this yc supports (%d)", version, len(migrations))
+	}
+	for i := version; i < len(migrations); i++ {
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			return fmt.Errorf("migrate schema to version %d: %w", i+1, err)
+		}
+	}
+	// PRAGMA 不支持参数占位符
+	if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, len(migrations))); err != nil {
+		return err
+	}
+	d.created = version == 0
+	return tx.Commit()
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// Created reports whether Open created the database, so ImportFiles has
+// something to do.
+func (d *DB) Created() bool {
+	return d.created
+}
+
+// ImportFiles copies the samples of the JSON Lines history file and the usage
+// of the recent JSON file into a database Open just created, so switching
+// backends keeps what was recorded so far. Files written with
+// --encrypt-state are opened with box. It does nothing for an existing
+// database; missing files are skipped.
+func (d *DB) ImportFiles(historyPath, recentPath string, box *sealed.Box) error {
+	if !d.created {
+		return nil
+	}
+	samples, err := history.ReadFile(historyPath, box)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", historyPath, err)
+	}
+	data, err := recent.ReadFile(recentPath, box)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", recentPath, err)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, sample := range samples {
+		if err := insertSample(tx, sample); err != nil {
+			return err
+		}
+	}
+	for account, providers := range data {
+		for key, usages := range providers {
+			providerID, err := strconv.Atoi(key)
+			if err != nil {
+				continue
+			}
+			if err := replaceUsage(tx, account, providerID, usages); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// History returns the database as a history backend.
+func (d *DB) History() history.Backend {
+	return historyBackend{d.db}
+}
+
+// Recent returns the database as a recent-usage backend.
+func (d *DB) Recent() recent.Backend {
+	return recentBackend{d.db}
+}
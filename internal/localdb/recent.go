@@ -0,0 +1,73 @@
+package localdb
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"yescode-tui/internal/recent"
+)
+
+// recentBackend stores usage in the recent_usage table, one row per
+// alternative, times as Unix nanoseconds.
+type recentBackend struct {
+	db *sql.DB
+}
+
+func (b recentBackend) Load() (recent.Data, error) {
+	rows, err := b.db.Query(`SELECT account, provider_id, alternative_id, count, last_used
+		FROM recent_usage ORDER BY last_used DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := make(recent.Data)
+	for rows.Next() {
+		var (
+			account    string
+			providerID int
+			usage      recent.Usage
+			nanos      int64
+		)
+		if err := rows.Scan(&account, &providerID, &usage.AlternativeID, &usage.Count, &nanos); err != nil {
+			return nil, err
+		}
+		usage.LastUsed = time.Unix(0, nanos)
+		providers, ok := data[account]
+		if !ok {
+			providers = make(map[string][]recent.Usage)
+			data[account] = providers
+		}
+		key := strconv.Itoa(providerID)
+		providers[key] = append(providers[key], usage)
+	}
+	return data, rows.Err()
+}
+
+func (b recentBackend) Save(account string, providerID int, usages []recent.Usage) error {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := replaceUsage(tx, account, providerID, usages); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// replaceUsage swaps the provider's rows for usages.
+func replaceUsage(tx *sql.Tx, account string, providerID int, usages []recent.Usage) error {
+	if _, err := tx.Exec(`DELETE FROM recent_usage WHERE account = ? AND provider_id = ?`, account, providerID); err != nil {
+		return err
+	}
+	for _, usage := range usages {
+		if _, err := tx.Exec(`INSERT INTO recent_usage (account, provider_id, alternative_id, count, last_used)
+			VALUES (?, ?, ?, ?, ?)`,
+			account, providerID, usage.AlternativeID, usage.Count, usage.LastUsed.UnixNano()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
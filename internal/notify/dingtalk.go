@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const dingTalkAPIBase = "https://oapi.dingtalk.com/robot/send"
+
+// DingTalk sends messages through a DingTalk group robot webhook.
+type DingTalk struct {
+	token      string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewDingTalk builds a DingTalk channel. secret is optional and enables signed requests.
+func NewDingTalk(token, secret string) (*DingTalk, error) {
+	if token == "" {
+		return nil, errors.New("dingtalk access token is required")
+	}
+	return &DingTalk{token: token, secret: secret, httpClient: &http.Client{Timeout: defaultSendTimeout}}, nil
+}
+
+// Name implements Channel.
+func (d *DingTalk) Name() string { return "dingtalk" }
+
+// Send implements Channel.
+func (d *DingTalk) Send(ctx context.Context, text string) error {
+	query := url.Values{"access_token": {d.token}}
+	if d.secret != "" {
+		// 加签：timestamp + "\n" + secret 的 HMAC-SHA256
+		ts := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		mac := hmac.New(sha256.New, []byte(d.secret))
+		mac.Write([]byte(ts + "\n" + d.secret))
+		query.Set("timestamp", ts)
+		query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+
+	payload := map[string]any{
+		"msgtype": "text",
+		"text":    map[string]string{"content": text},
+	}
+	var resp struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := postJSON(ctx, d.httpClient, dingTalkAPIBase+"?"+query.Encode(), payload, &resp); err != nil {
+		return err
+	}
+	if resp.ErrCode != 0 {
+		return fmt.Errorf("errcode=%d errmsg=%s", resp.ErrCode, resp.ErrMsg)
+	}
+	return nil
+}
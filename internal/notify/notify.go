@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Supported message languages.
+const (
+	LangZH = "zh"
+	LangEN = "en"
+)
+
+// Event describes an alert that should be delivered to notification channels.
+type Event struct {
+	Rule      string
+	Metric    string
+	Op        string
+	Value     float64
+	Threshold float64
+	Account   string
+	Time      time.Time
+}
+
+// Channel delivers rendered text to an external service.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, text string) error
+}
+
+// Dispatcher renders events with the configured language and fans them out to channels.
+type Dispatcher struct {
+	channels []Channel
+	lang     string
+}
+
+// NewDispatcher builds a Dispatcher. Unknown languages fall back to Chinese.
+func NewDispatcher(lang string, channels ...Channel) *Dispatcher {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if _, ok := templates[lang]; !ok {
+		lang = LangZH
+	}
+	return &Dispatcher{channels: channels, lang: lang}
+}
+
+// Empty reports whether no channel is configured.
+func (d *Dispatcher) Empty() bool {
+	return d == nil || len(d.channels) == 0
+}
+
+// Dispatch renders the event and sends it to every channel, joining failures.
+func (d *Dispatcher) Dispatch(ctx context.Context, ev Event) error {
+	if d.Empty() {
+		return nil
+	}
+	text, err := Render(d.lang, ev)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, ch := range d.channels {
+		if err := ch.Send(ctx, text); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ch.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Render formats the event using the template for lang.
+func Render(lang string, ev Event) (string, error) {
+	tmpl, ok := templates[lang]
+	if !ok {
+		tmpl = templates[LangZH]
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		Event
+		Label string
+	}{ev, metricLabel(lang, ev.Metric)}); err != nil {
+		return "", fmt.Errorf("render notification: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+var templates = map[string]*template.Template{
+	LangZH: template.Must(template.New(LangZH).Parse(
+		`【YesCode 提醒】{{if .Account}}{{.Account}} {{end}}{{.Label}}当前为 {{printf "%.2f" .Value}}，触发规则 {{.Rule}}（阈值 {{printf "%.2f" .Threshold}}）
+时间：{{.Time.Format "2006-01-02 15:04:05"}}`)),
+	LangEN: template.Must(template.New(LangEN).Parse(
+		`[YesCode Alert] {{if .Account}}{{.Account}} {{end}}{{.Label}} is {{printf "%.2f" .Value}}, rule {{.Rule}} fired (threshold {{printf "%.2f" .Threshold}})
+Time: {{.Time.Format "2006-01-02 15:04:05"}}`)),
+}
+
+var metricLabels = map[string]map[string]string{
+	LangZH: {
+		"balance":              "总余额",
+		"subscription_balance": "订阅余额",
+		"payg_balance":         "按需余额",
+		"week_spend":           "本周消费",
+		"month_spend":          "本月消费",
+		"week_pct":             "本周额度使用率",
+		"month_pct":            "本月额度使用率",
+		"spend_ratio":          "今日消费与近期日均之比",
+	},
+	LangEN: {
+		"balance":              "total balance",
+		"subscription_balance": "subscription balance",
+		"payg_balance":         "pay-as-you-go balance",
+		"week_spend":           "weekly spend",
+		"month_spend":          "monthly spend",
+		"week_pct":             "weekly limit usage (%)",
+		"month_pct":            "monthly limit usage (%)",
+		"spend_ratio":          "today's spend vs. the daily average (×)",
+	},
+}
+
+func metricLabel(lang, metric string) string {
+	if label, ok := metricLabels[lang][metric]; ok {
+		return label
+	}
+	return metric
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+const telegramAPIBase = "https://api.telegram.org"
+
+// Telegram sends messages through a Telegram bot.
+type Telegram struct {
+	token      string
+	chatID     string
+	httpClient *http.Client
+}
+
+// NewTelegram builds a Telegram channel from a bot token and target chat ID.
+func NewTelegram(token, chatID string) (*Telegram, error) {
+	if token == "" || chatID == "" {
+		return nil, errors.New("telegram bot token and chat id are required")
+	}
+	return &Telegram{token: token, chatID: chatID, httpClient: &http.Client{Timeout: defaultSendTimeout}}, nil
+}
+
+// Name implements Channel.
+func (t *Telegram) Name() string { return "telegram" }
+
+// Send implements Channel.
+func (t *Telegram) Send(ctx context.Context, text string) error {
+	endpoint := telegramAPIBase + "/bot" + t.token + "/sendMessage"
+	payload := map[string]string{"chat_id": t.chatID, "text": text}
+	var resp struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err := postJSON(ctx, t.httpClient, endpoint, payload, &resp); err != nil {
+		return err
+	}
+	if !resp.OK {
+		return errors.New(resp.Description)
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+// Package peer coordinates yc instances running against the same account.
+// Instances share one JSON board file: a switch in progress is claimed there
+// so another instance can't start a conflicting one, and completed changes
+// are published as events the other instances pick up.
+package peer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"yescode-tui/internal/sealed"
+)
+
+const (
+	// maxEvents bounds the events kept on the board; instances poll often
+	// enough that older ones have long been seen.
+	maxEvents = 32
+	// lockWait is how long an instance waits for another one holding the board.
+	lockWait = time.Second
+	// staleLock is the age after which a lock file is considered abandoned.
+	staleLock = 5 * time.Second
+)
+
+// ErrBusy is returned by Claim when another instance holds the target.
+var ErrBusy = errors.New("target claimed by another instance")
+
+// Kinds of events.
+const (
+	KindSwitch     = "switch"
+	KindPreference = "preference"
+)
+
+// Event is a change completed by one instance.
+type Event struct {
+	Seq           int       `json:"seq"`
+	PID           int       `json:"pid"`
+	Account       string    `json:"account"`
+	Kind          string    `json:"kind"`
+	ProviderID    int       `json:"provider_id,omitempty"`
+	AlternativeID int       `json:"alternative_id,omitempty"`
+	Name          string    `json:"name,omitempty"` // 方案名称或余额偏好
+	Time          time.Time `json:"time"`
+}
+
+// Claim marks a change one instance has in flight.
+type Claim struct {
+	PID     int       `json:"pid"`
+	Account string    `json:"account"`
+	Target  string    `json:"target"`
+	Expires time.Time `json:"expires"`
+}
+
+type board struct {
+	Claims []Claim `json:"claims"`
+	Events []Event `json:"events"`
+}
+
+// Logger receives problems with the board file that an instance works
+// around rather than fails on. *debuglog.Log implements it.
+type Logger interface {
+	Logf(format string, args ...any)
+}
+
+// Board is this instance's handle on the shared board file.
+type Board struct {
+	path string
+	pid  int
+	box  *sealed.Box
+	log  Logger
+
+	mu      sync.Mutex
+	lastSeq int // 已处理的最大事件序号
+}
+
+// Open reads the board at path and starts after its existing events, so a
+// new instance only hears about changes made from now on. A missing file
+// yields an empty board.
+func Open(path string) (*Board, error) {
+	return OpenSealed(path, nil, nil)
+}
+
+// OpenSealed is Open with the board file encrypted by box. Instances sharing
+// the board must use the same setting; a board one can't read is treated as
+// corrupt and started afresh. The corrupt file is kept next to the board
+// with a .bad suffix and reported to log, which may be nil.
+func OpenSealed(path string, box *sealed.Box, log Logger) (*Board, error) {
+	b := &Board{path: path, pid: os.Getpid(), box: box, log: log}
+	data, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+	b.lastSeq = lastSeq(data)
+	return b, nil
+}
+
+// SwitchTarget names the claim for switching a provider.
+func SwitchTarget(providerID int) string {
+	return fmt.Sprintf("provider:%d", providerID)
+}
+
+// PreferenceTarget names the claim for changing the balance preference.
+const PreferenceTarget = "preference"
+
+// Claim reserves target on account for ttl. When another live instance
+// already holds it, Claim returns that instance's PID and ErrBusy.
+func (b *Board) Claim(account, target string, ttl time.Duration) (int, error) {
+	if b == nil {
+		return 0, nil
+	}
+	owner := 0
+	err := b.update(func(data *board, now time.Time) {
+		for _, c := range data.Claims {
+			if c.Account == account && c.Target == target && c.PID != b.pid {
+				owner = c.PID
+				return
+			}
+		}
+		data.Claims = append(removeClaim(data.Claims, b.pid, account, target),
+			Claim{PID: b.pid, Account: account, Target: target, Expires: now.Add(ttl)})
+	})
+	if err != nil {
+		return 0, err
+	}
+	if owner != 0 {
+		return owner, ErrBusy
+	}
+	return 0, nil
+}
+
+// Release drops this instance's claim on target and, when ev is non-nil,
+// publishes it to the other instances.
+func (b *Board) Release(account, target string, ev *Event) error {
+	if b == nil {
+		return nil
+	}
+	return b.update(func(data *board, now time.Time) {
+		data.Claims = removeClaim(data.Claims, b.pid, account, target)
+		if ev == nil {
+			return
+		}
+		e := *ev
+		e.Seq = lastSeq(data) + 1
+		e.PID = b.pid
+		e.Account = account
+		e.Time = now
+		data.Events = append(data.Events, e)
+		if len(data.Events) > maxEvents {
+			data.Events = data.Events[len(data.Events)-maxEvents:]
+		}
+	})
+}
+
+// Poll returns the events other instances published since the last poll.
+func (b *Board) Poll() ([]Event, error) {
+	if b == nil {
+		return nil, nil
+	}
+	data, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var events []Event
+	for _, e := range data.Events {
+		if e.Seq > b.lastSeq && e.PID != b.pid {
+			events = append(events, e)
+		}
+	}
+	b.lastSeq = max(b.lastSeq, lastSeq(data))
+	return events, nil
+}
+
+// update applies fn to the board under the file lock, dropping expired
+// claims first, and saves the result.
+func (b *Board) update(fn func(data *board, now time.Time)) error {
+	unlock, err := b.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := b.readFile()
+	var corrupt *corruptError
+	if errors.As(err, &corrupt) {
+		// 写入前把无法解读的文件移到一旁，留作排查，而不是直接覆盖
+		data, err = &board{}, b.moveAside(corrupt)
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	live := data.Claims[:0]
+	for _, c := range data.Claims {
+		if now.Before(c.Expires) {
+			live = append(live, c)
+		}
+	}
+	data.Claims = live
+	fn(data, now)
+	return b.write(data)
+}
+
+// lock takes the board's lock file, removing one left behind by an instance
+// that died while holding it.
+func (b *Board) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return nil, err
+	}
+	lockPath := b.path + ".lock"
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("等待实例锁超时: %s", lockPath)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// corruptError reports a board file that exists but can't be decoded.
+type corruptError struct {
+	err error
+}
+
+func (e *corruptError) Error() string { return "decode board: " + e.err.Error() }
+func (e *corruptError) Unwrap() error { return e.err }
+
+// read loads the board for reading only: a corrupt file reads as an empty
+// board and is left for the next update to move aside.
+func (b *Board) read() (*board, error) {
+	data, err := b.readFile()
+	var corrupt *corruptError
+	if errors.As(err, &corrupt) {
+		return &board{}, nil
+	}
+	return data, err
+}
+
+func (b *Board) readFile() (*board, error) {
+	data := &board{}
+	raw, err := os.ReadFile(b.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if raw, err = b.box.Open(raw); err != nil {
+		return nil, &corruptError{err: err}
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, &corruptError{err: err}
+	}
+	return data, nil
+}
+
+// moveAside renames the corrupt board file to path.bad, replacing an older
+// one, and logs why it couldn't be read. The caller holds the lock.
+func (b *Board) moveAside(corrupt *corruptError) error {
+	bad := b.path + ".bad"
+	if err := os.Rename(b.path, bad); err != nil {
+		return err
+	}
+	if b.log != nil {
+		b.log.Logf("实例协调文件无法读取，已移到 %s，从空记录开始: %v", bad, corrupt)
+	}
+	return nil
+}
+
+func (b *Board) write(data *board) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if raw, err = b.box.Seal(raw); err != nil {
+		return err
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.path)
+}
+
+func removeClaim(claims []Claim, pid int, account, target string) []Claim {
+	out := claims[:0]
+	for _, c := range claims {
+		if c.PID != pid || c.Account != account || c.Target != target {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func lastSeq(data *board) int {
+	if len(data.Events) == 0 {
+		return 0
+	}
+	return data.Events[len(data.Events)-1].Seq
+}
@@ -0,0 +1,157 @@
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openPair opens two boards on one file as if from two instances.
+func openPair(t *testing.T) (*Board, *Board) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "board.json")
+	a, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.pid = a.pid + 1
+	return a, b
+}
+
+func TestClaim(t *testing.T) {
+	tests := []struct {
+		name    string
+		account string
+		target  string
+		ttl     time.Duration
+		release bool
+		busy    bool
+	}{
+		{"same target", "main", SwitchTarget(1), time.Minute, false, true},
+		{"other target", "main", SwitchTarget(2), time.Minute, false, false},
+		{"other account", "work", SwitchTarget(1), time.Minute, false, false},
+		{"expired claim", "main", SwitchTarget(1), -time.Second, false, false},
+		{"released claim", "main", SwitchTarget(1), time.Minute, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := openPair(t)
+			if _, err := a.Claim("main", SwitchTarget(1), tt.ttl); err != nil {
+				t.Fatal(err)
+			}
+			if tt.release {
+				if err := a.Release("main", SwitchTarget(1), nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+			owner, err := b.Claim(tt.account, tt.target, time.Minute)
+			if tt.busy {
+				if !errors.Is(err, ErrBusy) || owner != a.pid {
+					t.Errorf("Claim = %d, %v; want %d, ErrBusy", owner, err, a.pid)
+				}
+				return
+			}
+			if err != nil || owner != 0 {
+				t.Errorf("Claim = %d, %v; want 0, nil", owner, err)
+			}
+		})
+	}
+}
+
+func TestPollSkipsOwnEvents(t *testing.T) {
+	a, b := openPair(t)
+	if err := a.Release("main", PreferenceTarget, &Event{Kind: KindPreference, Name: "payg_only"}); err != nil {
+		t.Fatal(err)
+	}
+	if events, err := a.Poll(); err != nil || len(events) != 0 {
+		t.Errorf("own poll = %v, %v; want no events", events, err)
+	}
+	events, err := b.Poll()
+	if err != nil || len(events) != 1 || events[0].Name != "payg_only" || events[0].PID != a.pid {
+		t.Fatalf("other poll = %+v, %v; want the preference event", events, err)
+	}
+	if events, err := b.Poll(); err != nil || len(events) != 0 {
+		t.Errorf("second poll = %v, %v; want no events", events, err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	tests := []struct {
+		name    string
+		age     time.Duration // age of a lock file left by another instance; 0 for none
+		wantErr bool
+	}{
+		{"free", 0, false},
+		{"stale lock", 2 * staleLock, false},
+		{"held lock", -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := openPair(t)
+			lockPath := a.path + ".lock"
+			if tt.age != 0 {
+				if err := os.WriteFile(lockPath, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+				if tt.age > 0 {
+					old := time.Now().Add(-tt.age)
+					if err := os.Chtimes(lockPath, old, old); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			_, err := a.Claim("main", SwitchTarget(1), time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Claim err = %v, want error %v", err, tt.wantErr)
+			}
+			if _, statErr := os.Stat(lockPath); !tt.wantErr && !errors.Is(statErr, os.ErrNotExist) {
+				t.Errorf("lock file left behind after Claim: %v", statErr)
+			}
+		})
+	}
+}
+
+type recordLog []string
+
+func (l *recordLog) Logf(format string, args ...any) {
+	*l = append(*l, fmt.Sprintf(format, args...))
+}
+
+func TestCorruptBoardMovedAside(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var log recordLog
+	b, err := OpenSealed(path, nil, &log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events, err := b.Poll(); err != nil || len(events) != 0 {
+		t.Fatalf("Poll = %v, %v; want an empty board", events, err)
+	}
+	if len(log) != 0 {
+		t.Errorf("reading logged %q, want nothing before the board is written", log)
+	}
+
+	if _, err := b.Claim("main", SwitchTarget(1), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if raw, err := os.ReadFile(path + ".bad"); err != nil || string(raw) != "{not json" {
+		t.Errorf("moved-aside file = %q, %v; want the corrupt content", raw, err)
+	}
+	if len(log) != 1 || !strings.Contains(log[0], path+".bad") {
+		t.Errorf("log = %q, want one note naming the moved file", log)
+	}
+	if _, err := b.readFile(); err != nil {
+		t.Errorf("board after the claim: %v", err)
+	}
+}
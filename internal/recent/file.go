@@ -0,0 +1,76 @@
+package recent
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"yescode-tui/internal/sealed"
+)
+
+// jsonFile keeps all usage in one JSON file, rewritten on every save and
+// sealed by box when set.
+type jsonFile struct {
+	path string
+	box  *sealed.Box
+	data Data
+}
+
+func (f *jsonFile) Load() (Data, error) {
+	data, err := ReadFile(f.path, f.box)
+	if err != nil {
+		return nil, err
+	}
+	f.data = data
+	return data, nil
+}
+
+func (f *jsonFile) Save(account string, providerID int, usages []Usage) error {
+	providers, ok := f.data[account]
+	if !ok {
+		providers = make(map[string][]Usage)
+		f.data[account] = providers
+	}
+	providers[strconv.Itoa(providerID)] = usages
+
+	raw, err := json.MarshalIndent(f.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if raw, err = f.box.Seal(raw); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// ReadFile returns the usage saved in the JSON file at path, opening it with
+// box if sealed; a missing or corrupt file has none.
+func ReadFile(path string, box *sealed.Box) (Data, error) {
+	data := make(Data)
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if raw, err = box.Open(raw); err != nil {
+		// 与损坏不同，密钥不对时不能覆盖文件
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		// 文件损坏时从空记录开始，下次写入会覆盖
+		return make(Data), nil
+	}
+	return data, nil
+}
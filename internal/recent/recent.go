@@ -0,0 +1,122 @@
+// Package recent remembers which provider alternatives the user switched to.
+package recent
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"yescode-tui/internal/sealed"
+)
+
+// maxPerProvider bounds the remembered alternatives of one provider.
+const maxPerProvider = 10
+
+// Usage records how often and when an alternative was selected.
+type Usage struct {
+	AlternativeID int       `json:"alternative_id"`
+	Count         int       `json:"count"`
+	LastUsed      time.Time `json:"last_used"`
+}
+
+// Data is the usage of every account, keyed by account and provider ID.
+type Data map[string]map[string][]Usage
+
+// Backend persists a Store's usage.
+type Backend interface {
+	// Load returns all saved usage.
+	Load() (Data, error)
+	// Save replaces the saved usage of one provider.
+	Save(account string, providerID int, usages []Usage) error
+}
+
+// Store keeps usage per account and provider.
+type Store struct {
+	backend Backend
+
+	mu   sync.Mutex
+	data Data
+}
+
+// Open reads the store from the JSON file at path; a missing file yields an
+// empty store.
+func Open(path string) (*Store, error) {
+	return OpenWith(&jsonFile{path: path})
+}
+
+// OpenSealed is Open for a JSON file sealed by box. A plaintext file is read
+// as is and sealed on the next save.
+func OpenSealed(path string, box *sealed.Box) (*Store, error) {
+	return OpenWith(&jsonFile{path: path, box: box})
+}
+
+// OpenWith reads the store from backend.
+func OpenWith(backend Backend) (*Store, error) {
+	data, err := backend.Load()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = make(Data)
+	}
+	return &Store{backend: backend, data: data}, nil
+}
+
+// Record notes that the provider switched from previousID (0 if unknown) to
+// alternativeID and saves the store. The previous alternative counts as used
+// until now without its selection count changing.
+func (s *Store) Record(account string, providerID, previousID, alternativeID int, now time.Time) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	providers, ok := s.data[account]
+	if !ok {
+		providers = make(map[string][]Usage)
+		s.data[account] = providers
+	}
+	key := strconv.Itoa(providerID)
+	usages := providers[key]
+	if previousID != 0 && previousID != alternativeID {
+		// 上一个方案使用到此刻，排在新方案之后
+		usages = touch(usages, previousID, 0, now.Add(-time.Nanosecond))
+	}
+	usages = touch(usages, alternativeID, 1, now)
+	sortByRecency(usages)
+	if len(usages) > maxPerProvider {
+		usages = usages[:maxPerProvider]
+	}
+	providers[key] = usages
+	return s.backend.Save(account, providerID, usages)
+}
+
+// Recent returns the provider's usage, most recently used first.
+func (s *Store) Recent(account string, providerID int) []Usage {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Usage(nil), s.data[account][strconv.Itoa(providerID)]...)
+}
+
+// touch marks id used at t, adding count to its selections.
+func touch(usages []Usage, id, count int, t time.Time) []Usage {
+	for i := range usages {
+		if usages[i].AlternativeID == id {
+			usages[i].Count += count
+			usages[i].LastUsed = t
+			return usages
+		}
+	}
+	return append(usages, Usage{AlternativeID: id, Count: count, LastUsed: t})
+}
+
+func sortByRecency(usages []Usage) {
+	sort.SliceStable(usages, func(i, j int) bool {
+		return usages[i].LastUsed.After(usages[j].LastUsed)
+	})
+}
@@ -0,0 +1,217 @@
+// Package reconcile compares externally recorded usage cost (e.g. a Claude Code
+// usage export) with the spend YesCode reported for the same days.
+package reconcile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"yescode-tui/internal/history"
+)
+
+// DayCost is the externally recorded cost of one day.
+type DayCost struct {
+	Date time.Time
+	Cost float64
+}
+
+var (
+	dateKeys = []string{"date", "day", "timestamp", "time", "created_at"}
+	costKeys = []string{"cost", "totalcost", "total_cost", "costusd", "cost_usd", "amount", "usd"}
+)
+
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+}
+
+// Parse reads a CSV or JSON usage export and sums cost per local day. Rows may be
+// daily totals or individual requests; both aggregate the same way.
+func Parse(r io.Reader, loc *time.Location) ([]DayCost, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("empty usage file")
+	}
+
+	var rows []map[string]string
+	if trimmed[0] == '[' || trimmed[0] == '{' {
+		rows, err = parseJSON(trimmed)
+	} else {
+		rows, err = parseCSV(trimmed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[time.Time]float64)
+	for i, row := range rows {
+		rawDate, ok := lookup(row, dateKeys)
+		if !ok {
+			return nil, fmt.Errorf("row %d: missing date column", i+1)
+		}
+		date, err := parseDate(rawDate, loc)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		rawCost, ok := lookup(row, costKeys)
+		if !ok {
+			return nil, fmt.Errorf("row %d: missing cost column", i+1)
+		}
+		cost, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(rawCost), "$"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid cost %q", i+1, rawCost)
+		}
+		totals[date] += cost
+	}
+
+	out := make([]DayCost, 0, len(totals))
+	for date, cost := range totals {
+		out = append(out, DayCost{Date: date, Cost: cost})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out, nil
+}
+
+func parseJSON(data []byte) ([]map[string]string, error) {
+	var raw []map[string]any
+	if data[0] == '{' {
+		// ccusage 风格：{"daily": [...]}
+		var wrapped map[string]json.RawMessage
+		if err := json.Unmarshal(data, &wrapped); err != nil {
+			return nil, fmt.Errorf("decode json: %w", err)
+		}
+		var list json.RawMessage
+		for _, key := range []string{"daily", "data", "usage", "entries"} {
+			if v, ok := wrapped[key]; ok {
+				list = v
+				break
+			}
+		}
+		if list == nil {
+			return nil, errors.New("json object has no daily/data/usage/entries array")
+		}
+		data = list
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	rows := make([]map[string]string, 0, len(raw))
+	for _, item := range raw {
+		row := make(map[string]string, len(item))
+		for k, v := range item {
+			row[normalizeKey(k)] = fmt.Sprint(v)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseCSV(data []byte) ([]map[string]string, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("decode csv: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("csv needs a header row and at least one data row")
+	}
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, col := range header {
+			if i < len(record) {
+				row[normalizeKey(col)] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func normalizeKey(k string) string {
+	k = strings.ToLower(strings.TrimSpace(k))
+	k = strings.ReplaceAll(k, " ", "_")
+	k = strings.ReplaceAll(k, "(", "")
+	k = strings.ReplaceAll(k, ")", "")
+	k = strings.ReplaceAll(k, "$", "usd")
+	return k
+}
+
+func lookup(row map[string]string, keys []string) (string, bool) {
+	for _, k := range keys {
+		if v, ok := row[k]; ok && strings.TrimSpace(v) != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func parseDate(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			t = t.In(loc)
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+}
+
+// Row compares one day of external cost with reported spend.
+type Row struct {
+	Date     time.Time `json:"date"`
+	External float64   `json:"external"`
+	Reported float64   `json:"reported"`
+	// Sampled is false when there is no local sample for the day.
+	Sampled bool `json:"sampled"`
+	// Estimated marks days without samples whose Reported spend is their
+	// share of the spend between the samples on either side. They are not
+	// checked against the tolerance, since the split is proportional to time.
+	Estimated bool `json:"estimated"`
+	// Mismatch marks days whose relative difference exceeds the tolerance.
+	Mismatch bool `json:"mismatch"`
+}
+
+// Diff returns Reported - External.
+func (r Row) Diff() float64 {
+	return r.Reported - r.External
+}
+
+// Compare pairs each external day with the reported spend of the same day.
+// External cost is multiplied by multiplier (the effective rate) before comparing.
+func Compare(external []DayCost, reported []history.DaySpend, multiplier, tolerance float64) []Row {
+	byDate := make(map[string]history.DaySpend, len(reported))
+	for _, day := range reported {
+		byDate[day.Date.Format(time.DateOnly)] = day
+	}
+
+	rows := make([]Row, 0, len(external))
+	for _, ext := range external {
+		row := Row{Date: ext.Date, External: ext.Cost * multiplier}
+		if day, ok := byDate[ext.Date.Format(time.DateOnly)]; ok && day.Known() {
+			row.Sampled = day.Sampled
+			row.Estimated = day.Estimated
+			row.Reported = day.Amount
+			base := math.Max(row.External, row.Reported)
+			row.Mismatch = !row.Estimated && base > 0 && math.Abs(row.Diff())/base > tolerance
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
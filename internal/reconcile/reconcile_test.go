@@ -0,0 +1,138 @@
+package reconcile
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"yescode-tui/internal/history"
+)
+
+func day(loc *time.Location, d int) time.Time {
+	return time.Date(2025, 3, d, 0, 0, 0, 0, loc)
+}
+
+func TestParse(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "csv daily totals",
+			input: "Date,Cost ($)\n2025-03-02,1.5\n2025-03-01,$2.25\n",
+			want:  "03-01=2.25 03-02=1.50",
+		},
+		{
+			name:  "csv requests summed per local day",
+			input: "timestamp,cost\n2025-03-01T15:00:00Z,1\n2025-03-01T17:00:00Z,2\n",
+			want:  "03-01=1.00 03-02=2.00",
+		},
+		{
+			name:  "json array",
+			input: `[{"date":"2025-03-01","totalCost":0.5},{"date":"2025/03/01","totalCost":"$0.25"}]`,
+			want:  "03-01=0.75",
+		},
+		{
+			name:  "ccusage wrapped object",
+			input: `{"daily":[{"date":"2025-03-01","totalCost":3}],"totals":{"totalCost":3}}`,
+			want:  "03-01=3.00",
+		},
+		{
+			name:  "data wrapped object",
+			input: `{"data":[{"day":"2025-03-03","cost_usd":1}]}`,
+			want:  "03-03=1.00",
+		},
+		{name: "empty", input: "  \n", wantErr: true},
+		{name: "object without list", input: `{"totals":{}}`, wantErr: true},
+		{name: "csv header only", input: "date,cost\n", wantErr: true},
+		{name: "missing cost column", input: "date,tokens\n2025-03-01,10\n", wantErr: true},
+		{name: "invalid cost", input: "date,cost\n2025-03-01,abc\n", wantErr: true},
+		{name: "unrecognized date", input: "date,cost\nyesterday,1\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, err := Parse(strings.NewReader(tt.input), loc)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse = %v, want an error", days)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, d := range days {
+				if d.Date.Location() != loc || d.Date.Hour() != 0 {
+					t.Errorf("date %v is not a local midnight", d.Date)
+				}
+				got = append(got, fmt.Sprintf("%s=%.2f", d.Date.Format("01-02"), d.Cost))
+			}
+			if s := strings.Join(got, " "); s != tt.want {
+				t.Errorf("Parse = %s, want %s", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	loc := time.UTC
+	external := []DayCost{
+		{Date: day(loc, 1), Cost: 10},
+		{Date: day(loc, 2), Cost: 10},
+		{Date: day(loc, 3), Cost: 10},
+		{Date: day(loc, 4), Cost: 10},
+		{Date: day(loc, 5), Cost: 10},
+		{Date: day(loc, 6), Cost: 0},
+	}
+	reported := []history.DaySpend{
+		// 差额 2/10 恰好等于容差，不算异常
+		{Date: day(loc, 1), Amount: 8, Sampled: true},
+		{Date: day(loc, 2), Amount: 7.9, Sampled: true},
+		{Date: day(loc, 3), Amount: 2, Estimated: true},
+		{Date: day(loc, 4)},
+		{Date: day(loc, 6), Sampled: true},
+	}
+	tests := []struct {
+		date      int
+		reported  float64
+		sampled   bool
+		estimated bool
+		mismatch  bool
+	}{
+		{1, 8, true, false, false},
+		{2, 7.9, true, false, true},
+		{3, 2, false, true, false},
+		{4, 0, false, false, false},
+		{5, 0, false, false, false},
+		{6, 0, true, false, false},
+	}
+	rows := Compare(external, reported, 1, 0.2)
+	if len(rows) != len(tests) {
+		t.Fatalf("Compare returned %d rows, want %d", len(rows), len(tests))
+	}
+	for i, tt := range tests {
+		row := rows[i]
+		if row.Date.Day() != tt.date || row.Reported != tt.reported || row.Sampled != tt.sampled ||
+			row.Estimated != tt.estimated || row.Mismatch != tt.mismatch {
+			t.Errorf("day %d: got %+v, want reported=%v sampled=%v estimated=%v mismatch=%v",
+				tt.date, row, tt.reported, tt.sampled, tt.estimated, tt.mismatch)
+		}
+	}
+}
+
+func TestCompareMultiplier(t *testing.T) {
+	external := []DayCost{{Date: day(time.UTC, 1), Cost: 10}}
+	reported := []history.DaySpend{{Date: day(time.UTC, 1), Amount: 5, Sampled: true}}
+	row := Compare(external, reported, 0.5, 0)[0]
+	if row.External != 5 || row.Mismatch {
+		t.Errorf("row = %+v, want external 5 and no mismatch", row)
+	}
+	if row := Compare(external, reported, 1, 0.49)[0]; !row.Mismatch {
+		t.Errorf("row = %+v, want a mismatch just past the tolerance", row)
+	}
+}
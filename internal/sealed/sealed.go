@@ -0,0 +1,141 @@
+// Package sealed encrypts local state files with a key kept in the OS
+// keyring (macOS Keychain, Windows Credential Manager or the Secret Service
+// on Linux), so samples and usage that name the account can't be read from
+// the files alone.
+package sealed
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	keyringService = "yescode-tui"
+	keyringUser    = "state-key"
+)
+
+// magic prefixes every sealed blob; data without it is read as plaintext, so
+// files written before encryption was enabled stay readable.
+var magic = []byte("ycsealed1:")
+
+// Box seals and opens data with one key. A nil Box leaves data unchanged.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New builds a Box from secret; the AES-256 key is derived from it.
+func New(secret []byte) (*Box, error) {
+	key := sha256.Sum256(append([]byte("yescode-tui local state v1\x00"), secret...))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// FromKeyring builds a Box from the secret stored in the OS keyring,
+// generating and storing a random one on first use.
+func FromKeyring() (*Box, error) {
+	encoded, err := keyring.Get(keyringService, keyringUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		encoded = base64.StdEncoding.EncodeToString(secret)
+		if err := keyring.Set(keyringService, keyringUser, encoded); err != nil {
+			return nil, fmt.Errorf("store key in keyring: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("read key from keyring: %w", err)
+	}
+	secret, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode keyring key: %w", err)
+	}
+	return New(secret)
+}
+
+// Seal encrypts plain into a self-describing blob. It fails only when no
+// random nonce can be drawn, in which case nothing may be written.
+func (b *Box) Seal(plain []byte) ([]byte, error) {
+	if b == nil {
+		return plain, nil
+	}
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	out := append([]byte(nil), magic...)
+	out = append(out, nonce...)
+	return b.aead.Seal(out, nonce, plain, magic), nil
+}
+
+// Open decrypts a blob made by Seal. Data without the sealed prefix is
+// returned as is.
+func (b *Box) Open(data []byte) ([]byte, error) {
+	if !IsSealed(data) {
+		return data, nil
+	}
+	if b == nil {
+		return nil, errors.New("data is encrypted; enable encryption to read it")
+	}
+	rest := data[len(magic):]
+	size := b.aead.NonceSize()
+	if len(rest) < size {
+		return nil, errors.New("sealed data is truncated")
+	}
+	plain, err := b.aead.Open(nil, rest[:size], rest[size:], magic)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return plain, nil
+}
+
+// SealText is Seal with the blob base64-encoded, for line-based files.
+func (b *Box) SealText(plain []byte) ([]byte, error) {
+	if b == nil {
+		return plain, nil
+	}
+	sealed, err := b.Seal(plain)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte(nil), magic...)
+	return base64.RawStdEncoding.AppendEncode(out, sealed[len(magic):]), nil
+}
+
+// OpenText decrypts a line made by SealText; other lines are returned as is.
+func (b *Box) OpenText(line []byte) ([]byte, error) {
+	if !IsSealed(line) {
+		return line, nil
+	}
+	raw, err := base64.RawStdEncoding.DecodeString(string(line[len(magic):]))
+	if err != nil {
+		return nil, fmt.Errorf("decode sealed line: %w", err)
+	}
+	return b.Open(append(append([]byte(nil), magic...), raw...))
+}
+
+// HasSealed reports whether data holds anything written by Seal or SealText,
+// e.g. a history file whose later lines were sealed.
+func HasSealed(data []byte) bool {
+	return bytes.Contains(data, magic)
+}
+
+// IsSealed reports whether data was written by Seal or SealText.
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
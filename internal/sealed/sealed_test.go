@@ -0,0 +1,137 @@
+package sealed
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newBox(t *testing.T, secret string) *Box {
+	t.Helper()
+	box, err := New([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return box
+}
+
+// mustSeal seals plain with box, failing the test on error.
+func mustSeal(t *testing.T, box *Box, plain []byte) []byte {
+	t.Helper()
+	sealed, err := box.Seal(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sealed
+}
+
+func TestRoundTrip(t *testing.T) {
+	box := newBox(t, "secret")
+	tests := []struct {
+		name  string
+		plain []byte
+	}{
+		{"empty", []byte{}},
+		{"json line", []byte(`{"time":"2026-03-10T12:00:00Z","account":"main","month_spend":12.5}`)},
+		{"binary", []byte{0, 1, 2, 0xff, '\n', 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed := mustSeal(t, box, tt.plain)
+			if !IsSealed(sealed) || (len(tt.plain) > 0 && bytes.Contains(sealed, tt.plain)) {
+				t.Fatalf("Seal = %q, want a sealed blob hiding the input", sealed)
+			}
+			if got, err := box.Open(sealed); err != nil || !bytes.Equal(got, tt.plain) {
+				t.Errorf("Open = %q, %v; want %q", got, err, tt.plain)
+			}
+
+			line, err := box.SealText(tt.plain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !IsSealed(line) || bytes.ContainsAny(line, "\n\r") {
+				t.Fatalf("SealText = %q, want a single sealed line", line)
+			}
+			if got, err := box.OpenText(line); err != nil || !bytes.Equal(got, tt.plain) {
+				t.Errorf("OpenText = %q, %v; want %q", got, err, tt.plain)
+			}
+		})
+	}
+}
+
+func TestPlaintextPassesThrough(t *testing.T) {
+	plain := []byte(`{"month_spend":1}`)
+	var none *Box
+	if got, err := none.Seal(plain); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("nil Seal = %q, %v; want the input", got, err)
+	}
+	for _, box := range []*Box{none, newBox(t, "secret")} {
+		if got, err := box.Open(plain); err != nil || !bytes.Equal(got, plain) {
+			t.Errorf("Open(plaintext) = %q, %v; want the input", got, err)
+		}
+		if got, err := box.OpenText(plain); err != nil || !bytes.Equal(got, plain) {
+			t.Errorf("OpenText(plaintext) = %q, %v; want the input", got, err)
+		}
+	}
+	if _, err := none.Open(mustSeal(t, newBox(t, "secret"), plain)); err == nil {
+		t.Error("nil Open of sealed data: want an error")
+	}
+}
+
+func TestHasSealed(t *testing.T) {
+	line, err := newBox(t, "secret").SealText([]byte(`{"month_spend":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mixed := append([]byte("{\"month_spend\":1}\n"), line...)
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"plaintext", []byte("{\"month_spend\":1}\n"), false},
+		{"sealed line", line, true},
+		{"sealed after plaintext", mixed, true},
+	}
+	for _, tt := range tests {
+		if got := HasSealed(tt.data); got != tt.want {
+			t.Errorf("%s: HasSealed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if IsSealed(mixed) {
+		t.Error("IsSealed of a file starting in plaintext: want false")
+	}
+}
+
+func TestOpenDetectsTampering(t *testing.T) {
+	box := newBox(t, "secret")
+	sealed := mustSeal(t, box, []byte("balance 42"))
+	flip := func(i int) []byte {
+		out := bytes.Clone(sealed)
+		out[i] ^= 0x01
+		return out
+	}
+	tests := []struct {
+		name string
+		data []byte
+		box  *Box
+	}{
+		{"flipped ciphertext", flip(len(sealed) - 1), box},
+		{"flipped nonce", flip(len(magic)), box},
+		{"truncated", sealed[:len(magic)+4], box},
+		{"wrong key", sealed, newBox(t, "other")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.box.Open(tt.data); err == nil {
+				t.Errorf("Open = %q, want an error", got)
+			}
+		})
+	}
+	line, err := box.SealText([]byte("balance 42"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := box.OpenText(append(bytes.Clone(line[:len(line)-1]), '!')); err == nil {
+		t.Error("OpenText of a damaged line: want an error")
+	}
+}
@@ -0,0 +1,128 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"yescode-tui/internal/api"
+)
+
+// Plan lists the changes needed to bring the live account to a desired state.
+// Only selections and preferences are applied; account fields are read-only.
+type Plan struct {
+	Preference *PreferenceChange `json:"preference,omitempty"` // nil when unchanged
+	Switches   []Switch          `json:"switches"`
+}
+
+// PreferenceChange updates the balance preference.
+type PreferenceChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Switch changes one provider's selected alternative.
+type Switch struct {
+	ProviderID int    `json:"provider_id"`
+	Provider   string `json:"provider"`
+	FromID     int    `json:"from_id"`
+	From       string `json:"from"`
+	ToID       int    `json:"to_id"`
+	To         string `json:"to"`
+}
+
+// Empty reports whether the live state already matches.
+func (p *Plan) Empty() bool {
+	return p.Preference == nil && len(p.Switches) == 0
+}
+
+// NewPlan diffs desired against live. Desired providers are matched by ID,
+// or by name when the ID is 0; alternatives likewise by ID or name.
+// Providers and fields left out of desired are not touched.
+func NewPlan(ctx context.Context, client api.Service, desired, live *Snapshot) (*Plan, error) {
+	plan := &Plan{}
+	if want := desired.Preferences.BalancePreference; want != "" && want != live.Preferences.BalancePreference {
+		plan.Preference = &PreferenceChange{From: live.Preferences.BalancePreference, To: want}
+	}
+
+	for _, want := range desired.Providers {
+		current, ok := findProvider(live.Providers, want)
+		if !ok {
+			return nil, fmt.Errorf("provider %s not found", describe(want.ID, want.Name))
+		}
+		toID, to := want.AlternativeID, want.Alternative
+		if toID == 0 {
+			if to == "" {
+				continue
+			}
+			alternatives, err := client.GetProviderAlternatives(ctx, current.ID)
+			if err != nil {
+				return nil, fmt.Errorf("fetch alternatives for provider %d: %w", current.ID, err)
+			}
+			for _, alt := range alternatives {
+				if strings.EqualFold(alt.Alternative.DisplayName, to) {
+					toID, to = alt.Alternative.ID, alt.Alternative.DisplayName
+				}
+			}
+			if toID == 0 {
+				return nil, fmt.Errorf("alternative %q not found for provider %s", want.Alternative, current.Name)
+			}
+		}
+		if toID == current.AlternativeID {
+			continue
+		}
+		plan.Switches = append(plan.Switches, Switch{
+			ProviderID: current.ID,
+			Provider:   current.Name,
+			FromID:     current.AlternativeID,
+			From:       current.Alternative,
+			ToID:       toID,
+			To:         to,
+		})
+	}
+	return plan, nil
+}
+
+// Apply performs the plan, calling report after each step. All steps are
+// attempted; the returned error joins every failure.
+func (p *Plan) Apply(ctx context.Context, client api.Service, report func(step string, err error)) error {
+	var errs []error
+	if p.Preference != nil {
+		_, err := client.UpdateBalancePreference(ctx, p.Preference.To)
+		report(fmt.Sprintf("balance_preference: %s → %s", p.Preference.From, p.Preference.To), err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("balance_preference: %w", err))
+		}
+	}
+	for _, s := range p.Switches {
+		_, err := client.SwitchProvider(ctx, s.ProviderID, s.ToID)
+		report(s.String(), err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %d: %w", s.ProviderID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String describes the switch as "Provider: From → To".
+func (s Switch) String() string {
+	return fmt.Sprintf("%s: %s → %s", s.Provider, describe(s.FromID, s.From), describe(s.ToID, s.To))
+}
+
+func findProvider(providers []Provider, want Provider) (Provider, bool) {
+	for _, p := range providers {
+		if (want.ID != 0 && p.ID == want.ID) || (want.ID == 0 && strings.EqualFold(p.Name, want.Name)) {
+			return p, true
+		}
+	}
+	return Provider{}, false
+}
+
+// describe renders a name with its ID, e.g. "Claude CF (11)".
+func describe(id int, name string) string {
+	if name == "" {
+		return fmt.Sprintf("#%d", id)
+	}
+	return fmt.Sprintf("%s (%d)", name, id)
+}
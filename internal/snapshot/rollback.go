@@ -0,0 +1,74 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"yescode-tui/internal/sealed"
+)
+
+// ErrNoRollback is returned when no rollback point was saved for an account.
+var ErrNoRollback = errors.New("no rollback point saved")
+
+// RollbackStore keeps the latest rollback point of each account, saved
+// before batch operations so they can be undone.
+type RollbackStore struct {
+	dir string
+	box *sealed.Box
+}
+
+// NewRollbackStore stores rollback points under dir, one file per account.
+func NewRollbackStore(dir string) *RollbackStore {
+	return &RollbackStore{dir: dir}
+}
+
+// NewSealedRollbackStore is NewRollbackStore with the files encrypted by
+// box. Points saved before encryption was enabled stay readable.
+func NewSealedRollbackStore(dir string, box *sealed.Box) *RollbackStore {
+	return &RollbackStore{dir: dir, box: box}
+}
+
+// Path returns the rollback file for account.
+func (r *RollbackStore) Path(account string) string {
+	return filepath.Join(r.dir, url.PathEscape(account)+".json")
+}
+
+// Save replaces the account's rollback point with s.
+func (r *RollbackStore) Save(account string, s *Snapshot) error {
+	var buf bytes.Buffer
+	if err := Encode(&buf, s, "json"); err != nil {
+		return err
+	}
+	data, err := r.box.Seal(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return err
+	}
+	path := r.Path(account)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load returns the account's rollback point, or ErrNoRollback.
+func (r *RollbackStore) Load(account string) (*Snapshot, error) {
+	raw, err := os.ReadFile(r.Path(account))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoRollback
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.box.Open(raw)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(bytes.NewReader(data))
+}
@@ -0,0 +1,219 @@
+// Package snapshot captures the account state as canonical JSON or YAML and
+// compares snapshots field by field.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"yescode-tui/internal/api"
+)
+
+// Version is the snapshot format version.
+const Version = 1
+
+// Snapshot is the full account state at one point in time.
+type Snapshot struct {
+	Version     int         `json:"version" yaml:"version"`
+	Account     Account     `json:"account" yaml:"account"`
+	Preferences Preferences `json:"preferences" yaml:"preferences"`
+	Providers   []Provider  `json:"providers" yaml:"providers"`
+}
+
+// Account holds the profile fields.
+type Account struct {
+	Username            string  `json:"username" yaml:"username"`
+	Email               string  `json:"email" yaml:"email"`
+	Plan                string  `json:"plan" yaml:"plan"`
+	SubscriptionExpiry  string  `json:"subscription_expiry" yaml:"subscription_expiry"`
+	Balance             float64 `json:"balance" yaml:"balance"`
+	SubscriptionBalance float64 `json:"subscription_balance" yaml:"subscription_balance"`
+	PayAsYouGoBalance   float64 `json:"pay_as_you_go_balance" yaml:"pay_as_you_go_balance"`
+	WeekSpend           float64 `json:"week_spend" yaml:"week_spend"`
+	MonthSpend          float64 `json:"month_spend" yaml:"month_spend"`
+}
+
+// Key identifies the account, matching history.AccountOf.
+func (a Account) Key() string {
+	if a.Username != "" {
+		return a.Username
+	}
+	return a.Email
+}
+
+// Preferences holds the user-controlled settings.
+type Preferences struct {
+	BalancePreference string `json:"balance_preference" yaml:"balance_preference"`
+}
+
+// Provider records the alternative selected for one provider.
+type Provider struct {
+	ID            int    `json:"id" yaml:"id"`
+	Name          string `json:"name" yaml:"name"`
+	AlternativeID int    `json:"alternative_id" yaml:"alternative_id"`
+	Alternative   string `json:"alternative" yaml:"alternative"`
+}
+
+// Capture fetches the live account state.
+func Capture(ctx context.Context, client api.Service) (*Snapshot, error) {
+	profile, err := client.GetProfile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch profile: %w", err)
+	}
+	resp, err := client.GetAvailableProviders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch providers: %w", err)
+	}
+
+	providers := make([]Provider, 0, len(resp.Providers))
+	for _, bucket := range resp.Providers {
+		selection, err := client.GetProviderSelection(ctx, bucket.Provider.ID)
+		if err != nil {
+			return nil, fmt.Errorf("fetch selection for provider %d: %w", bucket.Provider.ID, err)
+		}
+		providers = append(providers, Provider{
+			ID:            bucket.Provider.ID,
+			Name:          bucket.Provider.DisplayName,
+			AlternativeID: selection.SelectedAlternativeID,
+			Alternative:   selection.SelectedAlternative.DisplayName,
+		})
+	}
+	return New(profile, providers), nil
+}
+
+// New builds a snapshot from a profile and provider selections. Providers are
+// sorted by ID so the output is stable across runs.
+func New(profile *api.Profile, providers []Provider) *Snapshot {
+	s := &Snapshot{
+		Version: Version,
+		Account: Account{
+			Username:            profile.Username,
+			Email:               profile.Email,
+			Plan:                profile.SubscriptionPlan.Name,
+			SubscriptionExpiry:  profile.SubscriptionExpiry,
+			Balance:             profile.Balance,
+			SubscriptionBalance: profile.SubscriptionBalance,
+			PayAsYouGoBalance:   profile.PayAsYouGoBalance,
+			WeekSpend:           profile.CurrentWeekSpend,
+			MonthSpend:          profile.CurrentMonthSpend,
+		},
+		Preferences: Preferences{BalancePreference: profile.BalancePreference},
+		Providers:   providers,
+	}
+	sort.Slice(s.Providers, func(i, j int) bool { return s.Providers[i].ID < s.Providers[j].ID })
+	return s
+}
+
+// Encode writes s in the given format ("json" or "yaml") with a trailing newline.
+func Encode(w io.Writer, s *Snapshot, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(s); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// Decode reads a snapshot written by Encode, or a hand-written desired-state
+// file, in either format.
+func Decode(r io.Reader) (*Snapshot, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		err = json.Unmarshal(data, &s)
+	} else {
+		err = yaml.Unmarshal(data, &s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 手写的期望状态文件可以省略 version
+	if s.Version != 0 && s.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	return &s, nil
+}
+
+// Change is one field that differs between two snapshots. Old is empty for
+// added fields and New is empty for removed ones.
+type Change struct {
+	Path string `json:"path"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// Diff lists the fields that differ from old to new, in snapshot order.
+func Diff(old, new *Snapshot) []Change {
+	before, after := fields(old), fields(new)
+	seen := make(map[string]bool)
+	var changes []Change
+	for _, f := range before {
+		seen[f.path] = true
+		if value, ok := lookup(after, f.path); !ok || value != f.value {
+			changes = append(changes, Change{Path: f.path, Old: f.value, New: value})
+		}
+	}
+	for _, f := range after {
+		if !seen[f.path] {
+			changes = append(changes, Change{Path: f.path, New: f.value})
+		}
+	}
+	return changes
+}
+
+type field struct {
+	path  string
+	value string
+}
+
+// fields flattens s into dotted paths; providers are keyed by ID.
+func fields(s *Snapshot) []field {
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	out := []field{
+		{"account.username", s.Account.Username},
+		{"account.email", s.Account.Email},
+		{"account.plan", s.Account.Plan},
+		{"account.subscription_expiry", s.Account.SubscriptionExpiry},
+		{"account.balance", money(s.Account.Balance)},
+		{"account.subscription_balance", money(s.Account.SubscriptionBalance)},
+		{"account.pay_as_you_go_balance", money(s.Account.PayAsYouGoBalance)},
+		{"account.week_spend", money(s.Account.WeekSpend)},
+		{"account.month_spend", money(s.Account.MonthSpend)},
+		{"preferences.balance_preference", s.Preferences.BalancePreference},
+	}
+	for _, p := range s.Providers {
+		out = append(out, field{
+			path:  fmt.Sprintf("providers.%d.alternative", p.ID),
+			value: describe(p.AlternativeID, p.Alternative),
+		})
+	}
+	return out
+}
+
+func lookup(fs []field, path string) (string, bool) {
+	for _, f := range fs {
+		if f.path == path {
+			return f.value, true
+		}
+	}
+	return "", false
+}
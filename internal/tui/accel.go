@@ -0,0 +1,37 @@
+package tui
+
+import "time"
+
+const (
+	// navRepeatWindow is the longest gap between presses still treated as a held key.
+	navRepeatWindow = 150 * time.Millisecond
+	// navAccelAfter is how many repeats move one row before the step grows.
+	navAccelAfter = 8
+)
+
+// navAccel tracks a held navigation key to speed up long moves.
+type navAccel struct {
+	key    string
+	last   time.Time
+	streak int
+}
+
+// step returns how many rows a press of key moves at now: one row normally,
+// then 3 and 5 rows while the key keeps repeating.
+func (a *navAccel) step(key string, now time.Time) int {
+	if key == a.key && now.Sub(a.last) <= navRepeatWindow {
+		a.streak++
+	} else {
+		a.streak = 0
+	}
+	a.key, a.last = key, now
+
+	switch {
+	case a.streak < navAccelAfter:
+		return 1
+	case a.streak < navAccelAfter*3:
+		return 3
+	default:
+		return 5
+	}
+}
@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/alert"
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+)
+
+// accountData is the state cached for one account.
+type accountData struct {
+	client api.Service
+
+	profile                 *api.Profile
+	providerFlags           *api.ProvidersResponse
+	allProviders            []api.ProviderBucket
+	providers               []api.ProviderBucket
+	providerIdx             int
+	altIdx                  int
+	balancePreferenceIdx    int
+	providerData            map[int]*providerState
+	preferenceSwitching     bool
+	providersLoaded         bool
+	loadingProviders        bool
+	providersNext           *api.Page // 下一页提供商，nil 表示已全部加载
+	loadingMoreProviders    bool
+	loadingProfile          bool
+	profileErr              error
+	profileUpdated          time.Time
+	providersErr            error
+	lastAPIError            *api.APIError // 最近一次 API 错误，E 查看详情
+	manualRefreshingProfile bool
+	view                    listView
+	undo                    undoStack
+	alerts                  *alert.Engine
+	consistency             []api.Mismatch
+	checkingConsistency     bool
+
+	spendDays    []history.DaySpend
+	heatDays     []history.DaySpend
+	statsIdx     int
+	loadingStats bool
+
+	trendSamples []history.Sample
+	trendSeeded  bool
+
+	anomaly        history.Anomaly
+	anomalyChecked time.Time
+
+	stream streamState
+}
+
+func newAccountData(client api.Service) *accountData {
+	return &accountData{
+		client:       client,
+		providerData: make(map[int]*providerState),
+		view:         listView{splitPercent: defaultSplitPercent},
+	}
+}
+
+// Account is an additional account available in the account switcher.
+type Account struct {
+	Name   string
+	Client api.Service
+}
+
+// account is one switchable account; data is created on first use.
+type account struct {
+	name   string
+	client api.Service
+	data   *accountData
+}
+
+// switcherState drives the ctrl+a account switcher dialog.
+type switcherState struct {
+	cursor int
+}
+
+// accountMsg tags a response with the account whose request produced it, so
+// it updates that account's cache even after switching away.
+type accountMsg struct {
+	account int
+	msg     tea.Msg
+}
+
+// WithAccounts names the initial account and adds others to the switcher.
+func WithAccounts(current string, others []Account) Option {
+	return func(m *Model) {
+		m.accounts[0].name = current
+		for _, a := range others {
+			m.accounts = append(m.accounts, account{name: a.Name, client: a.Client})
+		}
+	}
+}
+
+// accountName labels an account; the unnamed one comes from flags or env.
+func (m *Model) accountName(i int) string {
+	if m.accounts[i].name == "" {
+		return i18n.T("默认")
+	}
+	return m.accounts[i].name
+}
+
+// scope tags the messages produced by cmd with account. With a single account
+// there is nothing to scope and cmd is returned as is.
+func (m *Model) scope(account int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil || len(m.accounts) < 2 {
+		return cmd
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			scoped := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				scoped[i] = m.scope(account, c)
+			}
+			return scoped
+		}
+		if !accountScoped(msg) {
+			return msg
+		}
+		return accountMsg{account: account, msg: msg}
+	}
+}
+
+// accountScoped reports whether msg carries data belonging to one account.
+// Ticks and other UI messages always apply to the active account.
+func accountScoped(msg tea.Msg) bool {
+	switch msg.(type) {
+	case profileLoadedMsg, providersLoadedMsg, providersPageLoadedMsg, alternativesLoadedMsg, selectionLoadedMsg,
+		switchCompletedMsg, preferenceUpdatedMsg, preferenceFailedMsg, providerLoadFailedMsg,
+		errMsg, statsLoadedMsg, trendLoadedMsg, resetProvidersLoadedMsg, resetResultMsg, planLoadedMsg, planStepMsg, consistencyCheckedMsg,
+		anomalyCheckedMsg, streamOpenedMsg, streamFailedMsg, streamEventMsg, streamClosedMsg, streamRetryMsg:
+		return true
+	}
+	return false
+}
+
+// updateAccount applies a tagged response to its own account's cache.
+func (m *Model) updateAccount(msg accountMsg) tea.Cmd {
+	if msg.account == m.accountIdx {
+		return m.scope(msg.account, m.update(msg.msg))
+	}
+	// 后台账户的响应只写入该账户的缓存，不改动当前界面的状态栏
+	active := m.accountData
+	status, err, toasts := m.status, m.err, m.toasts
+	m.accountData = m.accounts[msg.account].data
+	cmd := m.update(msg.msg)
+	m.accountData = active
+	m.status, m.err, m.toasts = status, err, toasts
+	return m.scope(msg.account, cmd)
+}
+
+// openSwitcher shows the account switcher when more than one account exists.
+func (m *Model) openSwitcher() tea.Cmd {
+	if len(m.accounts) < 2 {
+		m.status = i18n.T("未配置其他账户，请在配置文件中添加 [accounts.NAME]")
+		return clearStatusAfter(statusClearDelay)
+	}
+	m.switcher = &switcherState{cursor: m.accountIdx}
+	return nil
+}
+
+// handleSwitcherKey handles keys while the account switcher is open.
+func (m *Model) handleSwitcherKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+a":
+		m.switcher = nil
+	case "up", "k":
+		m.switcher.cursor = clampIndex(m.switcher.cursor-1, len(m.accounts))
+	case "down", "j":
+		m.switcher.cursor = clampIndex(m.switcher.cursor+1, len(m.accounts))
+	case "enter":
+		idx := m.switcher.cursor
+		m.switcher = nil
+		return m.switchAccount(idx)
+	}
+	return nil
+}
+
+// switchAccount activates account i, loading its data on first use and
+// reusing the cached data afterwards.
+func (m *Model) switchAccount(i int) tea.Cmd {
+	if i == m.accountIdx {
+		return nil
+	}
+	acct := &m.accounts[i]
+	if acct.data == nil {
+		acct.data = newAccountData(acct.client)
+		acct.data.alerts = m.accounts[0].data.alerts.Clone()
+	}
+	m.accountIdx = i
+	m.accountData = acct.data
+	m.resetSelectionUI()
+	cmds := []tea.Cmd{m.showToast(toastSuccess, i18n.Tf("已切换到账户 %s", m.accountName(i)))}
+	if m.profile == nil && !m.loadingProfile {
+		m.loadingProfile = true
+		cmds = append(cmds, loadProfileCmd(m.ctx, m.client), m.subscribe())
+	}
+	cmds = append(cmds, m.handleTabChanged())
+	return tea.Batch(cmds...)
+}
+
+// resetSelectionUI clears UI state that points into the previous account's data.
+func (m *Model) resetSelectionUI() {
+	m.pendingGoto = 0
+	m.focus = focusProviders
+	m.profileViewport.GotoTop()
+	m.profileCursor.idx = 0
+}
+
+func (m *Model) renderSwitcherDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor).Italic(true)
+
+	lines := []string{titleStyle.Render(i18n.T("切换账户")), ""}
+	for i, acct := range m.accounts {
+		line := cursorPrefix(i == m.switcher.cursor) + m.accountName(i)
+		if acct.data != nil && acct.data.profile != nil {
+			line += helpStyle.Render(" · " + history.AccountOf(acct.data.profile))
+		}
+		if i == m.accountIdx {
+			line += " " + glyphs.Check
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", hintStyle.Render(i18n.T("Enter 切换 · Esc 取消")))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(48)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
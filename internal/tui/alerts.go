@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/alert"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/notify"
+)
+
+const notifyTimeout = 15 * time.Second
+
+type notifyFailedMsg struct {
+	err error
+}
+
+// WithAlerts evaluates alert rules on every profile load and sends fired
+// alerts through the dispatcher's channels.
+func WithAlerts(engine *alert.Engine, dispatcher *notify.Dispatcher) Option {
+	return func(m *Model) {
+		m.alerts = engine
+		m.notifier = dispatcher
+	}
+}
+
+// evaluateAlerts checks the current profile against alert rules.
+func (m *Model) evaluateAlerts() []tea.Cmd {
+	if m.alerts == nil || m.notifier.Empty() {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, ev := range m.alerts.Evaluate(alert.Snapshot{Profile: m.profile, SpendRatio: m.anomaly.Ratio}) {
+		cmds = append(cmds, sendNotificationCmd(m.ctx, m.notifier, ev))
+	}
+	return cmds
+}
+
+// handleNotifyFailed surfaces notification delivery failures as a warning
+// only: the account itself is fine, so the error line stays untouched.
+func (m *Model) handleNotifyFailed(msg notifyFailedMsg) []tea.Cmd {
+	if canceled(msg.err) {
+		return nil
+	}
+	return []tea.Cmd{m.showToast(toastWarning, i18n.Tf("通知发送失败: %v", msg.err))}
+}
+
+func sendNotificationCmd(ctx context.Context, dispatcher *notify.Dispatcher, ev notify.Event) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+		defer cancel()
+		if err := dispatcher.Dispatch(ctx, ev); err != nil {
+			return notifyFailedMsg{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+)
+
+const (
+	// DefaultAnomalyRatio flags days spending three times the trailing
+	// average.
+	DefaultAnomalyRatio = 3.0
+	// anomalyMinSpend keeps small absolute amounts from being flagged on
+	// accounts that barely spend.
+	anomalyMinSpend = 1.0
+	// anomalyCheckInterval matches the sampling interval: the local history
+	// doesn't change more often than that.
+	anomalyCheckInterval = 5 * time.Minute
+)
+
+type anomalyCheckedMsg struct {
+	anomaly history.Anomaly
+	err     error
+}
+
+// WithAnomalyRatio sets how many times the trailing daily average today's
+// spend must reach to be flagged; 0 turns the banner off. The spend_ratio
+// alert metric is computed either way.
+func WithAnomalyRatio(ratio float64) Option {
+	return func(m *Model) {
+		m.anomalyRatio = ratio
+	}
+}
+
+// checkAnomaly compares today's spend with the trailing average from the
+// local history, at most once per sampling interval.
+func (m *Model) checkAnomaly() tea.Cmd {
+	if m.history == nil || m.profile == nil || time.Since(m.anomalyChecked) < anomalyCheckInterval {
+		return nil
+	}
+	m.anomalyChecked = time.Now()
+	return checkAnomalyCmd(m.history, history.AccountOf(m.profile))
+}
+
+// handleAnomalyChecked stores the result and re-evaluates the alert rules,
+// which may refer to spend_ratio.
+func (m *Model) handleAnomalyChecked(msg anomalyCheckedMsg) []tea.Cmd {
+	if msg.err != nil {
+		// 本地记录读取失败时不打扰用户，下次采样后再试
+		return nil
+	}
+	m.anomaly = msg.anomaly
+	return m.evaluateAlerts()
+}
+
+// spendAnomalous reports whether today's spend is high enough to flag.
+func (m *Model) spendAnomalous() bool {
+	return m.anomalyRatio > 0 && m.anomaly.Today >= anomalyMinSpend && m.anomaly.Ratio >= m.anomalyRatio
+}
+
+// renderAnomalyBanner warns about unusually high spend today, e.g. a
+// runaway agent or a leaked key.
+func (m *Model) renderAnomalyBanner() []string {
+	if !m.spendAnomalous() {
+		return nil
+	}
+	warnStyle := lipgloss.NewStyle().Foreground(warningColor).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	return []string{
+		warnStyle.Render(i18n.Tf("%s 今日消费异常：%s× 平均", glyphs.Warning, format.Number(m.anomaly.Ratio, 1))),
+		hintStyle.Render(i18n.Tf("  今日 %s，近 %d 天日均 %s，请检查是否有失控的任务或泄露的 API Key",
+			format.Money(m.anomaly.Today), history.AnomalyBaselineDays, format.Money(m.anomaly.Average))),
+	}
+}
+
+func checkAnomalyCmd(store *history.Store, account string) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		days := history.AnomalyBaselineDays + 1
+		// 多取一天，作为窗口内第一个样本的增量基准
+		samples, err := store.Load(account, now.AddDate(0, 0, -days-1))
+		if err != nil {
+			return anomalyCheckedMsg{err: err}
+		}
+		return anomalyCheckedMsg{anomaly: history.DetectAnomaly(history.DailySpend(samples, days, now))}
+	}
+}
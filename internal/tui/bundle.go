@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+
+	"yescode-tui/internal/appdir"
+	"yescode-tui/internal/i18n"
+)
+
+// BundleInfo is the part of the diagnostic bundle only the command knows.
+type BundleInfo struct {
+	Version string
+	Config  map[string]string // effective settings, secrets already redacted
+}
+
+type bundleSavedMsg struct {
+	path string
+	err  error
+}
+
+// WithDiagnosticBundle enables c in the diagnostics dialog, which exports a
+// redacted bundle for bug reports.
+func WithDiagnosticBundle(info BundleInfo) Option {
+	return func(m *Model) {
+		m.bundle = &info
+	}
+}
+
+// exportDiagnostics copies the bundle to the clipboard (OSC 52) and saves it
+// under the application directory.
+func (m *Model) exportDiagnostics() tea.Cmd {
+	if m.bundle == nil {
+		return nil
+	}
+	text := m.buildBundle(time.Now())
+	// 通过 OSC 52 写入剪贴板，终端不支持时仍可使用保存的文件
+	termenv.Copy(text)
+	return saveBundleCmd(text)
+}
+
+// handleBundleSaved reports the export inside the diagnostics dialog, which
+// covers the status bar.
+func (m *Model) handleBundleSaved(msg bundleSavedMsg) {
+	if msg.err != nil {
+		m.diagnosticsNote = i18n.Tf("已复制到剪贴板，但保存文件失败: %v", msg.err)
+		return
+	}
+	m.diagnosticsNote = i18n.T("已复制到剪贴板并保存到 ") + msg.path
+}
+
+// buildBundle renders the bundle as Markdown ready to paste into an issue.
+// It holds no API key, account identity or response data besides errors.
+func (m *Model) buildBundle(now time.Time) string {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+
+	line("## yescode-tui 诊断信息")
+	line("")
+	line("- 生成时间：%s", now.Format(time.RFC3339))
+	for _, env := range m.environmentLines() {
+		line("- %s", env)
+	}
+	line("- 账户：%d 个 · 省流模式：%t", len(m.accounts), m.lowBandwidth)
+
+	line("")
+	line("### 配置（已移除密钥）")
+	line("")
+	line("```")
+	keys := make([]string, 0, len(m.bundle.Config))
+	for k := range m.bundle.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		line("%s = %s", k, m.bundle.Config[k])
+	}
+	line("```")
+
+	line("")
+	line("### 会话统计")
+	line("")
+	for _, s := range m.renderSessionStats() {
+		line("- %s", strings.TrimSpace(s))
+	}
+
+	line("")
+	line("### 最近请求")
+	line("")
+	line("```")
+	for _, r := range m.diagnostics().RecentRequests() {
+		line("%s %-4s %-45s %s %6s %dB", r.Time.Format("15:04:05"), r.Method, r.Endpoint,
+			describeStatus(r.StatusCode), r.Latency.Round(time.Millisecond), r.Bytes)
+	}
+	line("```")
+
+	line("")
+	line("### 最近错误")
+	line("")
+	line("```")
+	for _, e := range m.diagnostics().RecentErrors() {
+		line("%s %s %s [%s] %s", e.Time.Format("15:04:05"), e.Method, e.Endpoint, describeStatus(e.StatusCode), e.Message)
+		if body := strings.Join(strings.Fields(e.Body), " "); body != "" {
+			line("    %s", body)
+		}
+	}
+	line("```")
+	return b.String()
+}
+
+// environmentLines describes the build, system and terminal.
+func (m *Model) environmentLines() []string {
+	version := "unknown"
+	if m.bundle != nil {
+		version = m.bundle.Version
+	}
+	return []string{
+		"版本：" + version,
+		fmt.Sprintf("系统：%s/%s · %s", runtime.GOOS, runtime.GOARCH, runtime.Version()),
+		fmt.Sprintf("终端：TERM=%s COLORTERM=%s TERM_PROGRAM=%s · %dx%d",
+			os.Getenv("TERM"), os.Getenv("COLORTERM"), os.Getenv("TERM_PROGRAM"), m.width, m.height),
+	}
+}
+
+func saveBundleCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		dir, err := appdir.Dir()
+		if err != nil {
+			return bundleSavedMsg{err: err}
+		}
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return bundleSavedMsg{err: err}
+		}
+		path := filepath.Join(dir, "diagnostics-"+time.Now().Format("20060102-150405")+".md")
+		if err := os.WriteFile(path, []byte(text), 0o600); err != nil {
+			return bundleSavedMsg{err: err}
+		}
+		return bundleSavedMsg{path: path}
+	}
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"yescode-tui/internal/i18n"
+)
+
+// buttonKind picks a button's colors.
+type buttonKind int
+
+const (
+	buttonNormal buttonKind = iota
+	buttonPrimary
+	buttonDanger
+)
+
+// button is a clickable action in a dialog or error panel. key is the
+// shortcut it stands for: pressing the key or clicking the button runs the
+// same handler.
+type button struct {
+	label string
+	key   string // as reported by tea.KeyMsg.String()
+	kind  buttonKind
+}
+
+// keyLabels spells the shortcuts shown on buttons.
+var keyLabels = map[string]string{"enter": "Enter", "esc": "Esc"}
+
+// text is the button's plain caption, also used to locate it on screen.
+func (b button) text() string {
+	key, ok := keyLabels[b.key]
+	if !ok {
+		key = b.key
+	}
+	return " " + i18n.T(b.label) + " (" + key + ") "
+}
+
+func (b button) render() string {
+	style := lipgloss.NewStyle().Foreground(onPrimaryColor)
+	switch b.kind {
+	case buttonPrimary:
+		style = style.Bold(true).Background(primaryColor)
+	case buttonDanger:
+		style = style.Bold(true).Background(errorColor)
+	default:
+		style = style.Background(mutedColor)
+	}
+	if noColor {
+		return "[" + b.text() + "]"
+	}
+	return style.Render(b.text())
+}
+
+// renderButtons lays out a row of buttons.
+func renderButtons(buttons ...button) string {
+	parts := make([]string, len(buttons))
+	for i, b := range buttons {
+		parts[i] = b.render()
+	}
+	return strings.Join(parts, "  ")
+}
+
+// retryButton replaces the "press r to retry" hint of error panels.
+var retryButton = button{label: "重试", key: "r", kind: buttonPrimary}
+
+// buttonKeyMsg builds the key message a button stands for.
+func buttonKeyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// buttonAt returns the shortcut of the button drawn at (x, y). It searches
+// the rendered screen for the button captions, so it needs no bookkeeping
+// about where each dialog or panel placed them.
+func (m *Model) buttonAt(x, y int, buttons []button) (string, bool) {
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	if y < 0 || y >= len(lines) {
+		return "", false
+	}
+	line := lines[y]
+	for _, b := range buttons {
+		text := b.text()
+		for offset := 0; ; {
+			i := strings.Index(line[offset:], text)
+			if i < 0 {
+				break
+			}
+			start := ansi.StringWidth(line[:offset+i])
+			if x >= start && x < start+ansi.StringWidth(text) {
+				return b.key, true
+			}
+			offset += i + len(text)
+		}
+	}
+	return "", false
+}
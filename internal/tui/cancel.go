@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"yescode-tui/internal/i18n"
+)
+
+// tabLoads scopes the reads that only serve one tab (the provider list,
+// alternatives and selections). Leaving the tab cancels them so their HTTP
+// calls end right away instead of running on until the client timeout.
+// Writes and the profile refresh use the program context and are only
+// cancelled on quit.
+type tabLoads struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// tabContext returns the context for reads that belong to the current tab.
+func (m *Model) tabContext() context.Context {
+	if m.tabLoads.ctx == nil {
+		m.tabLoads.ctx, m.tabLoads.cancel = context.WithCancel(m.ctx)
+	}
+	return m.tabLoads.ctx
+}
+
+// cancelTabLoads aborts the reads started for the tab being left. Their
+// loading flags are cleared right away, so the next visit starts them again;
+// the cancelled responses that still arrive are dropped by canceled.
+func (m *Model) cancelTabLoads() {
+	if m.tabLoads.cancel == nil {
+		return
+	}
+	m.tabLoads.cancel()
+	m.tabLoads = tabLoads{}
+
+	for _, acct := range m.accounts {
+		if acct.data == nil {
+			continue
+		}
+		acct.data.loadingProviders = false
+		acct.data.loadingMoreProviders = false
+		for _, state := range acct.data.providerData {
+			state.loadingAlternatives = false
+			state.loadingSelection = false
+		}
+	}
+	m.prefetch.queue = nil
+	clear(m.prefetch.running)
+	details, _, _ := strings.Cut(i18n.T("加载提供商 %d 详情中..."), "%d")
+	if strings.Contains(m.status, i18n.T("加载提供商列表中")) || strings.HasPrefix(m.status, details) {
+		m.status = ""
+	}
+}
+
+// canceled reports whether err only means the request was aborted on
+// purpose, which is not worth reporting.
+func canceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
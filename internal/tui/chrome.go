@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/i18n"
+)
+
+// Chrome controls how much room the title, hint line and tab header take.
+type Chrome string
+
+const (
+	// ChromeFull shows the title, the hint line and the tab header on separate rows.
+	ChromeFull Chrome = "full"
+	// ChromeMinimal collapses them into the tab header row.
+	ChromeMinimal Chrome = "minimal"
+	// ChromeAuto switches to minimal chrome on short terminals.
+	ChromeAuto Chrome = "auto"
+)
+
+// autoChromeMinHeight is the terminal height below which ChromeAuto is minimal.
+const autoChromeMinHeight = 30
+
+// ParseChrome validates a chrome mode name.
+func ParseChrome(name string) (Chrome, error) {
+	switch c := Chrome(name); c {
+	case ChromeFull, ChromeMinimal, ChromeAuto:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown chrome mode %q", name)
+}
+
+// WithChrome sets the header layout.
+func WithChrome(c Chrome) Option {
+	return func(m *Model) {
+		m.chrome = c
+	}
+}
+
+func (m *Model) minimalChrome() bool {
+	switch m.chrome {
+	case ChromeMinimal:
+		return true
+	case ChromeAuto:
+		return m.height > 0 && m.height < autoChromeMinHeight
+	}
+	return false
+}
+
+// renderChrome returns the header sections above the tab content.
+func (m *Model) renderChrome() []string {
+	helpHint := i18n.T("支持鼠标操作 · Enter 确认 · Esc 返回 · q 退出 · 输入 ? 查看完整操作帮助")
+	if m.minimalChrome() {
+		helpHint = glyphs.Title + i18n.T(" YesCode · ? 帮助")
+	}
+	if m.lowBandwidth {
+		helpHint += i18n.T(" · 省流模式")
+	}
+	if len(m.accounts) > 1 {
+		helpHint += i18n.T(" · 账户：") + m.accountName(m.accountIdx)
+	}
+
+	if m.minimalChrome() {
+		// 标签页保持在行首，点击位置与完整布局一致
+		tabs := m.renderTabHeader()
+		rest := max(m.width-lipgloss.Width(tabs)-1, 0)
+		hint := lipgloss.NewStyle().Foreground(mutedColor).Render(truncate(helpHint, rest))
+		return []string{tabs + " " + hint}
+	}
+
+	// Material Design 风格应用标题
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(primaryColor).
+		Width(m.width).
+		Align(lipgloss.Center)
+
+	// 简洁的帮助提示
+	helpHintStyle := lipgloss.NewStyle().
+		Foreground(mutedColor).
+		Width(m.width).
+		Align(lipgloss.Center)
+
+	return []string{
+		titleStyle.Render(glyphs.Title + " YesCode TUI " + glyphs.Title),
+		helpHintStyle.Render(helpHint),
+		m.renderTabHeader(),
+	}
+}
+
+// uiLayout calculates UI element positions based on the View() structure.
+func (m *Model) uiLayout() uiLayout {
+	if m.minimalChrome() {
+		return uiLayout{
+			tabHeaderY:        0, // Tabs and hint share Y=0
+			contentStartY:     2, // Content starts at Y=2 (after header + blank)
+			panelInnerOffsetY: 2,
+			panelInnerOffsetX: 3,
+		}
+	}
+	return uiLayout{
+		titleLineY:        0, // Title at Y=0
+		helpLineY:         2, // Help hint at Y=2 (title + blank line)
+		tabHeaderY:        4, // Tab header at Y=4 (title + blank + help + blank)
+		contentStartY:     6, // Content starts at Y=6 (after tab header + blank)
+		panelInnerOffsetY: 2, // Panel has 1 line border + 1 line padding
+		panelInnerOffsetX: 3, // Panel has left border (1) + left padding (2)
+	}
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"context"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+type consistencyCheckedMsg struct {
+	mismatches []api.Mismatch
+	err        error
+}
+
+// checkConsistency cross-validates the cached provider flags against the
+// cached profile. The two are loaded at different times, so a disagreement is
+// only reported after fetching both concurrently confirms it.
+func (m *Model) checkConsistency() tea.Cmd {
+	if m.profile == nil || m.providerFlags == nil || m.checkingConsistency {
+		return nil
+	}
+	cached := api.CheckConsistency(m.profile, m.providerFlags)
+	if len(cached) == 0 {
+		m.consistency = nil
+		return nil
+	}
+	if slices.Equal(cached, m.consistency) {
+		return nil
+	}
+	m.checkingConsistency = true
+	return consistencyCheckCmd(m.ctx, m.client)
+}
+
+func (m *Model) handleConsistencyChecked(msg consistencyCheckedMsg) []tea.Cmd {
+	m.checkingConsistency = false
+	if msg.err != nil {
+		// 加载失败已由其他请求提示，这里不重复报错
+		return nil
+	}
+	previous := m.consistency
+	m.consistency = msg.mismatches
+	if len(msg.mismatches) == 0 || slices.Equal(previous, msg.mismatches) {
+		return nil
+	}
+	return []tea.Cmd{m.showToast(toastWarning, i18n.Tf("数据不一致：%s，服务器缓存可能已过期", describeMismatch(msg.mismatches[0])))}
+}
+
+// describeMismatch explains a mismatch in terms of the two endpoints.
+func describeMismatch(mm api.Mismatch) string {
+	switch {
+	case mm.Flag == "has_payg_balance" && mm.Flagged:
+		return i18n.T("提供商列表显示有按需余额，但用户资料中按需余额为 ") + format.Money(mm.Balance)
+	case mm.Flag == "has_payg_balance":
+		return i18n.T("提供商列表显示无按需余额，但用户资料中按需余额为 ") + format.Money(mm.Balance)
+	case mm.Flagged:
+		return i18n.T("提供商列表显示有订阅，但用户资料中订阅未激活")
+	default:
+		return i18n.Tf("提供商列表显示无订阅，但用户资料中订阅已激活（余额 %s）", format.Money(mm.Balance))
+	}
+}
+
+// renderConsistencyWarnings lists confirmed mismatches under the balances.
+func (m *Model) renderConsistencyWarnings() []string {
+	if len(m.consistency) == 0 {
+		return nil
+	}
+	warnStyle := lipgloss.NewStyle().Foreground(warningColor)
+	lines := []string{warnStyle.Render(i18n.Tf("  %s 数据不一致，服务器缓存可能已过期：", glyphs.Warning))}
+	for _, mm := range m.consistency {
+		lines = append(lines, warnStyle.Render("    "+describeMismatch(mm)))
+	}
+	return lines
+}
+
+func consistencyCheckCmd(ctx context.Context, client api.Service) tea.Cmd {
+	return func() tea.Msg {
+		mismatches, err := client.CheckConsistency(ctx)
+		return consistencyCheckedMsg{mismatches: mismatches, err: err}
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import "fmt"
+
+// Density controls how much detail list rows show.
+type Density string
+
+const (
+	// DensityCompact renders every list row on a single line.
+	DensityCompact Density = "compact"
+	// DensityComfortable adds the dimmed description under each row.
+	DensityComfortable Density = "comfortable"
+)
+
+// ParseDensity validates a density name.
+func ParseDensity(name string) (Density, error) {
+	switch d := Density(name); d {
+	case DensityCompact, DensityComfortable:
+		return d, nil
+	}
+	return "", fmt.Errorf("unknown density %q", name)
+}
+
+// WithDensity sets the list row density.
+func WithDensity(d Density) Option {
+	return func(m *Model) {
+		m.density = d
+	}
+}
+
+// descriptionLines returns the dimmed description row shown under a list row,
+// or nothing in compact density.
+func (m *Model) descriptionLines(area focusArea, desc string) []string {
+	if m.density != DensityComfortable || desc == "" {
+		return nil
+	}
+	// 面板左右内边距 2+2，描述缩进 4
+	width := m.panelWidth(area) - 8
+	return []string{helpStyle.Render("    " + truncate(desc, width))}
+}
+
+// rowAtLine maps a line inside a list panel to the row index, accounting for
+// description lines; it returns -1 when the line is outside every row.
+func (m *Model) rowAtLine(area focusArea, line int, descs []string) int {
+	if line < 0 {
+		return -1
+	}
+	for i, desc := range descs {
+		height := 1 + len(m.descriptionLines(area, desc))
+		if line < height {
+			return i
+		}
+		line -= height
+	}
+	return -1
+}
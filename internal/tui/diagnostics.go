@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+const (
+	diagnosticsMaxWidth     = 100
+	diagnosticsRecentErrors = 5
+)
+
+// describeStatus labels a recorded status code; 0 means a transport failure.
+func describeStatus(status int) string {
+	if status == 0 {
+		return i18n.T("网络错误")
+	}
+	return fmt.Sprintf("%d", status)
+}
+
+// diagnostics returns the client's request history; services that keep
+// none, like the in-memory fake, get empty views.
+func (m *Model) diagnostics() api.Diagnostics {
+	if d, ok := m.client.(api.Diagnostics); ok {
+		return d
+	}
+	return noDiagnostics{}
+}
+
+type noDiagnostics struct{}
+
+func (noDiagnostics) Stats() api.SessionStats             { return api.SessionStats{} }
+func (noDiagnostics) RecentErrors() []api.RequestError    { return nil }
+func (noDiagnostics) RecentRequests() []api.RequestRecord { return nil }
+
+func (m *Model) renderDiagnosticsDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	headerStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	errorStyle := lipgloss.NewStyle().Foreground(errorColor)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor).Italic(true)
+
+	width := diagnosticsMaxWidth
+	if m.width > 0 && m.width-4 < width {
+		width = m.width - 4
+	}
+	// 边框 2 + 左右内边距 6
+	inner := width - 8
+
+	errs := m.diagnostics().RecentErrors()
+	lines := []string{titleStyle.Render(i18n.T("诊断信息")), ""}
+
+	lines = append(lines, sectionStyle.Render(i18n.T("本次会话")))
+	lines = append(lines, m.renderSessionStats()...)
+	lines = append(lines, "")
+
+	lines = append(lines, sectionStyle.Render(i18n.T("请求失败统计")))
+	stats := api.AggregateFailures(errs)
+	if len(stats) == 0 {
+		lines = append(lines, i18n.T("  本次会话暂无失败请求"))
+	} else {
+		lines = append(lines, headerStyle.Render("  "+padRight(i18n.T("次数"), 8)+" "+padRight(i18n.T("状态"), 10)+i18n.T(" 首次 → 最近  端点")))
+		for _, stat := range stats {
+			row := fmt.Sprintf("  %-8d %s %s → %s  %s %s",
+				stat.Count,
+				padRight(describeStatus(stat.StatusCode), 10),
+				format.Time(stat.FirstSeen),
+				format.Time(stat.LastSeen),
+				stat.Method,
+				stat.Endpoint,
+			)
+			lines = append(lines, truncate(row, inner))
+		}
+	}
+
+	if len(errs) > 0 {
+		lines = append(lines, "", sectionStyle.Render(i18n.T("最近错误")))
+		start := len(errs) - diagnosticsRecentErrors
+		if start < 0 {
+			start = 0
+		}
+		for i := len(errs) - 1; i >= start; i-- {
+			e := errs[i]
+			row := fmt.Sprintf("  %s %s %s [%s] %s",
+				format.Time(e.Time), e.Method, e.Endpoint, describeStatus(e.StatusCode), e.Message)
+			lines = append(lines, errorStyle.Render(truncate(row, inner)))
+			if body := strings.Join(strings.Fields(e.Body), " "); body != "" {
+				// 保留服务端原始响应，便于查看未本地化的 error/message 字段
+				lines = append(lines, headerStyle.Render(truncate(i18n.T("    原始响应：")+body, inner)))
+			}
+		}
+	}
+
+	lines = append(lines, "")
+	if m.diagnosticsNote != "" {
+		lines = append(lines, truncate(m.diagnosticsNote, inner))
+	}
+	if m.bundle != nil {
+		lines = append(lines, hintStyle.Render(i18n.T("诊断包会复制到剪贴板并保存到文件，其中的密钥已移除")))
+	}
+	lines = append(lines, renderButtons(m.diagnosticsButtons()...))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(width)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
+
+func (m *Model) renderSessionStats() []string {
+	stats := m.diagnostics().Stats()
+	hitRate := i18n.T("暂无条件请求")
+	if stats.Conditional > 0 {
+		hitRate = i18n.Tf("%.0f%%（%d/%d）", stats.CacheHitRate()*100, stats.CacheHits, stats.Conditional)
+	}
+	return []string{
+		i18n.Tf("  API 调用：%d 次（含重试）", stats.Requests),
+		i18n.Tf("  传输数据：上行 %s · 下行 %s", formatBytes(stats.BytesSent), formatBytes(stats.BytesReceived)),
+		i18n.Tf("  缓存命中率：%s", hitRate),
+		i18n.Tf("  平均延迟：%s", stats.AverageLatency().Round(time.Millisecond)),
+	}
+}
+
+// formatBytes renders a byte count with a binary unit.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KiB"
+	for _, next := range []string{"MiB", "GiB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// padRight pads s with spaces to width display cells.
+func padRight(s string, width int) string {
+	if gap := width - lipgloss.Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// padLeft right-aligns s to width display cells.
+func padLeft(s string, width int) string {
+	if gap := width - lipgloss.Width(s); gap > 0 {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s
+}
+
+// truncate shortens s to at most width display cells, adding an ellipsis.
+func truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes)+glyphs.Ellipsis) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + glyphs.Ellipsis
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/i18n"
+)
+
+// docsURL points to the usage guide shown in empty states.
+const docsURL = "https://github.com/kywrl/yescode-tui#readme"
+
+// renderProvidersEmpty explains why the provider list is empty and what to do.
+func (m *Model) renderProvidersEmpty() []string {
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	if query := m.filter.query(); query != "" {
+		return []string{
+			i18n.Tf("没有匹配“%s”的提供商", query),
+			hintStyle.Render(i18n.T("按 Esc 清除筛选")),
+		}
+	}
+	if len(m.allProviders) > 0 {
+		return []string{
+			i18n.T("所有提供商都已隐藏"),
+			hintStyle.Render(i18n.Tf("按 X 显示全部 %d 个提供商", len(m.allProviders))),
+		}
+	}
+	if flags := m.providerFlags; flags != nil && !flags.HasSubscription && !flags.HasPaygBalance {
+		return []string{
+			i18n.T("账户暂无订阅和按需余额，因此没有可用提供商"),
+			"",
+			i18n.T("在控制台订阅套餐或充值："),
+			"  " + m.client.BaseURL(),
+			hintStyle.Render(i18n.T("完成后按 r 重新加载")),
+		}
+	}
+	return []string{
+		i18n.T("暂无可用提供商"),
+		"",
+		i18n.T("服务端没有为该账户开放任何提供商"),
+		hintStyle.Render(i18n.T("按 r 重新加载 · 使用说明：") + docsURL),
+	}
+}
+
+// renderOnboarding guides accounts that can't make requests yet: no
+// subscription and no balance, or a balance that has run out.
+func (m *Model) renderOnboarding() []string {
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	warnStyle := lipgloss.NewStyle().Foreground(warningColor)
+	console := m.client.BaseURL()
+	subscribed := m.profile.SubscriptionPlan.Name != ""
+
+	switch {
+	case m.profile.Balance > 0:
+		return nil
+	case !subscribed:
+		return []string{
+			titleStyle.Render(i18n.T("开始使用")),
+			i18n.T("  账户暂无订阅和余额，完成以下任一步骤后即可发起请求："),
+			i18n.Tf("  %s 订阅套餐：在控制台选择订阅计划", glyphs.Bullet),
+			i18n.Tf("  %s 按需充值：在控制台充值按需余额", glyphs.Bullet),
+			i18n.T("  控制台：") + console,
+			hintStyle.Render(i18n.T("  完成后按 r 刷新 · 使用说明：") + docsURL),
+		}
+	default:
+		return []string{
+			warnStyle.Render(i18n.Tf("%s 余额已用完", glyphs.Warning)),
+			i18n.T("  订阅余额和按需余额均为 $0，新的请求将被拒绝："),
+			i18n.Tf("  %s 等待订阅额度在下个周期重置，或", glyphs.Bullet),
+			i18n.Tf("  %s 在控制台充值按需余额：%s", glyphs.Bullet, console),
+			hintStyle.Render(i18n.T("  充值后按 r 刷新")),
+		}
+	}
+}
+
+// renderSubscribeHint suggests a subscription below the spending stats of
+// accounts that only use PAYG balance.
+func (m *Model) renderSubscribeHint() []string {
+	if m.profile.Balance <= 0 {
+		// 余额为 0 时已由 renderOnboarding 给出完整指引
+		return nil
+	}
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	return []string{hintStyle.Render(i18n.T("  未订阅套餐，当前仅使用按需余额 · 订阅：") + m.client.BaseURL())}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+// errorAdvice turns a request error into a short explanation and next steps.
+type errorAdvice struct {
+	title string
+	hints []string
+}
+
+// adviseError maps common API and network failures to friendly guidance;
+// console is the web console URL.
+func adviseError(err error, console string) errorAdvice {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		switch code := apiErr.StatusCode; {
+		case code == http.StatusUnauthorized:
+			return errorAdvice{
+				title: i18n.T("API Key 无效或已过期"),
+				hints: []string{
+					i18n.T("检查 --api-key、YESCODE_API_KEY 或配置文件中的 api_key"),
+					i18n.T("可在控制台重新生成：") + console,
+				},
+			}
+		case code == http.StatusForbidden:
+			return errorAdvice{
+				title: i18n.T("当前套餐无权执行此操作"),
+				hints: []string{
+					i18n.T("该功能可能需要订阅或更高等级的套餐"),
+					i18n.T("查看套餐：") + console,
+				},
+			}
+		case code == http.StatusNotFound:
+			return errorAdvice{
+				title: i18n.T("接口不存在"),
+				hints: []string{i18n.T("检查 --base-url 是否指向 YesCode 服务")},
+			}
+		case code == http.StatusTooManyRequests:
+			return errorAdvice{
+				title: i18n.T("请求过于频繁"),
+				hints: []string{i18n.T("稍后重试，或使用 --low-bandwidth 降低刷新频率")},
+			}
+		case code >= 500:
+			return errorAdvice{
+				title: i18n.Tf("YesCode 服务暂时不可用（HTTP %d）", code),
+				hints: []string{
+					i18n.T("通常很快恢复，请稍后重试"),
+					i18n.T("服务状态：") + console,
+				},
+			}
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return errorAdvice{
+			title: i18n.T("连接超时"),
+			hints: []string{
+				i18n.T("检查网络或代理设置（--proxy 或 HTTPS_PROXY）"),
+				i18n.T("网络受限时可尝试 --connect-to、--dns 或 --http3"),
+			},
+		}
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return errorAdvice{
+			title: i18n.T("无法连接到服务器"),
+			hints: []string{
+				i18n.T("检查网络、代理设置和 --base-url"),
+				i18n.T("网络受限时可尝试 --connect-to、--dns 或 --http3"),
+			},
+		}
+	}
+	return errorAdvice{title: i18n.T("请求失败")}
+}
+
+// describeError is the one-line form of adviseError for the status bar;
+// unrecognized errors keep their raw text.
+func (m *Model) describeError(err error) string {
+	advice := adviseError(err, m.client.BaseURL())
+	if len(advice.hints) == 0 {
+		return fmt.Sprintf("%s: %v", advice.title, err)
+	}
+	return advice.title
+}
+
+// renderErrorAdvice renders err with its guidance in the affected panel; the
+// raw error is kept as a muted detail line truncated to width.
+func (m *Model) renderErrorAdvice(err error, width int) []string {
+	errorStyle := lipgloss.NewStyle().Foreground(errorColor)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor)
+
+	advice := adviseError(err, m.client.BaseURL())
+	lines := []string{errorStyle.Render(fmt.Sprintf("%s %s", glyphs.Warning, advice.title)), ""}
+	for _, hint := range advice.hints {
+		lines = append(lines, fmt.Sprintf("%s %s", glyphs.Bullet, hint))
+	}
+	if len(advice.hints) > 0 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, hintStyle.Render(truncate(i18n.T("详情：")+err.Error(), width)))
+	return lines
+}
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+// errorDetailMaxBodyLines caps the raw response shown in the dialog; the
+// copied text always holds all of it.
+const errorDetailMaxBodyLines = 15
+
+// errorDetailState is the open error detail dialog.
+type errorDetailState struct {
+	err  *api.APIError
+	note string
+}
+
+// showErrorToast reports a failed request. API error responses are kept for
+// the error detail dialog and their toast points to it.
+func (m *Model) showErrorToast(text string, err error) tea.Cmd {
+	cmd := m.showToast(toastError, text)
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		m.lastAPIError = apiErr
+		m.toasts[len(m.toasts)-1].hint = i18n.T("E 详情")
+	}
+	return cmd
+}
+
+// openErrorDetail shows the latest API error of the active account.
+func (m *Model) openErrorDetail() tea.Cmd {
+	if m.lastAPIError == nil {
+		m.status = i18n.T("本次会话暂无 API 错误")
+		return clearStatusAfter(statusClearDelay)
+	}
+	m.errorDetail = &errorDetailState{err: m.lastAPIError}
+	return nil
+}
+
+// handleErrorDetailKey copies the report with c and closes with Esc or E.
+func (m *Model) handleErrorDetailKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "E", "enter":
+		m.errorDetail = nil
+	case "c":
+		// 通过 OSC 52 写入剪贴板
+		termenv.Copy(errorReport(m.errorDetail.err))
+		m.errorDetail.note = i18n.T("已复制到剪贴板")
+	}
+	return nil
+}
+
+func (m *Model) errorDetailButtons() []button {
+	return []button{
+		{label: "复制", key: "c", kind: buttonPrimary},
+		{label: "关闭", key: "esc"},
+	}
+}
+
+// errorReport is the plain-text form of err copied to the clipboard.
+func errorReport(err *api.APIError) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", err.Method, err.Path)
+	fmt.Fprintf(&b, "Status: %d %s\n", err.StatusCode, http.StatusText(err.StatusCode))
+	fmt.Fprintf(&b, "Time: %s\n", err.Time.Format("2006-01-02T15:04:05.000Z07:00"))
+	if err.Message != "" {
+		fmt.Fprintf(&b, "Message: %s\n", err.Message)
+	}
+	b.WriteString("\n")
+	b.WriteString(prettyBody(err.Body))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// prettyBody indents a JSON response body; other bodies are kept as is.
+func prettyBody(body string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(body), "", "  "); err == nil {
+		return out.String()
+	}
+	return strings.TrimSpace(body)
+}
+
+func (m *Model) renderErrorDetailDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	labelStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	errorStyle := lipgloss.NewStyle().Foreground(errorColor)
+
+	width := diagnosticsMaxWidth
+	if m.width > 0 && m.width-4 < width {
+		width = m.width - 4
+	}
+	// 边框 2 + 左右内边距 6
+	inner := width - 8
+
+	err := m.errorDetail.err
+	field := func(label, value string) string {
+		return labelStyle.Render(padRight(i18n.T(label), 10)) + value
+	}
+	status := fmt.Sprintf("%d %s", err.StatusCode, http.StatusText(err.StatusCode))
+	lines := []string{
+		titleStyle.Render(i18n.T("错误详情")),
+		"",
+		field("状态码", errorStyle.Render(status)),
+		field("请求", truncate(err.Method+" "+err.Path, inner-10)),
+		field("时间", format.DateTime(err.Time)),
+	}
+	if err.Message != "" {
+		lines = append(lines, field("消息", truncate(err.Message, inner-10)))
+	}
+
+	lines = append(lines, "", labelStyle.Render(i18n.T("原始响应")))
+	body := prettyBody(err.Body)
+	if body == "" {
+		body = i18n.T("（空）")
+	}
+	bodyLines := strings.Split(body, "\n")
+	if len(bodyLines) > errorDetailMaxBodyLines {
+		more := len(bodyLines) - errorDetailMaxBodyLines
+		bodyLines = append(bodyLines[:errorDetailMaxBodyLines], labelStyle.Render(i18n.Tf("… 另有 %d 行，复制后查看完整内容", more)))
+	}
+	for _, line := range bodyLines {
+		lines = append(lines, "  "+truncate(line, inner-2))
+	}
+
+	lines = append(lines, "")
+	if m.errorDetail.note != "" {
+		lines = append(lines, m.errorDetail.note)
+	}
+	lines = append(lines, renderButtons(m.errorDetailButtons()...))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(errorColor).
+		Padding(1, 3).
+		Width(width)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+const (
+	estimatorInputTokens = iota
+	estimatorOutputTokens
+	estimatorInputPrice
+	estimatorOutputPrice
+	estimatorFieldCount
+)
+
+// daysPerMonth is used to project monthly cost from a daily estimate.
+const daysPerMonth = 30
+
+// estimatorState holds the token usage estimator form.
+type estimatorState struct {
+	providerID int
+	inputs     []textinput.Model
+	focused    int
+}
+
+var estimatorLabels = [estimatorFieldCount]string{
+	"每日输入 tokens",
+	"每日输出 tokens",
+	"输入单价（$/百万）",
+	"输出单价（$/百万）",
+}
+
+func newEstimatorState(providerID int) *estimatorState {
+	// 基准单价默认取常见 Sonnet 级模型的官方价格，可自行修改
+	defaults := [estimatorFieldCount]string{"1000000", "200000", "3", "15"}
+
+	inputs := make([]textinput.Model, estimatorFieldCount)
+	for i := range inputs {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.CharLimit = 16
+		ti.Width = 16
+		ti.Cursor.SetMode(cursor.CursorStatic)
+		ti.SetValue(defaults[i])
+		inputs[i] = ti
+	}
+	inputs[0].Focus()
+	return &estimatorState{providerID: providerID, inputs: inputs}
+}
+
+// value parses field i, reporting whether it holds a non-negative number.
+func (e *estimatorState) value(i int) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(e.inputs[i].Value()), ",", ""), 64)
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// dailyCost computes the projected daily cost at the given rate multiplier.
+func (e *estimatorState) dailyCost(multiplier float64) (float64, bool) {
+	var vals [estimatorFieldCount]float64
+	for i := range vals {
+		v, ok := e.value(i)
+		if !ok {
+			return 0, false
+		}
+		vals[i] = v
+	}
+	base := vals[estimatorInputTokens]/1e6*vals[estimatorInputPrice] +
+		vals[estimatorOutputTokens]/1e6*vals[estimatorOutputPrice]
+	return base * multiplier, true
+}
+
+func (e *estimatorState) focus(i int) {
+	e.inputs[e.focused].Blur()
+	e.focused = (i + estimatorFieldCount) % estimatorFieldCount
+	e.inputs[e.focused].Focus()
+}
+
+// openEstimator opens the estimator for the highlighted provider.
+func (m *Model) openEstimator() {
+	if m.currentTab != tabProviders || len(m.providers) == 0 {
+		return
+	}
+	m.estimator = newEstimatorState(m.currentProviderID())
+}
+
+// handleEstimatorKey handles keys while the estimator form is open.
+func (m *Model) handleEstimatorKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.estimator = nil
+		return nil
+	case "tab", "down", "enter":
+		m.estimator.focus(m.estimator.focused + 1)
+		return nil
+	case "shift+tab", "up":
+		m.estimator.focus(m.estimator.focused - 1)
+		return nil
+	}
+
+	var cmd tea.Cmd
+	idx := m.estimator.focused
+	m.estimator.inputs[idx], cmd = m.estimator.inputs[idx].Update(msg)
+	return cmd
+}
+
+// estimatorRates returns the provider name, the active rate multiplier and the
+// cheapest alternative for the estimator's provider.
+func (m *Model) estimatorRates() (name string, current float64, cheapestName string, cheapest float64) {
+	for _, bucket := range m.providers {
+		if bucket.Provider.ID == m.estimator.providerID {
+			name = bucket.Provider.DisplayName
+			current = bucket.RateMultiplier
+			break
+		}
+	}
+
+	state := m.ensureProviderState(m.estimator.providerID)
+	if state.selection != nil && state.selection.SelectedAlternative.RateMultiplier > 0 {
+		current = state.selection.SelectedAlternative.RateMultiplier
+	}
+
+	cheapest = current
+	cheapestName = i18n.T("当前方案")
+	for _, alt := range state.alternatives {
+		if alt.Alternative.RateMultiplier > 0 && alt.Alternative.RateMultiplier < cheapest {
+			cheapest = alt.Alternative.RateMultiplier
+			cheapestName = alt.Alternative.DisplayName
+		}
+	}
+	return name, current, cheapestName, cheapest
+}
+
+func (m *Model) renderEstimatorDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	labelStyle := lipgloss.NewStyle().Width(20)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor).Italic(true)
+
+	name, current, cheapestName, cheapest := m.estimatorRates()
+
+	lines := []string{
+		titleStyle.Render(i18n.T("用量费用估算")),
+		helpStyle.Render(i18n.Tf("提供商：%s", name)),
+		"",
+	}
+	for i, input := range m.estimator.inputs {
+		prefix := cursorPrefix(i == m.estimator.focused)
+		lines = append(lines, prefix+labelStyle.Render(i18n.T(estimatorLabels[i]))+input.View())
+	}
+	lines = append(lines, "")
+
+	row := func(label string, multiplier float64) string {
+		daily, ok := m.estimator.dailyCost(multiplier)
+		if !ok {
+			return i18n.Tf("  %s ×%.2f：请输入有效数字", label, multiplier)
+		}
+		return i18n.Tf("  %s ×%.2f：每日 %s · 每月 %s", label, multiplier, format.Money(daily), format.Money(daily*daysPerMonth))
+	}
+	lines = append(lines, row(i18n.T("当前方案"), current))
+	if cheapest < current {
+		savingStyle := lipgloss.NewStyle().Foreground(successColor)
+		lines = append(lines, savingStyle.Render(row(i18n.T("最便宜 ")+cheapestName, cheapest)))
+		if daily, ok := m.estimator.dailyCost(current - cheapest); ok {
+			lines = append(lines, savingStyle.Render(i18n.T("  切换后每月可省 ")+format.Money(daily*daysPerMonth)))
+		}
+	} else {
+		lines = append(lines, helpStyle.Render(i18n.T("  当前方案已是最低倍率")))
+	}
+	lines = append(lines, "", hintStyle.Render(i18n.T("Tab/↑↓ 切换输入项 · Esc 关闭")))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(64)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
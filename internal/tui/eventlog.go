@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/eventlog"
+	"yescode-tui/internal/i18n"
+)
+
+// eventLogState is the open event viewer, a snapshot of the event log taken
+// when opened; r takes a new one.
+type eventLogState struct {
+	events []eventlog.Event
+	cursor int
+	offset int
+}
+
+// WithEventLog records every message the model handles to log and enables
+// the hidden Ctrl+E event viewer.
+func WithEventLog(log *eventlog.Log) Option {
+	return func(m *Model) {
+		m.eventLog = log
+	}
+}
+
+// recordEvent logs msg when the event log is on.
+func (m *Model) recordEvent(msg tea.Msg) {
+	if m.eventLog == nil {
+		return
+	}
+	m.eventLog.Record(eventlog.Event{Time: time.Now(), Type: fmt.Sprintf("%T", msg), Detail: m.describeMsg(msg)})
+}
+
+// describeMsg picks the fields of msg worth logging. Payloads such as the
+// profile are left out, and so are keys typed on the lock screen, which may
+// be the PIN.
+func (m *Model) describeMsg(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.lock.locked {
+			return "key=<已移除>"
+		}
+		return fmt.Sprintf("key=%q", msg.String())
+	case tea.MouseMsg:
+		return fmt.Sprintf("x=%d y=%d event=%q", msg.X, msg.Y, msg.String())
+	case tea.WindowSizeMsg:
+		return fmt.Sprintf("width=%d height=%d", msg.Width, msg.Height)
+	case accountMsg:
+		inner := fmt.Sprintf("%T", msg.msg)
+		if detail := m.describeMsg(msg.msg); detail != "" {
+			inner += " " + detail
+		}
+		return fmt.Sprintf("account=%d %s", msg.account, inner)
+	case providersLoadedMsg:
+		return fmt.Sprintf("providers=%d", len(msg.response.Providers))
+	case alternativesLoadedMsg:
+		return fmt.Sprintf("provider=%d alternatives=%d", msg.providerID, len(msg.alternatives))
+	case selectionLoadedMsg:
+		return fmt.Sprintf("provider=%d selected=%d", msg.providerID, selectedAlternativeID(msg.selection))
+	case switchCompletedMsg:
+		return fmt.Sprintf("provider=%d selected=%d", msg.providerID, selectedAlternativeID(msg.selection))
+	case providerLoadFailedMsg:
+		return fmt.Sprintf("provider=%d target=%s err=%q", msg.providerID, msg.target, msg.err)
+	case errMsg:
+		return fmt.Sprintf("target=%s err=%q", msg.target, msg.err)
+	case preferenceUpdatedMsg:
+		return fmt.Sprintf("preference=%s", msg.preference)
+	case preferenceFailedMsg:
+		return fmt.Sprintf("target=%s err=%q", msg.target, msg.err)
+	}
+	return ""
+}
+
+// openEventLog shows the latest events with the newest selected.
+func (m *Model) openEventLog() tea.Cmd {
+	if m.eventLog == nil {
+		m.status = i18n.T("事件日志需要使用 --debug-ui 启动")
+		return clearStatusAfter(statusClearDelay)
+	}
+	m.eventView = &eventLogState{}
+	m.reloadEventLog()
+	return nil
+}
+
+func (m *Model) reloadEventLog() {
+	m.eventView.events = m.eventLog.Recent()
+	m.eventView.cursor = max(len(m.eventView.events)-1, 0)
+}
+
+// handleEventLogKey moves through the events, reloads with r and closes
+// with Esc or Ctrl+E.
+func (m *Model) handleEventLogKey(msg tea.KeyMsg) tea.Cmd {
+	s := m.eventView
+	switch msg.String() {
+	case "esc", "ctrl+e":
+		m.eventView = nil
+	case "up", "k":
+		s.cursor = max(s.cursor-1, 0)
+	case "down", "j":
+		s.cursor = max(min(s.cursor+1, len(s.events)-1), 0)
+	case "home", "g":
+		s.cursor = 0
+	case "end", "G":
+		s.cursor = max(len(s.events)-1, 0)
+	case "r":
+		m.reloadEventLog()
+	}
+	return nil
+}
+
+func (m *Model) eventLogButtons() []button {
+	return []button{
+		{label: "刷新", key: "r"},
+		{label: "关闭", key: "esc", kind: buttonPrimary},
+	}
+}
+
+func (m *Model) renderEventLogDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	labelStyle := lipgloss.NewStyle().Foreground(mutedColor)
+
+	width := diagnosticsMaxWidth
+	if m.width > 0 && m.width-4 < width {
+		width = m.width - 4
+	}
+	// 边框 2 + 左右内边距 6
+	inner := width - 8
+
+	s := m.eventView
+	lines := []string{
+		titleStyle.Render(i18n.T("事件日志")),
+		labelStyle.Render(truncate(i18n.Tf("写入 %s", m.eventLog.Path()), inner)),
+		"",
+	}
+	if len(s.events) == 0 {
+		lines = append(lines, helpStyle.Render(i18n.T("还没有事件")))
+	} else {
+		// 与请求日志相同，为选中事件的详情留出空间
+		rows := m.requestLogRows()
+		s.offset = min(s.offset, s.cursor)
+		s.offset = max(s.offset, s.cursor-rows+1)
+		end := min(s.offset+rows, len(s.events))
+		for i := s.offset; i < end; i++ {
+			prefix := "  "
+			if i == s.cursor {
+				prefix = glyphs.Cursor + " "
+			}
+			e := s.events[i]
+			// 光标 2 + 时间 13
+			row := padRight(truncate(e.Type, 28), 28) + " " + e.Detail
+			lines = append(lines, prefix+e.Time.Format("15:04:05.000")+" "+truncate(row, inner-15))
+		}
+		if more := len(s.events) - end; more > 0 {
+			lines = append(lines, labelStyle.Render(i18n.Tf("▼ 还有 %d 行", more)))
+		}
+		e := s.events[s.cursor]
+		lines = append(lines, "",
+			labelStyle.Render(padRight(i18n.T("类型"), 10))+truncate(e.Type, inner-10),
+			labelStyle.Render(padRight(i18n.T("内容"), 10))+truncate(e.Detail, inner-10),
+		)
+	}
+
+	lines = append(lines, "", renderButtons(m.eventLogButtons()...))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(width)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+// listFilter narrows both provider tab lists while a query is set: the
+// providers and the current provider's alternatives. editing is true while
+// its "/" prompt has the keyboard.
+type listFilter struct {
+	input   textinput.Model
+	editing bool
+}
+
+func (f *listFilter) query() string {
+	if f == nil {
+		return ""
+	}
+	return strings.TrimSpace(f.input.Value())
+}
+
+// editingFilter returns the filter if its prompt is open.
+func (m *Model) editingFilter() *listFilter {
+	if m.filter != nil && m.filter.editing {
+		return m.filter
+	}
+	return nil
+}
+
+// openFilter shows the "/" prompt, keeping the current query so it can be
+// refined.
+func (m *Model) openFilter() {
+	if m.filter != nil {
+		m.filter.editing = true
+		m.filter.input.Focus()
+		return
+	}
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = i18n.T("按名称、类型或来源筛选提供商和方案")
+	ti.CharLimit = 32
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.Focus()
+	m.filter = &listFilter{input: ti, editing: true}
+}
+
+// clearFilter drops the query and shows both lists in full again. It reports
+// false when no filter was set.
+func (m *Model) clearFilter() (tea.Cmd, bool) {
+	if m.filter == nil {
+		return nil, false
+	}
+	m.filter = nil
+	return m.applyFilter(), true
+}
+
+// handleFilterKey handles keys while the filter prompt is open. Both lists
+// narrow as the query is typed; ↑↓ move within the focused panel's matches.
+func (m *Model) handleFilterKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		cmd, _ := m.clearFilter()
+		return cmd
+	case "enter":
+		m.filter.editing = false
+		m.filter.input.Blur()
+		if m.filter.query() == "" {
+			m.filter = nil
+		}
+		return nil
+	case "up", "ctrl+p":
+		return m.moveSelection(-1)
+	case "down", "ctrl+n":
+		return m.moveSelection(1)
+	}
+
+	var cmd tea.Cmd
+	m.filter.input, cmd = m.filter.input.Update(msg)
+	return tea.Batch(cmd, m.applyFilter())
+}
+
+// applyFilter refreshes both panels for the current query, moving each
+// cursor to the first match when its row no longer matches.
+func (m *Model) applyFilter() tea.Cmd {
+	cmd := m.setListView(m.view)
+	state := m.ensureProviderState(m.currentProviderID())
+	visible := m.visibleAlternatives(state)
+	if len(visible) > 0 && !slices.Contains(visible, m.altIdx) {
+		m.altIdx = visible[0]
+	}
+	return cmd
+}
+
+// filterProviders keeps the providers matching the query, best match first.
+func (m *Model) filterProviders(providers []api.ProviderBucket) []api.ProviderBucket {
+	query := m.filter.query()
+	if query == "" {
+		return providers
+	}
+	type scored struct {
+		bucket api.ProviderBucket
+		score  int
+	}
+	var matches []scored
+	for _, bucket := range providers {
+		best, ok := 0, false
+		for _, field := range []string{bucket.Provider.DisplayName, bucket.Provider.Type, bucket.Source, translateSourceLabel(bucket.Source)} {
+			if score, matched := fuzzyScore(query, field); matched {
+				best, ok = max(best, score), true
+			}
+		}
+		// 已加载的方案匹配时，提供商也保留在列表中
+		if state, loaded := m.providerData[bucket.Provider.ID]; loaded {
+			for _, alt := range state.alternatives {
+				if score, matched := m.alternativeScore(alt.Alternative); matched {
+					best, ok = max(best, score), true
+				}
+			}
+		}
+		if ok {
+			matches = append(matches, scored{bucket, best})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	out := make([]api.ProviderBucket, len(matches))
+	for i, match := range matches {
+		out[i] = match.bucket
+	}
+	return out
+}
+
+// fuzzyScore matches query as a case-insensitive subsequence of text,
+// ignoring spaces in the query. Consecutive runs and matches at the start of
+// a word score higher.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	t := []rune(strings.ToLower(text))
+	score, qi, prev := 0, 0, -2
+	for i := 0; i < len(t) && qi < len(q); i++ {
+		if t[i] != q[qi] {
+			continue
+		}
+		score++
+		if i == prev+1 {
+			score += 2
+		}
+		if i == 0 || !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1]) {
+			score += 3
+		}
+		prev = i
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// filterNote describes the active filter for the list footer.
+func (m *Model) filterNote() string {
+	query := m.filter.query()
+	if query == "" {
+		return ""
+	}
+	total := 0
+	for _, bucket := range m.allProviders {
+		if !m.view.hidden[bucket.Provider.ID] {
+			total++
+		}
+	}
+	return i18n.Tf("筛选“%s”：提供商 %d/%d（/ 修改 · Esc 清除）", query, len(m.providers), total)
+}
+
+// visibleAlternatives returns the indices of the alternatives matching the
+// filter, in list order. The selected alternative always stays visible so
+// its marker is not lost while filtering. When the provider matched by its
+// own name and none of its alternatives do, all of them are shown.
+func (m *Model) visibleAlternatives(state *providerState) []int {
+	if m.alternativeMatches(state) == 0 {
+		return allIndices(len(state.alternatives))
+	}
+	visible := make([]int, 0, len(state.alternatives))
+	for i, alt := range state.alternatives {
+		selected := state.selection != nil && state.selection.SelectedAlternativeID == alt.Alternative.ID
+		if _, matched := m.alternativeScore(alt.Alternative); selected || matched {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+func allIndices(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+// alternativeScore matches the filter query against an alternative's name
+// and type.
+func (m *Model) alternativeScore(alt api.ProviderAlternative) (int, bool) {
+	query := m.filter.query()
+	nameScore, nameOK := fuzzyScore(query, alt.DisplayName)
+	typeScore, typeOK := fuzzyScore(query, alt.Type)
+	return max(nameScore, typeScore), nameOK || typeOK
+}
+
+// alternativeMatches counts the alternatives of state matching the filter.
+func (m *Model) alternativeMatches(state *providerState) int {
+	matched := 0
+	for _, alt := range state.alternatives {
+		if _, ok := m.alternativeScore(alt.Alternative); ok {
+			matched++
+		}
+	}
+	return matched
+}
+
+// altFilterNote describes the filter for the alternatives panel footer.
+func (m *Model) altFilterNote(state *providerState) []string {
+	query := m.filter.query()
+	if query == "" {
+		return nil
+	}
+	note := i18n.Tf("筛选“%s”：方案 %d/%d", query, m.alternativeMatches(state), len(state.alternatives))
+	if m.alternativeMatches(state) == 0 {
+		note = i18n.Tf("筛选“%s”：没有匹配的方案，显示全部 %d 个", query, len(state.alternatives))
+	}
+	return []string{"", helpStyle.Render(note)}
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/i18n"
+)
+
+// focusMarker labels the focused panel so focus doesn't rely on color alone.
+const focusMarker = "[焦点]"
+
+// focusIndicators controls how the focused panel is marked besides its border color.
+type focusIndicators struct {
+	marker  bool // show focusMarker above the focused panel's list
+	inverse bool // render the focused panel's cursor row in inverse video
+}
+
+// WithFocusIndicators configures the textual focus marker and inverse-video cursor row.
+func WithFocusIndicators(marker, inverse bool) Option {
+	return func(m *Model) {
+		m.focusIndicators = focusIndicators{marker: marker, inverse: inverse}
+	}
+}
+
+// renderPanel draws a bordered panel, highlighting it when area has focus.
+// The marker row replaces the top padding, so list offsets stay the same.
+// Lines beyond the panel height scroll with the cursor line.
+func (m *Model) renderPanel(area focusArea, lines []string, cursor int) string {
+	focused := m.focus == area
+	header := ""
+	if focused && m.focusIndicators.marker {
+		header = lipgloss.NewStyle().Bold(true).Foreground(primaryColor).Render(i18n.T(focusMarker))
+	}
+	content := header + "\n" + strings.Join(m.scrollPanel(area, lines, cursor), "\n")
+
+	style := panelStyle.Copy().PaddingTop(0)
+	if focused {
+		style = style.BorderStyle(activeBorder).BorderForeground(primaryColor)
+	}
+	return style.Width(m.panelWidth(area)).Height(m.panelHeight()).Render(content)
+}
+
+// focusRegions lists the interactive regions of the current tab in Tab order.
+func (m *Model) focusRegions() []focusArea {
+	switch m.currentTab {
+	case tabProviders:
+		return []focusArea{focusProviders, focusAlternatives}
+	case tabBalancePreference:
+		return []focusArea{focusPreference}
+	case tabStats:
+		return []focusArea{focusChart}
+	}
+	return []focusArea{focusProfile}
+}
+
+// cycleFocus moves focus to the next (delta 1) or previous (-1) region.
+// Past either end it continues on the neighbouring tab, so Tab still walks
+// through every tab.
+func (m *Model) cycleFocus(delta int) tea.Cmd {
+	regions := m.focusRegions()
+	next := max(slices.Index(regions, m.focus), 0) + delta
+	if next >= 0 && next < len(regions) {
+		return m.focusRegion(regions[next])
+	}
+	if delta > 0 {
+		return m.switchToNextTab()
+	}
+	cmd := m.switchToPrevTab()
+	regions = m.focusRegions()
+	return tea.Batch(cmd, m.focusRegion(regions[len(regions)-1]))
+}
+
+// focusRegion moves focus to area, loading what the region needs.
+func (m *Model) focusRegion(area focusArea) tea.Cmd {
+	if area == focusAlternatives {
+		return m.openAlternatives()
+	}
+	m.focus = area
+	return nil
+}
+
+// cursorStyle returns style, in inverse video when it renders the cursor row
+// of the focused panel and inverse indicators are enabled.
+func (m *Model) cursorStyle(area focusArea, style lipgloss.Style) lipgloss.Style {
+	if m.focus == area && m.focusIndicators.inverse {
+		return style.Copy().Reverse(true)
+	}
+	return style
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/i18n"
+)
+
+// providerRequirement returns what the account lacks to use a provider billed
+// from source, or "" when it is usable. Nothing is reported before the
+// provider list (and with it the account flags) has loaded.
+func (m *Model) providerRequirement(source string) string {
+	if m.providerFlags == nil {
+		return ""
+	}
+	switch source {
+	case "pay_as_you_go", "payg":
+		if !m.providerFlags.HasPaygBalance {
+			return i18n.T("需要按需余额")
+		}
+	case "subscription":
+		if !m.providerFlags.HasSubscription {
+			return i18n.T("需要订阅")
+		}
+	}
+	return ""
+}
+
+// formatRequirement renders the unusable badge of a provider row.
+func formatRequirement(requirement string) string {
+	if requirement == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(warningColor).Render(" [" + requirement + "]")
+}
+
+// preferenceNotes explains how a balance preference behaves for this account,
+// given whether it has a subscription and PAYG balance.
+func (m *Model) preferenceNotes(preference string) []string {
+	flags := m.providerFlags
+	if flags == nil {
+		return nil
+	}
+	warnStyle := lipgloss.NewStyle().Foreground(warningColor)
+	switch {
+	case preference == "subscription_first" && !flags.HasSubscription && !flags.HasPaygBalance:
+		return []string{warnStyle.Render(i18n.T("    当前没有订阅和按需余额，请求将无法计费"))}
+	case preference == "subscription_first" && !flags.HasSubscription:
+		return []string{warnStyle.Render(i18n.T("    当前没有订阅，将直接使用按需余额"))}
+	case preference == "payg_only" && !flags.HasPaygBalance:
+		return []string{warnStyle.Render(i18n.T("    当前没有按需余额，选择后请求将失败，请先充值"))}
+	}
+	return nil
+}
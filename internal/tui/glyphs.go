@@ -0,0 +1,112 @@
+package tui
+
+import "fmt"
+
+// Icon set names accepted by ApplyIcons.
+const (
+	IconsUnicode = "unicode"
+	IconsNerd    = "nerd"
+	IconsASCII   = "ascii"
+)
+
+// glyphSet centralizes every symbol drawn by the interface.
+type glyphSet struct {
+	Title    string
+	Cursor   string
+	Check    string
+	Bullet   string
+	Balance  string
+	Provider string // empty: Swatch is drawn before provider names instead
+	Swatch   string
+	Warning  string
+	More     string
+	Ellipsis string
+
+	// 统计图表
+	Bars       []string // nine levels, from empty to full
+	AxisTick   string
+	AxisLine   string
+	AxisCorner string
+	AxisRule   string
+	NoSample   string
+	Heat       []string // five levels, from no spend to the busiest day
+}
+
+var glyphSets = map[string]glyphSet{
+	IconsUnicode: {
+		Title:      "◆",
+		Cursor:     "▶",
+		Check:      "✓",
+		Bullet:     "●",
+		Balance:    "●",
+		Swatch:     "■",
+		Warning:    "⚠",
+		More:       "▼",
+		Ellipsis:   "…",
+		Bars:       []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		AxisTick:   "┤",
+		AxisLine:   "│",
+		AxisCorner: "└",
+		AxisRule:   "─",
+		NoSample:   "·",
+		Heat:       []string{"·", "░", "▒", "▓", "█"},
+	},
+	IconsNerd: {
+		Title:      "\uf489", // nf-oct-terminal
+		Cursor:     "\uf054", // nf-fa-chevron_right
+		Check:      "\uf00c", // nf-fa-check
+		Bullet:     "\uf111", // nf-fa-circle
+		Balance:    "\uf155", // nf-fa-dollar
+		Provider:   "\uf233", // nf-fa-server
+		Swatch:     "\uf0c8", // nf-fa-square
+		Warning:    "\uf071", // nf-fa-warning
+		More:       "\uf078", // nf-fa-chevron_down
+		Ellipsis:   "…",
+		Bars:       []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		AxisTick:   "┤",
+		AxisLine:   "│",
+		AxisCorner: "└",
+		AxisRule:   "─",
+		NoSample:   "·",
+		Heat:       []string{"·", "░", "▒", "▓", "█"},
+	},
+	IconsASCII: {
+		Title:      "*",
+		Cursor:     ">",
+		Check:      "[x]",
+		Bullet:     "-",
+		Balance:    "-",
+		Swatch:     "#",
+		Warning:    "!",
+		More:       "v",
+		Ellipsis:   "~",
+		Bars:       []string{" ", ".", ".", ":", ":", "|", "|", "#", "#"},
+		AxisTick:   "+",
+		AxisLine:   "|",
+		AxisCorner: "+",
+		AxisRule:   "-",
+		NoSample:   ".",
+		Heat:       []string{".", "-", "+", "*", "#"},
+	},
+}
+
+// glyphs is the active icon set.
+var glyphs = glyphSets[IconsUnicode]
+
+// ApplyIcons selects the icon set used by every view. It must be called before NewModel.
+func ApplyIcons(name string) error {
+	set, ok := glyphSets[name]
+	if !ok {
+		return fmt.Errorf("unknown icon set %q", name)
+	}
+	glyphs = set
+	return nil
+}
+
+// cursorPrefix returns the list prefix for a row, marking the cursor row.
+func cursorPrefix(selected bool) string {
+	if selected {
+		return glyphs.Cursor + " "
+	}
+	return "  "
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+// openGoto shows the ":" prompt for jumping to a provider.
+func (m *Model) openGoto() {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.Placeholder = i18n.T("提供商 ID 或序号")
+	ti.CharLimit = 8
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.Focus()
+	m.gotoInput = &ti
+}
+
+// handleGotoKey handles keys while the goto prompt is open.
+func (m *Model) handleGotoKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.gotoInput = nil
+		return nil
+	case "enter":
+		target := strings.TrimSpace(m.gotoInput.Value())
+		m.gotoInput = nil
+		if target == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(target, "#"))
+		if err != nil {
+			m.status = i18n.Tf("无效的提供商编号：%s", target)
+			return clearStatusAfter(errorClearDelay)
+		}
+		m.pendingGoto = n
+		cmd := m.switchTab(tabProviders)
+		if !m.providersLoaded {
+			// 提供商列表加载完成后再跳转
+			return cmd
+		}
+		return tea.Batch(cmd, m.applyGoto())
+	}
+
+	var cmd tea.Cmd
+	*m.gotoInput, cmd = m.gotoInput.Update(msg)
+	return cmd
+}
+
+// applyGoto jumps to the pending target, matching a provider ID first and a
+// 1-based list position second. An ID that isn't among the loaded providers
+// may be on a later page, so pages are loaded until it turns up or the list
+// ends before positions are considered.
+func (m *Model) applyGoto() tea.Cmd {
+	n := m.pendingGoto
+	if n == 0 {
+		return nil
+	}
+
+	row := -1
+	for i, bucket := range m.providers {
+		if bucket.Provider.ID == n {
+			row = i
+			break
+		}
+	}
+	loaded := slices.ContainsFunc(m.allProviders, func(b api.ProviderBucket) bool {
+		return b.Provider.ID == n
+	})
+	if row < 0 && !loaded && m.providersNext != nil {
+		if m.loadingMoreProviders {
+			// 正在加载的页到达后再找
+			return nil
+		}
+		m.status = i18n.Tf("在后续页中查找提供商 %d...", n)
+		return m.loadNextProvidersPage()
+	}
+	m.pendingGoto = 0
+	if row < 0 && loaded {
+		m.status = i18n.Tf("提供商 %d 已隐藏或不在当前筛选结果中", n)
+		return clearStatusAfter(errorClearDelay)
+	}
+	if row < 0 && n >= 1 && n <= len(m.providers) {
+		row = n - 1
+	}
+	if row < 0 {
+		m.status = i18n.Tf("未找到 ID 或序号为 %d 的提供商", n)
+		return clearStatusAfter(errorClearDelay)
+	}
+	return m.selectProvider(row)
+}
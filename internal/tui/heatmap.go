@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+const (
+	// heatmapWeeks is how many weeks the heatmap covers, this week included.
+	heatmapWeeks = 12
+	// heatmapLabelWidth fits the weekday labels in front of the rows.
+	heatmapLabelWidth = 5
+	heatmapCellWidth  = 2 // cell + gap
+	// heatmapGap separates the heatmap from the bar chart beside it.
+	heatmapGap = 4
+)
+
+// heatmapDays is the number of days from the first day of the week
+// heatmapWeeks-1 weeks ago through today, so each column is one full week
+// starting on Sunday and the last one ends today.
+func heatmapDays(now time.Time) int {
+	return (heatmapWeeks-1)*7 + int(now.Weekday()) + 1
+}
+
+// heatLevel maps amount to one of the len(glyphs.Heat) levels relative to
+// the busiest day; any spend at all is at least level 1.
+func heatLevel(amount, peak float64) int {
+	top := len(glyphs.Heat) - 1
+	if amount <= 0 || peak <= 0 {
+		return 0
+	}
+	return max(1, min(top, int(amount/peak*float64(top)+0.999)))
+}
+
+// renderHeatmap draws a GitHub-style grid of daily spend: one column per
+// week, one row per weekday. Days without samples are left blank.
+func (m *Model) renderHeatmap() []string {
+	if len(m.heatDays) == 0 {
+		return nil
+	}
+	peak := 0.0
+	for _, day := range m.heatDays {
+		peak = max(peak, day.Amount)
+	}
+	var selected time.Time
+	if len(m.spendDays) > 0 {
+		selected = m.spendDays[clampIndex(m.statsIdx, len(m.spendDays))].Date
+	}
+
+	cellStyle := lipgloss.NewStyle().Foreground(primaryColor)
+	zeroStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	cursorStyle := lipgloss.NewStyle().Foreground(accentColor)
+
+	lines := []string{titleStyle.Render(i18n.Tf("近 %d 周消费热力图", heatmapWeeks))}
+	for weekday := range 7 {
+		var b strings.Builder
+		label := ""
+		if weekday%2 == 1 {
+			// 与 GitHub 一样只标出周一、周三、周五
+			label = i18n.T(weekdayNames[weekday])
+		}
+		b.WriteString(helpStyle.Render(padRight(label, heatmapLabelWidth)))
+		for i := weekday; i < len(m.heatDays); i += 7 {
+			day := m.heatDays[i]
+			level := heatLevel(day.Amount, peak)
+			cell, style := glyphs.Heat[level], cellStyle
+			switch {
+			case !day.Known():
+				cell = " "
+			case level == 0:
+				style = zeroStyle
+			}
+			if day.Date.Equal(selected) {
+				style = cursorStyle
+				if cell == " " {
+					cell = glyphs.NoSample
+				}
+			}
+			b.WriteString(style.Render(cell) + " ")
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+
+	legend := make([]string, len(glyphs.Heat))
+	for i, g := range glyphs.Heat {
+		legend[i] = cellStyle.Render(g)
+	}
+	legend[0] = zeroStyle.Render(glyphs.Heat[0])
+	first := m.heatDays[0].Date
+	lines = append(lines,
+		helpStyle.Render(padRight("", heatmapLabelWidth)+format.ShortDate(first)+i18n.T(" 起，每列一周")),
+		helpStyle.Render(padRight("", heatmapLabelWidth)+i18n.T("少 "))+strings.Join(legend, " ")+helpStyle.Render(i18n.T(" 多 · 空白为未采样")),
+	)
+	return lines
+}
+
+// heatmapWidth is the width of the heatmap grid in cells.
+func heatmapWidth() int {
+	return heatmapLabelWidth + heatmapWeeks*heatmapCellWidth
+}
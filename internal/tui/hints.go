@@ -0,0 +1,112 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// hintAlphabet supplies hint labels, home row first.
+const hintAlphabet = "asdfghjklqwertyuiopzxcvbnm"
+
+type hintKind int
+
+const (
+	hintTab hintKind = iota
+	hintProvider
+	hintAlternative
+	hintPreference
+)
+
+// hintTarget is an actionable element that can receive a hint label.
+type hintTarget struct {
+	kind  hintKind
+	index int
+}
+
+// openHints enters hint mode, labeling every actionable element on screen.
+func (m *Model) openHints() {
+	m.showHints = true
+}
+
+// hintTargets lists the actionable elements of the current view in label order.
+func (m *Model) hintTargets() []hintTarget {
+	var targets []hintTarget
+	for i := range tabTitles {
+		targets = append(targets, hintTarget{hintTab, i})
+	}
+	switch m.currentTab {
+	case tabProviders:
+		for i := range m.providers {
+			targets = append(targets, hintTarget{hintProvider, i})
+		}
+		state := m.ensureProviderState(m.currentProviderID())
+		if state.alternativesLoaded && !state.loadingAlternatives {
+			for _, i := range m.visibleAlternatives(state) {
+				targets = append(targets, hintTarget{hintAlternative, i})
+			}
+		}
+	case tabBalancePreference:
+		if m.profile != nil {
+			targets = append(targets, hintTarget{hintPreference, 0}, hintTarget{hintPreference, 1})
+		}
+	}
+	if len(targets) > len(hintAlphabet) {
+		targets = targets[:len(hintAlphabet)]
+	}
+	return targets
+}
+
+// hintLabel returns the label of an element, or "" outside hint mode.
+func (m *Model) hintLabel(kind hintKind, index int) string {
+	if !m.showHints {
+		return ""
+	}
+	for i, t := range m.hintTargets() {
+		if t.kind == kind && t.index == index {
+			return string(hintAlphabet[i])
+		}
+	}
+	return ""
+}
+
+// renderHint draws a hint label padded to the two-cell row prefix.
+func renderHint(label string) string {
+	style := lipgloss.NewStyle().Bold(true).Foreground(onPrimaryColor).Background(accentColor)
+	return style.Render(label) + " "
+}
+
+// rowPrefix returns the row's hint label in hint mode, otherwise its cursor prefix.
+func (m *Model) rowPrefix(kind hintKind, index int, selected bool) string {
+	if label := m.hintLabel(kind, index); label != "" {
+		return renderHint(label)
+	}
+	return cursorPrefix(selected)
+}
+
+// handleHintKey activates the labeled element; any other key leaves hint mode.
+func (m *Model) handleHintKey(key string) tea.Cmd {
+	m.showHints = false
+	for i, t := range m.hintTargets() {
+		if key == string(hintAlphabet[i]) {
+			return m.activateHint(t)
+		}
+	}
+	return nil
+}
+
+func (m *Model) activateHint(t hintTarget) tea.Cmd {
+	switch t.kind {
+	case hintTab:
+		return m.switchTab(tabIndex(t.index))
+	case hintProvider:
+		return m.selectProvider(t.index)
+	case hintAlternative:
+		m.focus = focusAlternatives
+		m.altIdx = t.index
+		return m.switchSelection()
+	case hintPreference:
+		m.balancePreferenceIdx = t.index
+		return m.toggleBalancePreference()
+	}
+	return nil
+}
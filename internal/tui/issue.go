@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+
+	"yescode-tui/internal/i18n"
+)
+
+// newIssueURL is where bug reports are filed.
+const newIssueURL = "https://github.com/kywrl/yescode-tui/issues/new"
+
+type issueOpenedMsg struct {
+	link string
+	err  error
+}
+
+// issueLink pre-fills a GitHub issue with the environment and the most
+// recent error, leaving the description to the reporter.
+func (m *Model) issueLink() string {
+	title := "[bug] "
+	var body strings.Builder
+	body.WriteString("## 问题描述\n\n<!-- 请描述遇到的问题以及复现步骤 -->\n\n## 环境\n\n")
+	for _, env := range m.environmentLines() {
+		fmt.Fprintf(&body, "- %s\n", env)
+	}
+
+	if errs := m.diagnostics().RecentErrors(); len(errs) > 0 {
+		e := errs[len(errs)-1]
+		title += truncate(fmt.Sprintf("%s %s 失败（%s）：%s", e.Method, e.Endpoint, describeStatus(e.StatusCode), e.Message), 80)
+		fmt.Fprintf(&body, "\n## 最近错误\n\n```\n%s %s %s [%s] %s\n```\n",
+			e.Time.Format("2006-01-02 15:04:05"), e.Method, e.Endpoint, describeStatus(e.StatusCode), e.Message)
+	}
+	body.WriteString("\n<!-- 完整的诊断包可在诊断信息（Ctrl+D）中按 c 复制后粘贴到这里 -->\n")
+
+	query := url.Values{}
+	query.Set("title", title)
+	query.Set("body", body.String())
+	return newIssueURL + "?" + query.Encode()
+}
+
+// reportIssue opens the pre-filled issue in the browser.
+func (m *Model) reportIssue() tea.Cmd {
+	link := m.issueLink()
+	return func() tea.Msg {
+		return issueOpenedMsg{link: link, err: openBrowser(link)}
+	}
+}
+
+// handleIssueOpened falls back to the clipboard when no browser could be
+// started, e.g. over SSH.
+func (m *Model) handleIssueOpened(msg issueOpenedMsg) {
+	if msg.err != nil {
+		termenv.Copy(msg.link)
+		m.diagnosticsNote = i18n.Tf("无法打开浏览器（%v），链接已复制到剪贴板", msg.err)
+		return
+	}
+	m.diagnosticsNote = i18n.T("已在浏览器中打开新 issue")
+}
+
+func openBrowser(link string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", link)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", link)
+	default:
+		cmd = exec.Command("xdg-open", link)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"yescode-tui/internal/i18n"
+)
+
+// The screen stacks the chrome, the content area and the status bar. The
+// content area takes every row the window leaves, so tall terminals show
+// longer lists and short ones keep the status bar on screen.
+const (
+	// fallbackContentRows sizes the content area before the first
+	// WindowSizeMsg arrives.
+	fallbackContentRows = 21
+	// minContentRows keeps the panels usable on very short terminals.
+	minContentRows = 5
+	// statusRows is the blank line and the status bar below the content.
+	statusRows = 2
+)
+
+// contentRows returns the height of the area between the chrome and the
+// status bar.
+func (m *Model) contentRows() int {
+	if m.height <= 0 {
+		return fallbackContentRows
+	}
+	return max(m.height-m.uiLayout().contentStartY-statusRows, minContentRows)
+}
+
+// contentHeight returns the profile viewport height: the content area less
+// the scroll indicator row.
+func (m *Model) contentHeight() int {
+	return m.contentRows() - 1
+}
+
+// panelHeight returns the height of the provider panels inside their borders.
+func (m *Model) panelHeight() int {
+	return m.contentRows() - 2
+}
+
+// fillContent pads content to the height of the content area, keeping the
+// status bar on the bottom row when a tab has little to show.
+func (m *Model) fillContent(content string) string {
+	if m.height <= 0 {
+		return content
+	}
+	if missing := m.contentRows() - lipgloss.Height(content); missing > 0 {
+		content += strings.Repeat("\n", missing)
+	}
+	return content
+}
+
+// softWrap wraps every line of content wider than width, breaking at spaces
+// where it can and inside words where it must. Continuation lines keep the
+// leading spaces of the line they continue, so indented fields stay aligned.
+func softWrap(content string, width int) string {
+	if width <= 0 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if ansi.StringWidth(line) <= width {
+			out = append(out, line)
+			continue
+		}
+		body := strings.TrimLeft(line, " ")
+		indent := len(line) - len(body)
+		if indent*2 > width {
+			// 缩进占去太多宽度时不再保留缩进
+			body, indent = line, 0
+		}
+		pad := strings.Repeat(" ", indent)
+		for _, part := range strings.Split(ansi.Wrap(body, width-indent, ""), "\n") {
+			out = append(out, pad+part)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// scrollPanel returns the lines of a panel that fit its height, scrolled so
+// the cursor line stays visible; cursor is -1 when the panel has no cursor.
+// The offset is kept between renders, so the list only scrolls once the
+// cursor leaves the window.
+func (m *Model) scrollPanel(area focusArea, lines []string, cursor int) []string {
+	// 焦点标记行和底部内边距之外的行留给列表
+	rows := max(m.panelHeight()-2, 1)
+	offset := &m.panelScroll[area]
+	if len(lines) <= rows {
+		*offset = 0
+		return lines
+	}
+	// 最后一行留给“更多”提示
+	window := max(rows-1, 1)
+	if cursor >= 0 {
+		if cursor < *offset {
+			*offset = cursor
+		}
+		if cursor >= *offset+window {
+			*offset = cursor - window + 1
+		}
+	}
+	*offset = min(*offset, len(lines)-window)
+
+	out := append([]string(nil), lines[*offset:*offset+window]...)
+	if below := len(lines) - *offset - window; below > 0 {
+		out = append(out, helpStyle.Render(glyphs.More+i18n.Tf(" 还有 %d 行", below)))
+	}
+	return out
+}
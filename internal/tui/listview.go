@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+const (
+	defaultSplitPercent = 50
+	minSplitPercent     = 30
+	maxSplitPercent     = 70
+	splitStep           = 5
+)
+
+// listView holds the local preferences that shape the providers tab. Every
+// change goes through the undo stack.
+type listView struct {
+	sortByName   bool
+	hidden       map[int]bool // hidden provider IDs
+	splitPercent int          // left panel share of the width
+}
+
+// withHidden returns a copy of v with the provider's hidden flag set.
+func (v listView) withHidden(providerID int, hidden bool) listView {
+	next := make(map[int]bool, len(v.hidden)+1)
+	for id := range v.hidden {
+		next[id] = true
+	}
+	if hidden {
+		next[providerID] = true
+	} else {
+		delete(next, providerID)
+	}
+	v.hidden = next
+	return v
+}
+
+// rebuildProviders derives the visible provider list from the API response
+// and the list view, keeping the cursor on the same provider when possible.
+// It reports whether the current provider changed.
+func (m *Model) rebuildProviders() bool {
+	currentID := m.currentProviderID()
+	visible := make([]api.ProviderBucket, 0, len(m.allProviders))
+	for _, bucket := range m.allProviders {
+		if !m.view.hidden[bucket.Provider.ID] {
+			visible = append(visible, bucket)
+		}
+	}
+	if m.view.sortByName {
+		sort.SliceStable(visible, func(i, j int) bool {
+			return strings.ToLower(visible[i].Provider.DisplayName) < strings.ToLower(visible[j].Provider.DisplayName)
+		})
+	}
+	// 固定顺序优先于名称排序
+	orderProviders(visible, m.providerOrder)
+	visible = m.filterProviders(visible)
+
+	m.providers = visible
+	m.providerIdx = clampIndex(m.providerIdx, len(visible))
+	for i, bucket := range visible {
+		if bucket.Provider.ID == currentID {
+			m.providerIdx = i
+		}
+	}
+	return m.currentProviderID() != currentID
+}
+
+// setListView applies v and loads the details of a newly current provider.
+func (m *Model) setListView(v listView) tea.Cmd {
+	m.view = v
+	if !m.rebuildProviders() || len(m.providers) == 0 {
+		return nil
+	}
+	m.syncAltIdx(m.currentProviderID())
+	if !m.loadProviderDetailsOnMove() {
+		return nil
+	}
+	return m.queueProviderDetailLoad(m.currentProviderID())
+}
+
+// changeListView switches to next as an undoable command.
+func (m *Model) changeListView(label string, next listView) tea.Cmd {
+	prev := m.view
+	return m.execute(uiCommand{
+		label:  label,
+		apply:  func(m *Model) tea.Cmd { return m.setListView(next) },
+		revert: func(m *Model) tea.Cmd { return m.setListView(prev) },
+	})
+}
+
+// handleListViewKey handles undo/redo and the providers tab view keys.
+func (m *Model) handleListViewKey(key string) tea.Cmd {
+	switch key {
+	case "ctrl+z":
+		return m.undoUI()
+	case "ctrl+y":
+		return m.redoUI()
+	}
+	if m.currentTab != tabProviders {
+		return nil
+	}
+
+	switch key {
+	case "s":
+		next := m.view
+		next.sortByName = !next.sortByName
+		label := i18n.T("按名称排序")
+		if !next.sortByName {
+			label = i18n.T("恢复默认排序")
+		}
+		return m.changeListView(label, next)
+	case "x":
+		if len(m.providers) == 0 {
+			return nil
+		}
+		if len(m.providers) == 1 {
+			m.status = i18n.T("至少保留一个提供商")
+			return clearStatusAfter(statusClearDelay)
+		}
+		id := m.currentProviderID()
+		label := i18n.Tf("隐藏 %s", m.providerDisplayName(id))
+		return m.changeListView(label, m.view.withHidden(id, true))
+	case "X":
+		if len(m.view.hidden) == 0 {
+			return nil
+		}
+		next := m.view
+		next.hidden = nil
+		return m.changeListView(i18n.Tf("显示 %d 个已隐藏的提供商", len(m.view.hidden)), next)
+	case "<", ">":
+		next := m.view
+		if key == "<" {
+			next.splitPercent -= splitStep
+		} else {
+			next.splitPercent += splitStep
+		}
+		if next.splitPercent < minSplitPercent || next.splitPercent > maxSplitPercent {
+			return nil
+		}
+		return m.changeListView(i18n.Tf("面板比例 %d:%d", next.splitPercent, 100-next.splitPercent), next)
+	}
+	return nil
+}
+
+// listViewFooter describes the active list preferences under the provider list.
+func (m *Model) listViewFooter() []string {
+	var notes []string
+	if note := m.filterNote(); note != "" {
+		notes = append(notes, note)
+	}
+	if m.view.sortByName {
+		notes = append(notes, i18n.T("按名称排序"))
+	}
+	if n := len(m.view.hidden); n > 0 {
+		notes = append(notes, i18n.Tf("已隐藏 %d 个（X 全部显示）", n))
+	}
+	if len(notes) == 0 {
+		return nil
+	}
+	return []string{"", helpStyle.Render(strings.Join(notes, " · "))}
+}
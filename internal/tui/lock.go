@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/i18n"
+)
+
+// idleLock blanks the screen after a period without input, so balances and
+// the account email don't stay on an unattended terminal. Refreshing pauses
+// while locked.
+type idleLock struct {
+	after        time.Duration // 0 disables the lock
+	pin          string        // required to unlock when set
+	lastActivity time.Time
+	locked       bool
+	input        textinput.Model
+	wrongPIN     bool
+}
+
+// idleCheckMsg asks whether the terminal has been idle long enough to lock.
+type idleCheckMsg struct{}
+
+// WithIdleLock locks the screen after d without keyboard or mouse input.
+// With a non-empty pin, unlocking requires typing it; otherwise any key
+// resumes.
+func WithIdleLock(d time.Duration, pin string) Option {
+	return func(m *Model) {
+		m.lock = idleLock{after: d, pin: pin, lastActivity: time.Now()}
+	}
+}
+
+func idleCheckAfter(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return idleCheckMsg{} })
+}
+
+// startIdleCheck schedules the first idle check, if the lock is enabled.
+func (m *Model) startIdleCheck() tea.Cmd {
+	if m.lock.after <= 0 {
+		return nil
+	}
+	return idleCheckAfter(m.lock.after)
+}
+
+// handleIdleCheck locks the screen once the idle period has passed, or
+// checks again when it will.
+func (m *Model) handleIdleCheck() tea.Cmd {
+	if m.lock.locked {
+		return nil
+	}
+	idle := time.Since(m.lock.lastActivity)
+	if idle < m.lock.after {
+		return idleCheckAfter(m.lock.after - idle)
+	}
+	m.lock.locked = true
+	m.lock.wrongPIN = false
+	if m.lock.pin != "" {
+		ti := textinput.New()
+		ti.Prompt = i18n.T("PIN：")
+		ti.EchoMode = textinput.EchoPassword
+		ti.CharLimit = 32
+		ti.Focus()
+		m.lock.input = ti
+	}
+	return nil
+}
+
+// noteActivity records user input for the idle timer.
+func (m *Model) noteActivity() {
+	m.lock.lastActivity = time.Now()
+}
+
+// handleLockKey unlocks with any key, or with Enter after the PIN when one
+// is configured. The key is consumed either way.
+func (m *Model) handleLockKey(msg tea.KeyMsg) tea.Cmd {
+	if m.lock.pin == "" {
+		return m.unlock()
+	}
+	switch msg.String() {
+	case "enter":
+		if subtle.ConstantTimeCompare([]byte(m.lock.input.Value()), []byte(m.lock.pin)) == 1 {
+			return m.unlock()
+		}
+		m.lock.wrongPIN = true
+		m.lock.input.Reset()
+		return nil
+	case "esc":
+		m.lock.input.Reset()
+		return nil
+	}
+	var cmd tea.Cmd
+	m.lock.input, cmd = m.lock.input.Update(msg)
+	return cmd
+}
+
+// unlock shows the interface again, reloading the profile that went stale
+// while refreshing was paused.
+func (m *Model) unlock() tea.Cmd {
+	m.lock.locked = false
+	m.noteActivity()
+	return tea.Batch(loadProfileCmd(m.ctx, m.client), idleCheckAfter(m.lock.after))
+}
+
+// renderLockScreen replaces the whole view while locked.
+func (m *Model) renderLockScreen() string {
+	lines := []string{titleStyle.Render(glyphs.Title + i18n.T(" YesCode 已锁定")), ""}
+	if m.lock.pin == "" {
+		lines = append(lines, helpStyle.Render(i18n.T("按任意键继续")))
+	} else {
+		lines = append(lines, m.lock.input.View())
+		if m.lock.wrongPIN {
+			lines = append(lines, lipgloss.NewStyle().Foreground(errorColor).Render(i18n.T("PIN 错误")))
+		}
+		lines = append(lines, "", helpStyle.Render(i18n.T("输入 PIN 后按 Enter 解锁 · Ctrl+C 退出")))
+	}
+	content := strings.Join(lines, "\n")
+	if m.width <= 0 || m.height <= 0 {
+		return content
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lowBandwidthRefreshInterval replaces profileRefreshInterval in low-bandwidth mode.
+const lowBandwidthRefreshInterval = 60 * time.Second
+
+// WithLowBandwidth slows the automatic refresh and loads provider details only
+// on demand, for metered connections.
+func WithLowBandwidth() Option {
+	return func(m *Model) {
+		m.lowBandwidth = true
+	}
+}
+
+// WithRefreshInterval overrides the automatic profile refresh interval.
+func WithRefreshInterval(d time.Duration) Option {
+	return func(m *Model) {
+		m.refreshOverride = d
+	}
+}
+
+func (m *Model) refreshInterval() time.Duration {
+	if m.refreshOverride > 0 {
+		return m.refreshOverride
+	}
+	if m.lowBandwidth {
+		return lowBandwidthRefreshInterval
+	}
+	return profileRefreshInterval
+}
+
+// loadProviderDetailsOnMove reports whether moving the provider cursor should
+// fetch that provider's details right away.
+func (m *Model) loadProviderDetailsOnMove() bool {
+	return !m.lowBandwidth
+}
+
+// openAlternatives moves focus to the alternatives panel, loading it if needed.
+func (m *Model) openAlternatives() tea.Cmd {
+	m.focus = focusAlternatives
+	// 切换到右栏时，同步游标到当前激活项
+	m.syncAltIdx(m.currentProviderID())
+	return m.queueProviderDetailLoad(m.currentProviderID())
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// modal is an open dialog. While one is open it receives every key, the
+// background ignores the mouse, and View draws it above the dimmed main view.
+// Clicking one of its buttons sends the button's key.
+type modal struct {
+	render  func() string
+	key     func(tea.KeyMsg) tea.Cmd
+	buttons func() []button // nil when the dialog has none
+}
+
+// activeModal returns the topmost open dialog, or nil. The order decides
+// which dialog is shown and handles keys when several are open; the quit
+// confirmation and the operation summary come first since they must be
+// answered.
+func (m *Model) activeModal() *modal {
+	switch {
+	case m.quitDialog:
+		return &modal{m.renderQuitDialog, m.handleQuitDialogKey, m.quitButtons}
+	case m.summary != nil:
+		return &modal{m.renderSummaryDialog, m.handleSummaryKey, m.summaryButtons}
+	case m.plan != nil:
+		return &modal{m.renderPlanDialog, m.handlePlanKey, m.planButtons}
+	case m.switcher != nil:
+		return &modal{m.renderSwitcherDialog, m.handleSwitcherKey, nil}
+	case m.showHelpDialog:
+		return &modal{m.renderHelpDialog, m.handleHelpKey, nil}
+	case m.showDiagnostics:
+		return &modal{m.renderDiagnosticsDialog, m.handleDiagnosticsKey, m.diagnosticsButtons}
+	case m.errorDetail != nil:
+		return &modal{m.renderErrorDetailDialog, m.handleErrorDetailKey, m.errorDetailButtons}
+	case m.requestLog != nil:
+		return &modal{m.renderRequestLogDialog, m.handleRequestLogKey, m.requestLogButtons}
+	case m.eventView != nil:
+		return &modal{m.renderEventLogDialog, m.handleEventLogKey, m.eventLogButtons}
+	case m.estimator != nil:
+		return &modal{m.renderEstimatorDialog, m.handleEstimatorKey, nil}
+	case m.resetAll != nil:
+		return &modal{m.renderResetAllDialog, m.handleResetAllKey, m.resetAllButtons}
+	case m.search != nil:
+		return &modal{m.renderSearchDialog, m.handleSearchKey, nil}
+	}
+	return nil
+}
+
+// handleHelpKey closes the help dialog with Esc or ?.
+func (m *Model) handleHelpKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "?", "？":
+		m.showHelpDialog = false
+	}
+	return nil
+}
+
+// handleDiagnosticsKey closes the diagnostics dialog with Esc or Ctrl+D;
+// c exports the diagnostic bundle and i files an issue.
+func (m *Model) handleDiagnosticsKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+d":
+		m.showDiagnostics = false
+		m.diagnosticsNote = ""
+	case "c":
+		return m.exportDiagnostics()
+	case "i":
+		return m.reportIssue()
+	}
+	return nil
+}
+
+// diagnosticsButtons are the actions at the bottom of the diagnostics dialog.
+func (m *Model) diagnosticsButtons() []button {
+	var buttons []button
+	if m.bundle != nil {
+		buttons = append(buttons, button{label: "导出诊断包", key: "c", kind: buttonPrimary})
+	}
+	return append(buttons, button{label: "提交 issue", key: "i"}, button{label: "关闭", key: "esc"})
+}
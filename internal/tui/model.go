@@ -3,24 +3,39 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"yescode-tui/internal/api"
+	"yescode-tui/internal/debuglog"
+	"yescode-tui/internal/eventlog"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/notify"
+	"yescode-tui/internal/peer"
+	"yescode-tui/internal/recent"
+	"yescode-tui/internal/snapshot"
 )
 
+// focusArea is an interactive region that receives navigation keys.
 type focusArea int
 
 const (
 	focusProviders focusArea = iota
 	focusAlternatives
+	focusProfile    // 用户资料滚动区域
+	focusPreference // 余额使用偏好选项
+	focusChart      // 统计图表
 )
 
 type tabIndex int
@@ -29,12 +44,20 @@ const (
 	tabProfile tabIndex = iota
 	tabProviders
 	tabBalancePreference
+	tabStats
+	tabCount
 )
 
+// tabTitles holds the header label of each tab, indexed by tabIndex.
+var tabTitles = [tabCount]string{
+	"1. 用户资料",
+	"2. 提供商",
+	"3. 余额使用偏好",
+	"4. 统计",
+}
+
 // UI layout constants
 const (
-	defaultViewportHeight  = 20
-	defaultPanelHeight     = 10
 	minPanelWidth          = 30
 	viewportWidthMargin    = 4
 	profileRefreshInterval = 5 * time.Second
@@ -52,46 +75,80 @@ type uiLayout struct {
 	panelInnerOffsetX int // X offset for panel inner content (border + padding)
 }
 
-// getUILayout calculates UI element positions based on View() structure.
-func getUILayout() uiLayout {
-	return uiLayout{
-		titleLineY:        0, // Title at Y=0
-		helpLineY:         2, // Help hint at Y=2 (title + blank line)
-		tabHeaderY:        4, // Tab header at Y=4 (title + blank + help + blank)
-		contentStartY:     6, // Content starts at Y=6 (after tab header + blank)
-		panelInnerOffsetY: 2, // Panel has 1 line border + 1 line padding
-		panelInnerOffsetX: 3, // Panel has left border (1) + left padding (2)
-	}
-}
-
 // Model wires Bubble Tea with the YesCode API client.
 type Model struct {
-	client *api.Client
-
-	profile                 *api.Profile
-	providers               []api.ProviderBucket
-	providerIdx             int
-	altIdx                  int
-	balancePreferenceIdx    int
-	focus                   focusArea
-	currentTab              tabIndex
-	ready                   bool
-	status                  string
-	err                     error
-	width                   int
-	height                  int
-	providerData            map[int]*providerState
-	preferenceSwitching     bool
-	spinner                 spinner.Model
-	help                    help.Model
-	keys                    keyMap
-	profileViewport         viewport.Model
-	providersLoaded         bool
-	loadingProviders        bool
-	loadingProfile          bool
-	manualRefreshingProfile bool
-	showHelpDialog          bool
-}
+	// accountData holds everything cached for the active account; switching
+	// accounts swaps it out.
+	*accountData
+	ctx        context.Context // 退出时取消，终止进行中的请求
+	cancel     context.CancelFunc
+	writes     *writeQueue
+	accounts   []account
+	accountIdx int
+	switcher   *switcherState
+
+	focus           focusArea
+	currentTab      tabIndex
+	ready           bool
+	status          string
+	err             error
+	width           int
+	height          int
+	spinner         spinner.Model
+	help            help.Model
+	keys            keyMap
+	profileViewport viewport.Model
+	profileCursor   profileCursor
+	showHelpDialog  bool
+	showDiagnostics bool
+	showHints       bool
+	navAccel        navAccel
+	gotoInput       *textinput.Model
+	filter          *listFilter
+	errorDetail     *errorDetailState
+	toasts          []toast
+	nextToastID     int
+	lock            idleLock
+	rateLimit       rateLimit
+	perf            perfStats
+	prefetch        prefetcher
+	tabLoads        tabLoads
+	quitArmed       bool // 已按过一次 Esc，再按 Esc 或 q 退出
+	confirmQuit     bool
+	quitDialog      bool
+	panelScroll     [2]int // 提供商和方案面板的滚动偏移
+	pendingGoto     int
+	estimator       *estimatorState
+	resetAll        *resetAllState
+	search          *searchState
+	summary         *operationSummary
+	plan            *planState
+	planFile        string
+	rollback        *snapshot.RollbackStore
+	bundle          *BundleInfo
+	diagnosticsNote string
+	recent          *recent.Store
+	peers           *peer.Board
+	debugLog        *debuglog.Log
+	requestLog      *requestLogState
+	eventLog        *eventlog.Log
+	eventView       *eventLogState
+	providerOrder   []string
+	lowBandwidth    bool
+	streamEnabled   bool
+	anomalyRatio    float64
+	refreshOverride time.Duration
+	trendWindow     time.Duration
+	focusIndicators focusIndicators
+	density         Density
+	chrome          Chrome
+
+	notifier *notify.Dispatcher
+	history  *history.Store
+}
+
+// Option configures a Model.
+type Option func(*Model)
 
 type providerState struct {
 	alternatives        []api.AlternativeOption
@@ -101,6 +158,7 @@ type providerState struct {
 	loadingAlternatives bool
 	loadingSelection    bool
 	switching           bool
+	switchTarget        string // 正在切换到的方案名称
 	lastError           error
 }
 
@@ -117,7 +175,10 @@ type keyMap struct {
 	Tab1     key.Binding
 	Tab2     key.Binding
 	Tab3     key.Binding
+	Tab4     key.Binding
 	Help     key.Binding
+	Estimate key.Binding
+	Restore  key.Binding
 	Quit     key.Binding
 }
 
@@ -127,65 +188,80 @@ func (k keyMap) ShortHelp() []key.Binding {
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Tab, k.ShiftTab, k.Tab1, k.Tab2, k.Tab3},
+		{k.Tab, k.ShiftTab, k.Tab1, k.Tab2, k.Tab3, k.Tab4},
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Enter, k.Refresh, k.Quit},
-	}
-}
-
-var keys = keyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "上移"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "下移"),
-	),
-	Left: key.NewBinding(
-		key.WithKeys("left", "h"),
-		key.WithHelp("←/h", "切换焦点"),
-	),
-	Right: key.NewBinding(
-		key.WithKeys("right", "l"),
-		key.WithHelp("→/l", "切换焦点"),
-	),
-	Tab: key.NewBinding(
-		key.WithKeys("tab"),
-		key.WithHelp("tab", "下一标签页"),
-	),
-	ShiftTab: key.NewBinding(
-		key.WithKeys("shift+tab"),
-		key.WithHelp("shift+tab", "上一标签页"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "选择"),
-	),
-	Refresh: key.NewBinding(
-		key.WithKeys("r"),
-		key.WithHelp("r", "刷新"),
-	),
-	Tab1: key.NewBinding(
-		key.WithKeys("1"),
-		key.WithHelp("1", "用户资料"),
-	),
-	Tab2: key.NewBinding(
-		key.WithKeys("2"),
-		key.WithHelp("2", "提供商"),
-	),
-	Tab3: key.NewBinding(
-		key.WithKeys("3"),
-		key.WithHelp("3", "余额使用偏好"),
-	),
-	Help: key.NewBinding(
-		key.WithKeys("?", "？"),
-		key.WithHelp("?", "帮助"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("esc", "ctrl+c"),
-		key.WithHelp("esc", "退出"),
-	),
+		{k.Enter, k.Refresh, k.Estimate, k.Restore, k.Quit},
+	}
+}
+
+// newKeyMap builds the key bindings with help text in the active language.
+func newKeyMap() keyMap {
+	return keyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", i18n.T("上移")),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", i18n.T("下移")),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", i18n.T("切换焦点")),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", i18n.T("切换焦点")),
+		),
+		Tab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", i18n.T("下一标签页")),
+		),
+		ShiftTab: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", i18n.T("上一标签页")),
+		),
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", i18n.T("选择")),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", i18n.T("刷新")),
+		),
+		Tab1: key.NewBinding(
+			key.WithKeys("1"),
+			key.WithHelp("1", i18n.T("用户资料")),
+		),
+		Tab2: key.NewBinding(
+			key.WithKeys("2"),
+			key.WithHelp("2", i18n.T("提供商")),
+		),
+		Tab3: key.NewBinding(
+			key.WithKeys("3"),
+			key.WithHelp("3", i18n.T("余额使用偏好")),
+		),
+		Tab4: key.NewBinding(
+			key.WithKeys("4"),
+			key.WithHelp("4", i18n.T("统计")),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?", "？"),
+			key.WithHelp("?", i18n.T("帮助")),
+		),
+		Estimate: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", i18n.T("费用估算")),
+		),
+		Restore: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", i18n.T("恢复默认")),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+q", "ctrl+c"),
+			key.WithHelp("q", i18n.T("退出")),
+		),
+	}
 }
 
 type profileLoadedMsg struct {
@@ -216,7 +292,8 @@ type preferenceUpdatedMsg struct {
 }
 
 type preferenceFailedMsg struct {
-	err error
+	target string
+	err    error
 }
 
 type providerLoadFailedMsg struct {
@@ -226,7 +303,8 @@ type providerLoadFailedMsg struct {
 }
 
 type errMsg struct {
-	err error
+	target string // "profile", "providers" or "providers_page"
+	err    error
 }
 
 type clearStatusMsg struct{}
@@ -234,7 +312,7 @@ type clearStatusMsg struct{}
 type profileRefreshTickMsg struct{}
 
 // NewModel constructs the root Bubble Tea model.
-func NewModel(client *api.Client) *Model {
+func NewModel(client api.Service, opts ...Option) *Model {
 	// 创建 spinner
 	s := spinner.New()
 	s.Spinner = spinner.Dot
@@ -248,55 +326,95 @@ func NewModel(client *api.Client) *Model {
 	h.Styles.FullDesc = helpStyle
 
 	// 创建 viewport
-	vp := viewport.New(0, defaultViewportHeight)
-
-	return &Model{
-		client:          client,
-		focus:           focusProviders,
-		providerData:    make(map[int]*providerState),
+	vp := viewport.New(0, fallbackContentRows-1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Model{
+		ctx:             ctx,
+		cancel:          cancel,
+		writes:          &writeQueue{pending: map[int]tea.Cmd{}},
+		accountData:     newAccountData(client),
+		focus:           focusProfile, // 启动时位于用户资料标签页
 		spinner:         s,
 		help:            h,
-		keys:            keys,
+		keys:            newKeyMap(),
 		profileViewport: vp,
 		ready:           true,
-		loadingProfile:  true,
+		focusIndicators: focusIndicators{marker: true},
+		density:         DensityCompact,
+		anomalyRatio:    DefaultAnomalyRatio,
+		chrome:          ChromeFull,
+	}
+	m.loadingProfile = true
+	m.accounts = []account{{data: m.accountData}}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // Init triggers the first batch of API calls.
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(
-		loadProfileCmd(m.client),
+	cmds := []tea.Cmd{
+		loadProfileCmd(m.ctx, m.client),
 		m.spinner.Tick,
-		profileRefreshTicker(),
-	)
+		profileRefreshTicker(m.refreshInterval()),
+		m.subscribe(),
+		m.startIdleCheck(),
+		m.startPeerPoll(),
+	}
+	if m.planFile != "" {
+		cmds = append(cmds, m.openPlanFile())
+	}
+	return m.scope(m.accountIdx, tea.Batch(cmds...))
 }
 
 // Update handles Bubble Tea messages.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.recordEvent(msg)
+	m.noteMessage(msg)
+	if msg, ok := msg.(accountMsg); ok {
+		return m, m.updateAccount(msg)
+	}
+	cmd := m.update(msg)
+	return m, m.scope(m.accountIdx, cmd)
+}
+
+func (m *Model) update(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.handleWindowResize(msg)
+	case idleCheckMsg:
+		cmds = append(cmds, m.handleIdleCheck())
 	case tea.KeyMsg:
+		m.noteActivity()
 		if cmd := m.handleKey(msg); cmd != nil {
 			cmds = append(cmds, cmd)
 		}
 	case tea.MouseMsg:
+		if m.lock.locked {
+			break
+		}
+		m.noteActivity()
 		if cmd := m.handleMouse(msg); cmd != nil {
 			cmds = append(cmds, cmd)
 		}
 	case profileLoadedMsg:
-		m.handleProfileLoaded(msg)
+		cmds = append(cmds, m.handleProfileLoaded(msg)...)
 	case profileRefreshTickMsg:
 		cmds = append(cmds, m.handleProfileRefreshTick()...)
 	case providersLoadedMsg:
 		cmds = append(cmds, m.handleProvidersLoaded(msg)...)
+	case providersPageLoadedMsg:
+		cmds = append(cmds, m.handleProvidersPageLoaded(msg)...)
+	case consistencyCheckedMsg:
+		cmds = append(cmds, m.handleConsistencyChecked(msg)...)
 	case alternativesLoadedMsg:
-		m.handleAlternativesLoaded(msg)
+		cmds = append(cmds, m.handleAlternativesLoaded(msg))
 	case selectionLoadedMsg:
-		m.handleSelectionLoaded(msg)
+		cmds = append(cmds, m.handleSelectionLoaded(msg))
 	case switchCompletedMsg:
 		cmds = append(cmds, m.handleSwitchCompleted(msg)...)
 	case preferenceUpdatedMsg:
@@ -307,8 +425,56 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.handleProviderLoadFailed(msg)...)
 	case errMsg:
 		cmds = append(cmds, m.handleError(msg)...)
+	case statsLoadedMsg:
+		cmds = append(cmds, m.handleStatsLoaded(msg)...)
+	case trendLoadedMsg:
+		m.handleTrendLoaded(msg)
+	case sampleFailedMsg:
+		cmds = append(cmds, m.handleSampleFailed(msg)...)
+	case notifyFailedMsg:
+		cmds = append(cmds, m.handleNotifyFailed(msg)...)
+	case resetProvidersLoadedMsg:
+		cmds = append(cmds, m.handleResetProvidersLoaded(msg))
+	case resetResultMsg:
+		cmds = append(cmds, m.handleResetResult(msg))
+	case planLoadedMsg:
+		m.handlePlanLoaded(msg)
+	case planStepMsg:
+		cmds = append(cmds, m.handlePlanStep(msg))
+	case bundleSavedMsg:
+		m.handleBundleSaved(msg)
+	case anomalyCheckedMsg:
+		cmds = append(cmds, m.handleAnomalyChecked(msg)...)
+	case streamOpenedMsg:
+		cmds = append(cmds, m.handleStreamOpened(msg))
+	case streamEventMsg:
+		cmds = append(cmds, m.handleStreamEvent(msg)...)
+	case streamFailedMsg:
+		cmds = append(cmds, m.handleStreamFailed(msg))
+	case streamClosedMsg:
+		cmds = append(cmds, m.handleStreamClosed())
+	case streamRetryMsg:
+		cmds = append(cmds, m.subscribe())
+	case issueOpenedMsg:
+		m.handleIssueOpened(msg)
+	case consoleOpenedMsg:
+		cmds = append(cmds, m.handleConsoleOpened(msg))
+	case rollbackFailedMsg:
+		cmds = append(cmds, m.handleRollbackFailed(msg)...)
+	case recentFailedMsg:
+		cmds = append(cmds, m.handleRecentFailed(msg)...)
 	case clearStatusMsg:
 		m.handleClearStatus()
+	case toastExpiredMsg:
+		m.handleToastExpired(msg)
+	case perfTickMsg:
+		cmds = append(cmds, m.handlePerfTick())
+	case rateLimitTickMsg:
+		cmds = append(cmds, m.handleRateLimitTick())
+	case peerTickMsg:
+		cmds = append(cmds, m.handlePeerTick())
+	case peerEventsMsg:
+		cmds = append(cmds, m.handlePeerEvents(msg))
 	}
 
 	// 更新 spinner
@@ -316,7 +482,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.spinner, cmd = m.spinner.Update(msg)
 	cmds = append(cmds, cmd)
 
-	return m, tea.Batch(cmds...)
+	return tea.Batch(cmds...)
 }
 
 // handleWindowResize updates dimensions when the window is resized.
@@ -327,29 +493,44 @@ func (m *Model) handleWindowResize(msg tea.WindowSizeMsg) {
 }
 
 // handleProfileLoaded processes successful profile load.
-func (m *Model) handleProfileLoaded(msg profileLoadedMsg) {
+func (m *Model) handleProfileLoaded(msg profileLoadedMsg) []tea.Cmd {
 	m.profile = msg.profile
+	m.profileUpdated = time.Now()
+	m.profileErr = nil
 	m.loadingProfile = false
 	m.manualRefreshingProfile = false
 	m.status = ""
+	cmds := m.evaluateAlerts()
+	cmds = append(cmds, m.checkConsistency(), m.recordTrend(msg.profile))
+	if m.history != nil {
+		cmds = append(cmds, m.persist(recordSampleCmd(m.history, msg.profile)), m.checkAnomaly())
+		if m.currentTab == tabStats && m.spendDays == nil {
+			cmds = append(cmds, m.loadStats())
+		}
+	}
+	return cmds
 }
 
 // handleProfileRefreshTick handles periodic profile refresh.
 func (m *Model) handleProfileRefreshTick() []tea.Cmd {
 	var cmds []tea.Cmd
-	// 只在profile tab时自动刷新（不显示loading）
-	if m.currentTab == tabProfile {
-		cmds = append(cmds, loadProfileCmd(m.client))
+	// 只在profile tab时自动刷新（不显示loading），锁屏、被限流或由事件流推送时暂停
+	if m.currentTab == tabProfile && !m.lock.locked && !m.rateLimited() && !m.stream.connected {
+		cmds = append(cmds, loadProfileCmd(m.ctx, m.client))
 	}
 	// 继续下一个tick
-	cmds = append(cmds, profileRefreshTicker())
+	cmds = append(cmds, profileRefreshTicker(m.refreshInterval()))
 	return cmds
 }
 
 // handleProvidersLoaded processes provider list load.
 func (m *Model) handleProvidersLoaded(msg providersLoadedMsg) []tea.Cmd {
 	var cmds []tea.Cmd
-	m.providers = msg.response.Providers
+	m.providerFlags = msg.response
+	m.providersErr = nil
+	m.allProviders = msg.response.Providers
+	m.setProvidersNext(msg.response, api.Page{})
+	m.rebuildProviders()
 	m.providersLoaded = true
 	m.loadingProviders = false
 
@@ -358,18 +539,19 @@ func (m *Model) handleProvidersLoaded(msg providersLoadedMsg) []tea.Cmd {
 	}
 
 	// 立即清除加载状态消息
-	if strings.Contains(m.status, "加载提供商列表中") {
+	if strings.Contains(m.status, i18n.T("加载提供商列表中")) {
 		m.status = ""
 	}
 
-	if len(m.providers) > 0 {
-		cmds = append(cmds, m.queueProviderDetailLoad(m.currentProviderID()))
+	if len(m.providers) > 0 && m.loadProviderDetailsOnMove() {
+		cmds = append(cmds, m.queueProviderDetailLoad(m.currentProviderID()), m.startPrefetch())
 	}
+	cmds = append(cmds, m.applyGoto(), m.checkConsistency())
 	return cmds
 }
 
 // handleAlternativesLoaded processes alternatives load.
-func (m *Model) handleAlternativesLoaded(msg alternativesLoadedMsg) {
+func (m *Model) handleAlternativesLoaded(msg alternativesLoadedMsg) tea.Cmd {
 	state := m.ensureProviderState(msg.providerID)
 	state.alternatives = msg.alternatives
 	state.alternativesLoaded = true
@@ -378,13 +560,14 @@ func (m *Model) handleAlternativesLoaded(msg alternativesLoadedMsg) {
 	m.syncAltIdx(msg.providerID)
 
 	// 检查是否所有加载都完成，立即清除加载状态消息
-	if state.alternativesLoaded && state.selectionLoaded && strings.Contains(m.status, "加载提供商") {
+	if state.alternativesLoaded && state.selectionLoaded && strings.Contains(m.status, i18n.T("加载提供商")) {
 		m.status = ""
 	}
+	return m.prefetchSettled(msg.providerID)
 }
 
 // handleSelectionLoaded processes selection load.
-func (m *Model) handleSelectionLoaded(msg selectionLoadedMsg) {
+func (m *Model) handleSelectionLoaded(msg selectionLoadedMsg) tea.Cmd {
 	state := m.ensureProviderState(msg.providerID)
 	state.selection = msg.selection
 	state.selectionLoaded = true
@@ -393,40 +576,95 @@ func (m *Model) handleSelectionLoaded(msg selectionLoadedMsg) {
 	m.syncAltIdx(msg.providerID)
 
 	// 检查是否所有加载都完成，立即清除加载状态消息
-	if state.alternativesLoaded && state.selectionLoaded && strings.Contains(m.status, "加载提供商") {
+	if state.alternativesLoaded && state.selectionLoaded && strings.Contains(m.status, i18n.T("加载提供商")) {
 		m.status = ""
 	}
+	return m.prefetchSettled(msg.providerID)
 }
 
 // handleSwitchCompleted processes provider switch completion.
 func (m *Model) handleSwitchCompleted(msg switchCompletedMsg) []tea.Cmd {
 	state := m.ensureProviderState(msg.providerID)
+	previousID := selectedAlternativeID(state.selection)
+	m.showSummary(i18n.T("切换完成"), summaryItem{
+		label: m.providerDisplayName(msg.providerID),
+		old:   selectedAlternativeName(state.selection),
+		new:   msg.selection.SelectedAlternative.DisplayName,
+	})
 	state.selection = msg.selection
 	state.selectionLoaded = true
 	state.switching = false
 	state.lastError = nil
 	m.syncAltIdx(msg.providerID)
-	m.status = fmt.Sprintf("已切换到 %s", msg.selection.SelectedAlternative.DisplayName)
-	return []tea.Cmd{clearStatusAfter(statusClearDelay)}
+	m.status = ""
+	return []tea.Cmd{
+		m.showToast(toastSuccess, i18n.Tf("已切换到 %s", msg.selection.SelectedAlternative.DisplayName)),
+		m.recordRecent(msg.providerID, previousID, msg.selection.SelectedAlternativeID),
+		m.releasePeer(peer.SwitchTarget(msg.providerID), &peer.Event{
+			Kind:          peer.KindSwitch,
+			ProviderID:    msg.providerID,
+			AlternativeID: msg.selection.SelectedAlternativeID,
+			Name:          msg.selection.SelectedAlternative.DisplayName,
+		}),
+	}
+}
+
+// selectedAlternativeID returns the selected alternative, or 0 when unknown.
+func selectedAlternativeID(selection *api.ProviderSelection) int {
+	if selection == nil {
+		return 0
+	}
+	return selection.SelectedAlternativeID
+}
+
+// selectedAlternativeName returns the selected alternative's name, or "未知" when unknown.
+func selectedAlternativeName(selection *api.ProviderSelection) string {
+	if selection == nil {
+		return i18n.T("未知")
+	}
+	return selection.SelectedAlternative.DisplayName
 }
 
 // handlePreferenceUpdated processes preference update success.
 func (m *Model) handlePreferenceUpdated(msg preferenceUpdatedMsg) []tea.Cmd {
+	old := ""
 	if m.profile != nil {
+		old = m.profile.BalancePreference
 		m.profile.BalancePreference = msg.preference
 	}
+	m.showSummary(i18n.T("余额偏好已更新"), summaryItem{
+		label: i18n.T("余额使用偏好"),
+		old:   describePreference(old),
+		new:   describePreference(msg.preference),
+	})
 	m.preferenceSwitching = false
 	m.syncBalancePreferenceIdx()
-	m.status = fmt.Sprintf("余额偏好已切换为 %s", describePreference(msg.preference))
-	return []tea.Cmd{clearStatusAfter(statusClearDelay)}
+	m.status = ""
+	return []tea.Cmd{
+		m.showToast(toastSuccess, i18n.Tf("余额偏好已切换为 %s", describePreference(msg.preference))),
+		m.releasePeer(peer.PreferenceTarget, &peer.Event{Kind: peer.KindPreference, Name: msg.preference}),
+	}
 }
 
 // handlePreferenceFailed processes preference update failure.
 func (m *Model) handlePreferenceFailed(msg preferenceFailedMsg) []tea.Cmd {
 	m.preferenceSwitching = false
 	m.err = msg.err
-	m.status = fmt.Sprintf("余额偏好切换失败: %v", msg.err)
-	return []tea.Cmd{clearStatusAfter(errorClearDelay)}
+	m.status = ""
+	old := ""
+	if m.profile != nil {
+		old = m.profile.BalancePreference
+	}
+	m.showSummary(i18n.T("余额偏好切换失败"), summaryItem{
+		label: i18n.T("余额使用偏好"),
+		old:   describePreference(old),
+		new:   describePreference(msg.target),
+		err:   msg.err,
+	})
+	return []tea.Cmd{
+		m.showErrorToast(i18n.Tf("余额偏好切换失败: %s", m.describeError(msg.err)), msg.err),
+		m.releasePeer(peer.PreferenceTarget, nil),
+	}
 }
 
 // handleProviderLoadFailed processes provider load failures.
@@ -437,30 +675,69 @@ func (m *Model) handleProviderLoadFailed(msg providerLoadFailedMsg) []tea.Cmd {
 		state.loadingAlternatives = false
 	case "selection":
 		state.loadingSelection = false
+	}
+	if msg.target != "switch" && canceled(msg.err) {
+		// 离开标签页时取消的加载不提示，回到标签页时会重新加载
+		return nil
+	}
+	switch msg.target {
 	case "switch":
 		state.switching = false
+		m.showSummary(i18n.T("切换失败"), summaryItem{
+			label: m.providerDisplayName(msg.providerID),
+			old:   selectedAlternativeName(state.selection),
+			new:   state.switchTarget,
+			err:   msg.err,
+		})
 	}
 	state.lastError = msg.err
+	if msg.target != "switch" && m.prefetching(msg.providerID) {
+		// 后台预取失败只记录在该提供商上，移到它时再显示
+		cmds := []tea.Cmd{m.prefetchSettled(msg.providerID)}
+		if cmd, limited := m.noteRateLimit("", msg.err); limited {
+			cmds = append(cmds, cmd)
+		}
+		return cmds
+	}
 	m.err = msg.err
-	m.status = fmt.Sprintf("提供商 %d: %v", msg.providerID, msg.err)
-	return []tea.Cmd{clearStatusAfter(errorClearDelay)}
+	m.status = ""
+	cmds := []tea.Cmd{m.prefetchSettled(msg.providerID), m.showErrorToast(fmt.Sprintf("%s: %s", m.providerDisplayName(msg.providerID), m.describeError(msg.err)), msg.err)}
+	if cmd, limited := m.noteRateLimit("", msg.err); limited {
+		cmds = append(cmds, cmd)
+	}
+	if msg.target == "switch" {
+		cmds = append(cmds, m.releasePeer(peer.SwitchTarget(msg.providerID), nil))
+	}
+	return cmds
 }
 
 // handleError processes general errors.
 func (m *Model) handleError(msg errMsg) []tea.Cmd {
+	if canceled(msg.err) {
+		// 退出或离开标签页时取消的请求，不算失败
+		return nil
+	}
 	m.err = msg.err
-	m.status = msg.err.Error()
+	m.status = ""
 
-	// 如果是加载提供商失败，重置加载状态
-	if m.loadingProviders {
+	switch msg.target {
+	case "providers":
 		m.loadingProviders = false
-	}
-	// 如果是加载用户资料失败，重置加载状态
-	if m.loadingProfile {
+		m.providersErr = msg.err
+	case "providers_page":
+		// 已加载的页保持不变，移动光标时重试；等待这一页的跳转随之放弃
+		m.loadingMoreProviders = false
+		m.pendingGoto = 0
+	case "profile":
 		m.loadingProfile = false
 		m.manualRefreshingProfile = false
+		m.profileErr = msg.err
 	}
-	return []tea.Cmd{clearStatusAfter(errorClearDelay)}
+	// 被限流时由状态栏倒计时并自动重试，不再弹出错误通知
+	if cmd, limited := m.noteRateLimit(msg.target, msg.err); limited {
+		return []tea.Cmd{cmd}
+	}
+	return []tea.Cmd{m.showErrorToast(m.describeError(msg.err), msg.err)}
 }
 
 // handleClearStatus clears status and error messages.
@@ -469,73 +746,103 @@ func (m *Model) handleClearStatus() {
 	m.err = nil
 }
 
-// View renders the TUI.
+// View renders the TUI, with the performance overlay when it is shown.
 func (m *Model) View() string {
-	var sections []string
-
-	// Material Design 风格应用标题
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(primaryColor).
-		Width(m.width).
-		Align(lipgloss.Center)
-
-	sections = append(sections, titleStyle.Render("◆ YesCode TUI ◆"))
+	if !m.perf.shown {
+		return m.render()
+	}
+	start := time.Now()
+	view := m.render()
+	m.perf.noteRender(time.Since(start))
+	return m.renderPerf(view)
+}
 
-	// 简洁的帮助提示
-	helpHintStyle := lipgloss.NewStyle().
-		Foreground(mutedColor).
-		Width(m.width).
-		Align(lipgloss.Center)
-	sections = append(sections, helpHintStyle.Render("支持鼠标操作 · Enter 确认 · Esc 退出 · 输入 ? 查看完整操作帮助"))
+// render builds the whole screen.
+func (m *Model) render() string {
+	if m.lock.locked {
+		return m.renderLockScreen()
+	}
 
-	// 添加 tab header
-	sections = append(sections, m.renderTabHeader())
+	// 标题、帮助提示和 tab header
+	sections := m.renderChrome()
 
 	// 根据当前 tab 渲染不同内容
+	var content string
 	if m.currentTab == tabProfile {
-		sections = append(sections, m.renderProfileTab())
+		content = m.renderProfileTab()
 	} else if m.currentTab == tabProviders {
-		sections = append(sections, m.renderPanels())
+		content = m.renderPanels()
 	} else if m.currentTab == tabBalancePreference {
-		sections = append(sections, m.renderBalancePreferenceTab())
+		content = m.renderBalancePreferenceTab()
+	} else if m.currentTab == tabStats {
+		content = m.renderStatsTab()
 	}
+	sections = append(sections, m.fillContent(content))
 
-	// 始终渲染状态栏区域，保持视图高度一致
+	// 底部状态栏常驻：左侧为输入框或状态消息，没有时显示当前面板的按键提示
 	statusText := ""
 
 	// 如果正在手动刷新用户资料，显示刷新状态
-	if m.manualRefreshingProfile && m.currentTab == tabProfile {
-		statusText = fmt.Sprintf("刷新中... %s", m.spinner.View())
+	if m.gotoInput != nil {
+		statusText = m.gotoInput.View()
+	} else if f := m.editingFilter(); f != nil {
+		statusText = f.input.View() + helpStyle.Render(i18n.T("  ↑↓ 选择 · Enter 确定 · Esc 清除"))
+	} else if m.showHints {
+		statusText = i18n.T("提示模式：按标签字母激活 · 其他键退出")
+	} else if m.manualRefreshingProfile && m.currentTab == tabProfile {
+		statusText = i18n.Tf("刷新中... %s", m.spinner.View())
 	} else if m.status != "" {
 		statusText = m.status
 		// 如果状态消息表示正在进行中，添加 spinner
-		if strings.Contains(statusText, "中...") || strings.Contains(statusText, "加载") {
+		if strings.Contains(statusText, i18n.T("中...")) || strings.Contains(statusText, i18n.T("加载")) {
 			statusText = fmt.Sprintf("%s %s", statusText, m.spinner.View())
 		}
+	} else if m.rateLimited() {
+		statusText = m.rateLimitStatus()
 	}
-	sections = append(sections, statusStyle.Render(statusText))
+	sections = append(sections, m.renderStatusBar(statusText))
 
 	mainView := strings.Join(sections, "\n\n")
 
-	// 如果帮助对话框打开，只显示对话框，隐藏主页面
-	if m.showHelpDialog {
-		dialog := m.renderHelpDialog()
-		// 将对话框居中放置在全屏空间中
-		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, dialog)
+	// 对话框叠加在置灰的主页面之上，通知浮在最上层
+	if modal := m.activeModal(); modal != nil {
+		mainView = m.overlay(mainView, modal.render())
 	}
-
-	return mainView
+	return m.renderToasts(mainView)
 }
 
 func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
 	// Handle Ctrl+C first
-	if msg.Type == tea.KeyCtrlC {
-		return tea.Quit
+	if msg.Type == tea.KeyCtrlC || msg.Type == tea.KeyCtrlQ {
+		return m.quit()
+	}
+	if m.lock.locked {
+		return m.handleLockKey(msg)
+	}
+
+	// 对话框打开时，所有按键交给最上层的对话框处理
+	if modal := m.activeModal(); modal != nil {
+		return modal.key(msg)
+	}
+	if m.gotoInput != nil {
+		return m.handleGotoKey(msg)
+	}
+	if m.editingFilter() != nil {
+		return m.handleFilterKey(msg)
 	}
 
 	key := msg.String()
 
+	// 提示模式下，按键用于选择标签
+	if m.showHints {
+		return m.handleHintKey(key)
+	}
+
+	// 连按 Esc 才退出，中间按了其他键则重新计数
+	if key != "esc" && key != "q" {
+		m.quitArmed = false
+	}
+
 	// Handle quit and help
 	if cmd := m.handleQuitAndHelp(key); cmd != nil {
 		return cmd
@@ -547,7 +854,99 @@ func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
 	}
 
 	// Handle focus switching (left/right)
-	m.handleFocusSwitch(key)
+	if cmd := m.handleFocusSwitch(key); cmd != nil {
+		return cmd
+	}
+
+	// Handle stats day cursor (left/right)
+	m.handleStatsCursor(key)
+
+	// Handle provider filter
+	if key == "/" && m.currentTab == tabProviders {
+		m.openFilter()
+		return nil
+	}
+
+	// Handle usage estimator
+	if key == "e" {
+		m.openEstimator()
+		return nil
+	}
+
+	// Handle restore default alternative
+	if key == "d" {
+		return m.restoreDefaultSelection()
+	}
+	if key == "D" {
+		return m.openResetAll()
+	}
+
+	// Handle plan screen
+	if key == "P" {
+		return m.openPlanFile()
+	}
+
+	// Handle rollback
+	if key == "R" {
+		return m.openRollback()
+	}
+
+	// Handle account switcher
+	if key == "ctrl+a" {
+		return m.openSwitcher()
+	}
+
+	// Handle error detail
+	if key == "E" {
+		return m.openErrorDetail()
+	}
+
+	// Handle request log viewer
+	if key == "ctrl+l" {
+		return m.openRequestLog()
+	}
+
+	// Handle performance overlay
+	if key == "ctrl+p" {
+		return m.togglePerf()
+	}
+
+	// Handle event log viewer (hidden, for debugging the interface)
+	if key == "ctrl+e" {
+		return m.openEventLog()
+	}
+
+	// Handle global search
+	if key == "ctrl+f" {
+		return m.openSearch()
+	}
+
+	// Handle goto provider
+	if key == ":" {
+		m.openGoto()
+		return nil
+	}
+
+	// Handle copying the selected profile field
+	if key == "c" && m.currentTab == tabProfile {
+		return m.copyProfileField()
+	}
+
+	// Handle hint mode
+	if key == "f" {
+		m.openHints()
+		return nil
+	}
+
+	// Handle local list preferences and undo/redo
+	if cmd := m.handleListViewKey(key); cmd != nil {
+		return cmd
+	}
+
+	// Handle recently used alternatives (alt+1..alt+3)
+	if cmd := m.handleRecentKey(key); cmd != nil {
+		return cmd
+	}
 
 	// Handle refresh
 	if cmd := m.handleRefresh(key); cmd != nil {
@@ -567,83 +966,95 @@ func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
-// handleQuitAndHelp handles Esc and ? keys.
+// handleQuitAndHelp handles Esc, ? and Ctrl+D keys.
 func (m *Model) handleQuitAndHelp(key string) tea.Cmd {
 	switch key {
 	case "esc":
-		// 如果帮助对话框打开，关闭它；否则退出程序
-		if m.showHelpDialog {
-			m.showHelpDialog = false
-			return nil
-		}
-		return tea.Quit
+		// 对话框由 activeModal 处理，这里逐级返回
+		return m.handleEscape()
+	case "q", "ctrl+q":
+		return m.requestQuit()
 	case "?", "？":
-		// 切换帮助对话框显示状态
-		m.showHelpDialog = !m.showHelpDialog
+		m.showHelpDialog = true
+		return nil
+	case "ctrl+d":
+		m.showDiagnostics = true
 		return nil
 	}
 	return nil
 }
 
-// handleTabSwitch handles tab switching keys (1, 2, 3, tab, shift+tab).
+// handleTabSwitch handles tab switching keys (1-4, tab, shift+tab).
 func (m *Model) handleTabSwitch(key string) tea.Cmd {
 	switch key {
 	case "1":
-		m.currentTab = tabProfile
-		return nil
+		return m.switchTab(tabProfile)
 	case "2":
-		m.currentTab = tabProviders
-		m.focus = focusProviders
-		return m.ensureProvidersLoaded()
+		return m.switchTab(tabProviders)
 	case "3":
-		m.currentTab = tabBalancePreference
-		m.syncBalancePreferenceIdx()
-		return nil
+		return m.switchTab(tabBalancePreference)
+	case "4":
+		return m.switchTab(tabStats)
 	case "tab":
-		return m.switchToNextTab()
+		return m.cycleFocus(1)
 	case "shift+tab":
-		return m.switchToPrevTab()
+		return m.cycleFocus(-1)
 	}
 	return nil
 }
 
+// switchTab activates the given tab.
+func (m *Model) switchTab(tab tabIndex) tea.Cmd {
+	if tab != m.currentTab {
+		m.cancelTabLoads()
+	}
+	m.currentTab = tab
+	return m.handleTabChanged()
+}
+
 // switchToNextTab switches to the next tab.
 func (m *Model) switchToNextTab() tea.Cmd {
-	m.currentTab = (m.currentTab + 1) % 3
-	return m.handleTabChanged()
+	return m.switchTab((m.currentTab + 1) % tabCount)
 }
 
 // switchToPrevTab switches to the previous tab.
 func (m *Model) switchToPrevTab() tea.Cmd {
-	m.currentTab = (m.currentTab - 1 + 3) % 3
-	return m.handleTabChanged()
+	return m.switchTab((m.currentTab - 1 + tabCount) % tabCount)
 }
 
 // handleTabChanged handles post-tab-switch logic.
 func (m *Model) handleTabChanged() tea.Cmd {
-	if m.currentTab == tabProviders {
-		m.focus = focusProviders
+	m.focus = m.focusRegions()[0]
+	switch m.currentTab {
+	case tabProviders:
+		if m.providersLoaded && len(m.providers) > 0 && m.loadProviderDetailsOnMove() {
+			// 离开标签页时取消了未完成的详情加载，回来后继续
+			return tea.Batch(m.queueProviderDetailLoad(m.currentProviderID()), m.startPrefetch())
+		}
 		return m.ensureProvidersLoaded()
-	} else if m.currentTab == tabBalancePreference {
+	case tabBalancePreference:
 		m.syncBalancePreferenceIdx()
+		// 账户是否有订阅/按需余额来自提供商列表
+		return m.ensureProvidersLoaded()
+	case tabStats:
+		return m.loadStats()
 	}
 	return nil
 }
 
 // handleFocusSwitch handles left/right focus switching.
-func (m *Model) handleFocusSwitch(key string) {
+func (m *Model) handleFocusSwitch(key string) tea.Cmd {
 	if m.currentTab != tabProviders {
-		return
+		return nil
 	}
 
 	switch key {
 	case "left", "h":
 		m.focus = focusProviders
 	case "right", "l":
-		m.focus = focusAlternatives
-		// 切换到右栏时，同步游标到当前激活项
-		m.syncAltIdx(m.currentProviderID())
+		return m.openAlternatives()
 	}
+	return nil
 }
 
 // handleRefresh handles refresh key (r).
@@ -657,6 +1068,8 @@ func (m *Model) handleRefresh(key string) tea.Cmd {
 		return m.refreshProfile()
 	case tabProviders:
 		return m.refreshCurrentProvider()
+	case tabStats:
+		return m.loadStats()
 	}
 	return nil
 }
@@ -668,10 +1081,20 @@ func (m *Model) handleEnter(key string) tea.Cmd {
 	}
 
 	switch m.currentTab {
+	case tabProfile:
+		return m.runFieldAction()
 	case tabProviders:
 		if m.focus == focusAlternatives {
+			// 筛选隐藏了光标所在的方案时不切换
+			state := m.ensureProviderState(m.currentProviderID())
+			if !slices.Contains(m.visibleAlternatives(state), m.altIdx) {
+				return nil
+			}
 			return m.switchSelection()
 		}
+		if m.lowBandwidth {
+			return m.openAlternatives()
+		}
 	case tabBalancePreference:
 		return m.toggleBalancePreference()
 	}
@@ -689,28 +1112,44 @@ func (m *Model) handleNavigation(key string) tea.Cmd {
 	default:
 		return nil
 	}
+	// 按住 j/k 时逐渐加速
+	step := m.navAccel.step(key, time.Now())
 
-	// Profile tab: scroll viewport
-	if m.currentTab == tabProfile {
+	// 按焦点所在区域分派
+	switch m.focus {
+	case focusProfile:
+		if m.moveProfileCursor(delta) {
+			return nil
+		}
 		if delta < 0 {
-			m.profileViewport.LineUp(1)
+			m.profileViewport.LineUp(step)
 		} else {
-			m.profileViewport.LineDown(1)
+			m.profileViewport.LineDown(step)
 		}
-		return nil
-	}
-
-	// Balance preference tab: move between two options
-	if m.currentTab == tabBalancePreference {
+	case focusPreference:
 		m.balancePreferenceIdx = clampIndex(m.balancePreferenceIdx+delta, 2)
-		return nil
+	case focusProviders, focusAlternatives:
+		return m.moveSelection(delta * step)
 	}
-
-	// Providers tab: move selection
-	return m.moveSelection(delta)
+	return nil
 }
 
 func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	// 对话框打开时背景不响应鼠标，只有对话框中的按钮可以点击
+	if modal := m.activeModal(); modal != nil {
+		if modal.buttons != nil && msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress {
+			if key, ok := m.buttonAt(msg.X, msg.Y, modal.buttons()); ok {
+				return modal.key(buttonKeyMsg(key))
+			}
+		}
+		return nil
+	}
+	if m.showHints {
+		// 标签会改变布局，点击时直接退出提示模式
+		m.showHints = false
+		return nil
+	}
+
 	x, y := msg.X, msg.Y
 
 	// 处理滚轮滚动
@@ -726,7 +1165,7 @@ func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 		return nil
 	}
 
-	layout := getUILayout()
+	layout := m.uiLayout()
 
 	// 点击标签页
 	if y == layout.tabHeaderY {
@@ -750,6 +1189,9 @@ func (m *Model) handleMouseWheel(delta int) tea.Cmd {
 			m.profileViewport.LineDown(1)
 		}
 		return nil
+	} else if m.currentTab == tabStats {
+		m.moveStatsCursor(delta)
+		return nil
 	} else if m.currentTab == tabProviders || m.currentTab == tabBalancePreference {
 		// 其他 tab: 上下移动选择
 		return m.moveSelection(delta)
@@ -760,73 +1202,98 @@ func (m *Model) handleMouseWheel(delta int) tea.Cmd {
 func (m *Model) handleTabClick(x int) tea.Cmd {
 	// 计算标签页位置
 	// 使用 lipgloss 的宽度计算，更准确地处理中文字符
-	tab1Text := "1. 用户资料"
-	tab2Text := "2. 提供商"
-
-	// activeTabStyle: padding(0,2) + marginRight(1)
-	// 中文字符通常占 2 个宽度单位
-	tab1Width := lipgloss.Width(activeTabStyle.Render(tab1Text))
-	tab2Width := lipgloss.Width(activeTabStyle.Render(tab2Text))
-
-	tab1End := tab1Width
-	tab2End := tab1End + tab2Width
-
-	if x < tab1End {
-		m.currentTab = tabProfile
-	} else if x < tab2End {
-		m.currentTab = tabProviders
-		m.focus = focusProviders
-		return m.ensureProvidersLoaded()
-	} else {
-		m.currentTab = tabBalancePreference
-		m.syncBalancePreferenceIdx()
+	// inactiveTabStyle: padding(0,2) + marginRight(1)，当前标签页宽度相同
+	end := 0
+	for i, title := range tabTitles {
+		end += lipgloss.Width(inactiveTabStyle.Render(i18n.T(title)))
+		if x < end {
+			return m.switchTab(tabIndex(i))
+		}
 	}
 	return nil
 }
 
 func (m *Model) handleContentClick(x, y int) tea.Cmd {
-	layout := getUILayout()
+	layout := m.uiLayout()
 	contentY := y - layout.contentStartY
 
 	switch m.currentTab {
+	case tabProfile:
+		// 加载失败时点击错误信息或重试按钮即可重试
+		if m.profile == nil && m.profileErr != nil && !m.loadingProfile {
+			return m.refreshProfile()
+		}
+		m.clickProfileField(contentY)
 	case tabProviders:
 		return m.handleProvidersClick(x, contentY)
 	case tabBalancePreference:
 		return m.handleBalancePreferenceClick(contentY)
+	case tabStats:
+		m.handleStatsClick(x)
 	}
 	return nil
 }
 
 func (m *Model) handleProvidersClick(x, contentY int) tea.Cmd {
+	onLeft := x < m.panelWidth(focusProviders)+2
+	if m.providersErr != nil && len(m.allProviders) == 0 && !m.loadingProviders {
+		// 列表加载失败时，左侧面板只有错误信息和重试按钮，点击即重试
+		if onLeft {
+			return m.refreshCurrentProvider()
+		}
+		return nil
+	}
 	if len(m.providers) == 0 {
 		return nil
 	}
 
-	layout := getUILayout()
+	layout := m.uiLayout()
 	// 面板内部列表项的 Y 位置需要减去面板的边框和内边距
 	listItemY := contentY - layout.panelInnerOffsetY
 
-	// 左右面板以屏幕中心分界
-	if x < m.width/2 {
+	// 左侧面板外宽 = 内容宽度 + 左右边框
+	if onLeft {
 		// 点击左侧提供商列表
 		m.focus = focusProviders
-		if listItemY >= 0 && listItemY < len(m.providers) {
-			m.providerIdx = listItemY
-			return m.queueProviderDetailLoad(m.currentProviderID())
+		listItemY += m.panelScroll[focusProviders]
+		descs := make([]string, len(m.providers))
+		for i, bucket := range m.providers {
+			descs[i] = bucket.Provider.Description
+		}
+		if row := m.rowAtLine(focusProviders, listItemY, descs); row >= 0 {
+			return m.selectProvider(row)
 		}
 	} else {
 		// 点击右侧备选方案列表
 		m.focus = focusAlternatives
 		state := m.ensureProviderState(m.currentProviderID())
-		if state.alternativesLoaded {
-			if listItemY >= 0 && listItemY < len(state.alternatives) {
-				m.altIdx = listItemY
-				// 直接确认切换
-				return m.switchSelection()
-			} else {
-				// 点击空白区域，同步游标到当前激活项
-				m.syncAltIdx(m.currentProviderID())
-			}
+		if state.lastError != nil && !state.loadingAlternatives {
+			// 出错时面板只显示错误信息和重试按钮，点击即重试
+			return m.refreshCurrentProvider()
+		}
+		if !state.alternativesLoaded {
+			return m.queueProviderDetailLoad(m.currentProviderID())
+		}
+		listItemY += m.panelScroll[focusAlternatives]
+		// 顶部的“最近使用”区域：标题行之后每行一个方案
+		recentLines := len(m.renderRecentSection(m.currentProviderID()))
+		if recentLines > 0 && listItemY < recentLines {
+			return m.selectRecent(listItemY)
+		}
+		listItemY -= recentLines
+
+		visible := m.visibleAlternatives(state)
+		descs := make([]string, len(visible))
+		for row, i := range visible {
+			descs[row] = state.alternatives[i].Alternative.Description
+		}
+		if row := m.rowAtLine(focusAlternatives, listItemY, descs); row >= 0 {
+			m.altIdx = visible[row]
+			// 直接确认切换
+			return m.switchSelection()
+		} else {
+			// 点击空白区域，同步游标到当前激活项
+			m.syncAltIdx(m.currentProviderID())
 		}
 	}
 	return nil
@@ -861,14 +1328,25 @@ func (m *Model) handleBalancePreferenceClick(contentY int) tea.Cmd {
 	return nil
 }
 
+// selectProvider moves the provider cursor to row and loads its details.
+func (m *Model) selectProvider(row int) tea.Cmd {
+	m.focus = focusProviders
+	m.providerIdx = clampIndex(row, len(m.providers))
+	m.syncAltIdx(m.currentProviderID())
+	if !m.loadProviderDetailsOnMove() {
+		return m.loadMoreProviders()
+	}
+	return tea.Batch(m.queueProviderDetailLoad(m.currentProviderID()), m.loadMoreProviders())
+}
+
 func (m *Model) ensureProvidersLoaded() tea.Cmd {
 	// 如果已经加载或正在加载，不重复请求
 	if m.providersLoaded || m.loadingProviders {
 		return nil
 	}
 	m.loadingProviders = true
-	m.status = "加载提供商列表中..."
-	return loadProvidersCmd(m.client)
+	m.status = i18n.T("加载提供商列表中...")
+	return loadProvidersCmd(m.tabContext(), m.client)
 }
 
 func (m *Model) moveSelection(delta int) tea.Cmd {
@@ -879,13 +1357,22 @@ func (m *Model) moveSelection(delta int) tea.Cmd {
 	if m.focus == focusProviders {
 		m.providerIdx = clampIndex(m.providerIdx+delta, len(m.providers))
 		m.syncAltIdx(m.currentProviderID())
-		return m.queueProviderDetailLoad(m.currentProviderID())
+		if !m.loadProviderDetailsOnMove() {
+			return m.loadMoreProviders()
+		}
+		return tea.Batch(m.queueProviderDetailLoad(m.currentProviderID()), m.loadMoreProviders())
 	} else {
 		state := m.ensureProviderState(m.currentProviderID())
 		if len(state.alternatives) == 0 {
 			return nil
 		}
-		m.altIdx = clampIndex(m.altIdx+delta, len(state.alternatives))
+		// 筛选时只在可见的方案之间移动
+		visible := m.visibleAlternatives(state)
+		if len(visible) == 0 {
+			return nil
+		}
+		pos := max(slices.Index(visible, m.altIdx), 0)
+		m.altIdx = visible[clampIndex(pos+delta, len(visible))]
 	}
 	return nil
 }
@@ -893,12 +1380,19 @@ func (m *Model) moveSelection(delta int) tea.Cmd {
 func (m *Model) refreshProfile() tea.Cmd {
 	m.loadingProfile = true
 	m.manualRefreshingProfile = true
-	return loadProfileCmd(m.client)
+	m.client.InvalidateCache()
+	return loadProfileCmd(m.ctx, m.client)
 }
 
 func (m *Model) refreshCurrentProvider() tea.Cmd {
+	m.client.InvalidateCache()
 	if len(m.providers) == 0 {
-		return nil
+		// 列表为空时重新加载提供商列表（例如充值之后）
+		if len(m.allProviders) > 0 {
+			return nil
+		}
+		m.providersLoaded = false
+		return m.ensureProvidersLoaded()
 	}
 	state := m.ensureProviderState(m.currentProviderID())
 	state.alternativesLoaded = false
@@ -921,13 +1415,38 @@ func (m *Model) switchSelection() tea.Cmd {
 	}
 	target := state.alternatives[m.altIdx].Alternative
 	if state.selection != nil && state.selection.SelectedAlternativeID == target.ID {
-		m.status = fmt.Sprintf("已在使用 %s", target.DisplayName)
+		m.status = i18n.Tf("已在使用 %s", target.DisplayName)
 		return nil
 	}
 
+	if cmd, ok := m.claimPeer(peer.SwitchTarget(m.currentProviderID()), "另一个 yc 实例（PID %d）正在切换该提供商"); !ok {
+		return cmd
+	}
 	state.switching = true
-	m.status = fmt.Sprintf("切换到 %s 中...", target.DisplayName)
-	return switchProviderCmd(m.client, m.currentProviderID(), target.ID)
+	state.switchTarget = target.DisplayName
+	m.status = i18n.Tf("切换到 %s 中...", target.DisplayName)
+	return switchProviderCmd(m.ctx, m.client, m.currentProviderID(), target.ID)
+}
+
+// restoreDefaultSelection switches the current provider back to its own (official) alternative.
+func (m *Model) restoreDefaultSelection() tea.Cmd {
+	if m.currentTab != tabProviders || len(m.providers) == 0 {
+		return nil
+	}
+	state := m.ensureProviderState(m.currentProviderID())
+	if !state.alternativesLoaded {
+		m.status = i18n.T("备选方案尚未加载")
+		return clearStatusAfter(statusClearDelay)
+	}
+	for i, alt := range state.alternatives {
+		if alt.IsSelf {
+			m.focus = focusAlternatives
+			m.altIdx = i
+			return m.switchSelection()
+		}
+	}
+	m.status = i18n.T("该提供商没有官方方案")
+	return clearStatusAfter(statusClearDelay)
 }
 
 func (m *Model) toggleBalancePreference() tea.Cmd {
@@ -948,9 +1467,12 @@ func (m *Model) toggleBalancePreference() tea.Cmd {
 		return nil
 	}
 
+	if cmd, ok := m.claimPeer(peer.PreferenceTarget, "另一个 yc 实例（PID %d）正在切换余额偏好"); !ok {
+		return cmd
+	}
 	m.preferenceSwitching = true
-	m.status = fmt.Sprintf("切换余额偏好到 %s...", describePreference(target))
-	return updatePreferenceCmd(m.client, target)
+	m.status = i18n.Tf("切换余额偏好到 %s...", describePreference(target))
+	return updatePreferenceCmd(m.ctx, m.client, target)
 }
 
 func (m *Model) syncBalancePreferenceIdx() {
@@ -971,28 +1493,31 @@ func (m *Model) queueProviderDetailLoad(providerID int) tea.Cmd {
 	if providerID == 0 {
 		return nil
 	}
+	cmd := m.loadProviderDetails(providerID)
+	if cmd != nil {
+		m.status = i18n.Tf("加载提供商 %d 详情中...", providerID)
+	}
+
+	// 如果数据已经加载完成，立即同步游标位置到当前激活项
+	if state := m.ensureProviderState(providerID); state.alternativesLoaded && state.selectionLoaded {
+		m.syncAltIdx(providerID)
+	}
+	return cmd
+}
+
+// loadProviderDetails starts whichever of the provider's alternatives and
+// selection are neither loaded nor loading.
+func (m *Model) loadProviderDetails(providerID int) tea.Cmd {
 	state := m.ensureProviderState(providerID)
 	var cmds []tea.Cmd
-	var loading bool
 	if !state.alternativesLoaded && !state.loadingAlternatives {
 		state.loadingAlternatives = true
-		cmds = append(cmds, loadAlternativesCmd(m.client, providerID))
-		loading = true
+		cmds = append(cmds, loadAlternativesCmd(m.tabContext(), m.client, providerID))
 	}
 	if !state.selectionLoaded && !state.loadingSelection {
 		state.loadingSelection = true
-		cmds = append(cmds, loadSelectionCmd(m.client, providerID))
-		loading = true
-	}
-	if loading {
-		m.status = fmt.Sprintf("加载提供商 %d 详情中...", providerID)
-	}
-
-	// 如果数据已经加载完成，立即同步游标位置到当前激活项
-	if state.alternativesLoaded && state.selectionLoaded {
-		m.syncAltIdx(providerID)
+		cmds = append(cmds, loadSelectionCmd(m.tabContext(), m.client, providerID))
 	}
-
 	if len(cmds) == 0 {
 		return nil
 	}
@@ -1055,11 +1580,6 @@ func clampIndex(idx, length int) int {
 	return idx
 }
 
-// contentHeight 返回内容区域的固定高度
-func (m *Model) contentHeight() int {
-	return defaultViewportHeight
-}
-
 func (m *Model) renderPanels() string {
 	left := m.renderProvidersPanel()
 	right := m.renderAlternativesPanel()
@@ -1072,97 +1592,123 @@ func (m *Model) renderPanels() string {
 
 func (m *Model) renderProvidersPanel() string {
 	var lines []string
+	cursor := -1
 
 	if m.loadingProviders {
-		lines = append(lines, fmt.Sprintf("加载中... %s", m.spinner.View()))
+		lines = append(lines, i18n.Tf("加载中... %s", m.spinner.View()))
+	} else if m.providersErr != nil && len(m.allProviders) == 0 {
+		lines = append(lines, m.renderErrorAdvice(m.providersErr, m.panelWidth(focusProviders)-4)...)
+		lines = append(lines, "", retryButton.render())
 	} else if len(m.providers) == 0 {
-		lines = append(lines, "暂无可用提供商")
+		lines = append(lines, m.renderProvidersEmpty()...)
 	} else {
 		for i, bucket := range m.providers {
-			prefix := "  "
+			prefix := m.rowPrefix(hintProvider, i, i == m.providerIdx)
+			line := fmt.Sprintf("%s%s%s%s",
+				translateProviderDisplayName(bucket.Provider.DisplayName),
+				formatSourceSuffix(bucket.Source),
+				formatTypeSuffix(bucket.Provider.Type),
+				formatBadge(bucket.IsDefault, i18n.T("默认")),
+			)
+			requirement := m.providerRequirement(bucket.Source)
+			lineStyle := lipgloss.NewStyle()
+			if requirement != "" {
+				// 当前账户无法使用的提供商置灰显示
+				lineStyle = lineStyle.Foreground(mutedColor)
+			}
 			if i == m.providerIdx {
-				prefix = "▶ "
+				lineStyle = m.cursorStyle(focusProviders, lineStyle)
 			}
-			lines = append(lines,
-				fmt.Sprintf("%s%s%s%s",
-					prefix,
-					translateProviderDisplayName(bucket.Provider.DisplayName),
-					formatSourceSuffix(bucket.Source),
-					formatTypeSuffix(bucket.Provider.Type),
-				),
-			)
+			line = lineStyle.Render(line) + formatRequirement(requirement)
+			if i == m.providerIdx {
+				cursor = len(lines)
+			}
+			lines = append(lines, prefix+providerSwatch(bucket.Provider.Type)+" "+line)
+			lines = append(lines, m.descriptionLines(focusProviders, bucket.Provider.Description)...)
 		}
+		lines = append(lines, m.moreProvidersFooter()...)
+		lines = append(lines, m.listViewFooter()...)
 	}
 
-	content := strings.Join(lines, "\n")
-
-	style := panelStyle.Copy()
-	if m.focus == focusProviders {
-		style = style.Copy().BorderStyle(activeBorder).BorderForeground(primaryColor)
-	}
-	return style.Width(m.panelWidth()).Height(defaultPanelHeight).Render(content)
+	return m.renderPanel(focusProviders, lines, cursor)
 }
 
 func (m *Model) renderAlternativesPanel() string {
 	var lines []string
+	cursor := -1
 
 	if len(m.providers) == 0 {
-		lines = append(lines, "请先选择提供商")
+		lines = append(lines, i18n.T("请先选择提供商"))
 	} else {
 		state := m.ensureProviderState(m.currentProviderID())
 
 		switch {
 		case state.loadingAlternatives:
-			lines = append(lines, fmt.Sprintf("加载中... %s", m.spinner.View()))
+			lines = append(lines, i18n.Tf("加载中... %s", m.spinner.View()))
 		case state.lastError != nil:
-			errorStyle := lipgloss.NewStyle().Foreground(errorColor)
-			lines = append(lines, errorStyle.Render(fmt.Sprintf("⚠ 错误：%v", state.lastError)))
-			lines = append(lines, "")
-			lines = append(lines, "按 r 键重试")
+			lines = append(lines, m.renderErrorAdvice(state.lastError, m.panelWidth(focusAlternatives)-4)...)
+			lines = append(lines, "", retryButton.render())
+		case !state.alternativesLoaded && m.lowBandwidth:
+			lines = append(lines, helpStyle.Render(i18n.T("省流模式：按 → 或 Enter 加载方案")))
 		case len(state.alternatives) == 0:
-			lines = append(lines, "无可切换方案")
+			lines = append(lines, i18n.T("无可切换方案"))
 		default:
-			for i, alt := range state.alternatives {
-				prefix := "  "
-				if i == m.altIdx {
-					prefix = "▶ "
-				}
+			lines = append(lines, m.renderRecentSection(m.currentProviderID())...)
+			visible := m.visibleAlternatives(state)
+			for _, i := range visible {
+				alt := state.alternatives[i]
+				prefix := m.rowPrefix(hintAlternative, i, i == m.altIdx)
 
 				// 检查是否为当前选中项
 				isCurrentSelection := state.selection != nil && state.selection.SelectedAlternativeID == alt.Alternative.ID
 
 				// 构建行内容
 				lineText := fmt.Sprintf("%s%s ×%.2f",
-					prefix,
 					alt.Alternative.DisplayName,
+					formatBadge(alt.IsSelf, i18n.T("官方")),
 					alt.Alternative.RateMultiplier,
 				)
 
+				lineStyle := lipgloss.NewStyle()
+				if isCurrentSelection {
+					lineStyle = selectedItemStyle
+				}
+				if i == m.altIdx {
+					lineStyle = m.cursorStyle(focusAlternatives, lineStyle)
+				}
+				lineText = prefix + providerSwatch(alt.Alternative.Type) + " " + lineStyle.Render(lineText)
+
 				// 如果是当前选中项，添加标记
 				if isCurrentSelection {
 					checkStyle := lipgloss.NewStyle().Foreground(successColor)
-					lineText = selectedItemStyle.Render(lineText) + " " + checkStyle.Render("✓")
+					lineText += " " + checkStyle.Render(glyphs.Check)
 				}
 
+				if i == m.altIdx {
+					cursor = len(lines)
+				}
 				lines = append(lines, lineText)
+				lines = append(lines, m.descriptionLines(focusAlternatives, alt.Alternative.Description)...)
 			}
+			lines = append(lines, m.altFilterNote(state)...)
 		}
 	}
 
-	content := strings.Join(lines, "\n")
-
-	style := panelStyle.Copy()
-	if m.focus == focusAlternatives {
-		style = style.Copy().BorderStyle(activeBorder).BorderForeground(primaryColor)
-	}
-	return style.Width(m.panelWidth()).Height(defaultPanelHeight).Render(content)
+	return m.renderPanel(focusAlternatives, lines, cursor)
 }
 
-func (m *Model) panelWidth() int {
+// panelWidth returns the content width of the panel for area, dividing the
+// screen according to the adjustable split.
+func (m *Model) panelWidth(area focusArea) int {
 	if m.width <= 0 {
 		return 50
 	}
-	w := m.width/2 - 3
+	// 两个面板的边框共占 4 列，另留 2 列余量
+	total := m.width - 6
+	w := total * m.view.splitPercent / 100
+	if area == focusAlternatives {
+		w = total - w
+	}
 	if w < minPanelWidth {
 		return minPanelWidth
 	}
@@ -1180,9 +1726,9 @@ func formatSourceSuffix(source string) string {
 func translateSourceLabel(source string) string {
 	switch source {
 	case "subscription":
-		return "订阅"
+		return i18n.T("订阅")
 	case "pay_as_you_go", "payg":
-		return "按需"
+		return i18n.T("按需")
 	default:
 		return source
 	}
@@ -1193,6 +1739,13 @@ func translateProviderDisplayName(name string) string {
 	return name
 }
 
+func formatBadge(show bool, label string) string {
+	if !show {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", label)
+}
+
 func formatTypeSuffix(providerType string) string {
 	providerType = strings.TrimSpace(providerType)
 	if providerType == "" {
@@ -1202,47 +1755,45 @@ func formatTypeSuffix(providerType string) string {
 }
 
 var (
-	// Material Design 风格配色
-	primaryColor   = lipgloss.Color("#2196F3") // Material Blue
-	secondaryColor = lipgloss.Color("#1976D2") // Dark Blue
-	accentColor    = lipgloss.Color("#FF4081") // Pink Accent
-	mutedColor     = lipgloss.Color("#9E9E9E") // Grey
-	successColor   = lipgloss.Color("#4CAF50") // Green
-	errorColor     = lipgloss.Color("#F44336") // Red
-	warningColor   = lipgloss.Color("#FF9800") // Orange
-
-	panelStyle        = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2).BorderForeground(mutedColor)
-	activeBorder      = lipgloss.RoundedBorder()
-	titleStyle        = lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
-	helpStyle         = lipgloss.NewStyle().Foreground(mutedColor)
-	statusStyle       = lipgloss.NewStyle().Foreground(primaryColor)
-	selectedItemStyle = lipgloss.NewStyle().Bold(true).Foreground(accentColor)
-	activeTabStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Background(primaryColor).Padding(0, 2).MarginRight(1)
-	inactiveTabStyle  = lipgloss.NewStyle().Foreground(mutedColor).Padding(0, 2).MarginRight(1)
+	// 当前主题的配色，由 useTheme 设置
+	primaryColor   lipgloss.Color
+	secondaryColor lipgloss.Color
+	accentColor    lipgloss.Color
+	mutedColor     lipgloss.Color
+	successColor   lipgloss.Color
+	errorColor     lipgloss.Color
+	warningColor   lipgloss.Color
+	onPrimaryColor lipgloss.Color // Text on primary background
+
+	activeBorder = lipgloss.RoundedBorder()
+
+	// 由 rebuildStyles 根据当前配色生成
+	panelStyle        lipgloss.Style
+	titleStyle        lipgloss.Style
+	helpStyle         lipgloss.Style
+	statusStyle       lipgloss.Style
+	selectedItemStyle lipgloss.Style
+	activeTabStyle    lipgloss.Style
+	inactiveTabStyle  lipgloss.Style
 )
 
 func (m *Model) renderTabHeader() string {
 	tabs := []string{}
 
-	// Tab 1: 用户资料
-	if m.currentTab == tabProfile {
-		tabs = append(tabs, activeTabStyle.Render("1. 用户资料"))
-	} else {
-		tabs = append(tabs, inactiveTabStyle.Render("1. 用户资料"))
-	}
-
-	// Tab 2: 提供商
-	if m.currentTab == tabProviders {
-		tabs = append(tabs, activeTabStyle.Render("2. 提供商"))
-	} else {
-		tabs = append(tabs, inactiveTabStyle.Render("2. 提供商"))
-	}
-
-	// Tab 3: 余额使用偏好
-	if m.currentTab == tabBalancePreference {
-		tabs = append(tabs, activeTabStyle.Render("3. 余额使用偏好"))
-	} else {
-		tabs = append(tabs, inactiveTabStyle.Render("3. 余额使用偏好"))
+	for i, title := range tabTitles {
+		title = i18n.T(title)
+		if label := m.hintLabel(hintTab, i); label != "" {
+			title = renderHint(label) + title
+		}
+		if m.currentTab == tabIndex(i) {
+			if noColor {
+				// 无颜色时用括号标出当前标签页
+				title = "[" + title + "]"
+			}
+			tabs = append(tabs, activeTabStyle.Render(title))
+		} else {
+			tabs = append(tabs, inactiveTabStyle.Render(title))
+		}
 	}
 
 	tabsRow := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
@@ -1253,8 +1804,12 @@ func (m *Model) renderTabHeader() string {
 func (m *Model) renderProfileTab() string {
 	// 只在首次加载（profile为空且不是手动刷新）时显示内容区加载状态
 	// 手动刷新时在状态栏显示，内容区保持不变
+	if m.profile == nil && m.profileErr != nil && !m.loadingProfile {
+		lines := m.renderErrorAdvice(m.profileErr, m.width-viewportWidthMargin)
+		return strings.Join(append(lines, "", retryButton.render()), "\n")
+	}
 	if m.profile == nil && !m.manualRefreshingProfile {
-		return fmt.Sprintf("加载中... %s", m.spinner.View())
+		return i18n.Tf("加载中... %s", m.spinner.View())
 	}
 
 	// 如果profile还是nil（不应该发生，但防御性处理）
@@ -1262,8 +1817,17 @@ func (m *Model) renderProfileTab() string {
 		return ""
 	}
 
-	// 构建内容
+	// 构建内容，字段在渲染各部分时重新收集
+	m.profileCursor.fields = m.profileCursor.fields[:0]
 	var lines []string
+	if banner := m.renderAnomalyBanner(); len(banner) > 0 {
+		lines = append(lines, banner...)
+		lines = append(lines, "")
+	}
+	if onboarding := m.renderOnboarding(); len(onboarding) > 0 {
+		lines = append(lines, onboarding...)
+		lines = append(lines, "")
+	}
 	lines = append(lines, m.renderAccountInfo()...)
 	lines = append(lines, "")
 	lines = append(lines, m.renderBalanceOverview()...)
@@ -1274,10 +1838,14 @@ func (m *Model) renderProfileTab() string {
 	} else {
 		lines = append(lines, "")
 		lines = append(lines, m.renderSpendingStats()...)
+		lines = append(lines, m.renderSubscribeHint()...)
 	}
+	lines = append(lines, "")
+	lines = append(lines, m.renderSpendTrend()...)
 
 	content := strings.Join(lines, "\n")
 	m.setupProfileViewport(content)
+	m.placeProfileFields(lines, m.profileViewport.Width)
 
 	// 构建输出
 	var output []string
@@ -1292,75 +1860,95 @@ func (m *Model) renderProfileTab() string {
 
 // renderAccountInfo renders account information section.
 func (m *Model) renderAccountInfo() []string {
-	return []string{
-		titleStyle.Render("账户信息"),
-		fmt.Sprintf("  用户名：%s", m.profile.Username),
-		fmt.Sprintf("  邮箱：%s", m.profile.Email),
+	lines := []string{
+		titleStyle.Render(i18n.T("账户信息")),
+		m.fieldRow(fieldUsername, m.profile.Username, i18n.Tf("  用户名：%s", m.profile.Username)),
+		m.fieldRow(fieldEmail, m.profile.Email, i18n.Tf("  邮箱：%s", m.profile.Email)),
+	}
+	if !m.profileUpdated.IsZero() {
+		updated := i18n.T("  更新于 ") + format.Time(m.profileUpdated)
+		if m.stream.connected {
+			updated += i18n.T(" · 实时推送")
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(mutedColor).Render(updated))
 	}
+	return lines
 }
 
 // renderBalanceOverview renders balance overview section.
 func (m *Model) renderBalanceOverview() []string {
-	return []string{
-		titleStyle.Render("余额概览"),
-		fmt.Sprintf("  ● 订阅余额：$%.2f", m.profile.SubscriptionBalance),
-		fmt.Sprintf("  ● 按需余额：$%.2f", m.profile.PayAsYouGoBalance),
-		fmt.Sprintf("  ● 总余额：$%.2f", m.profile.Balance),
-		fmt.Sprintf("  ● 余额偏好：%s", describePreference(m.profile.BalancePreference)),
+	subscription := format.Money(m.profile.SubscriptionBalance)
+	payAsYouGo := format.Money(m.profile.PayAsYouGoBalance)
+	balance := format.Money(m.profile.Balance)
+	preference := describePreference(m.profile.BalancePreference)
+	lines := []string{
+		titleStyle.Render(i18n.T("余额概览")),
+		m.fieldRow(fieldSubscriptionBalance, subscription, i18n.Tf("  %s 订阅余额：%s", glyphs.Balance, subscription)),
+		m.fieldRow(fieldPayAsYouGoBalance, payAsYouGo, i18n.Tf("  %s 按需余额：%s", glyphs.Balance, payAsYouGo)),
+		m.fieldRow(fieldBalance, balance, i18n.Tf("  %s 总余额：%s", glyphs.Balance, balance)),
+		m.fieldRow(fieldBalancePreference, preference, i18n.Tf("  %s 余额偏好：%s", glyphs.Balance, preference)),
 	}
+	return append(lines, m.renderConsistencyWarnings()...)
 }
 
 // renderSubscriptionPlan renders subscription plan details.
 func (m *Model) renderSubscriptionPlan() []string {
 	plan := m.profile.SubscriptionPlan
 	lines := []string{
-		titleStyle.Render("订阅计划"),
-		fmt.Sprintf("  ● 计划：%s ($%.2f)", plan.Name, plan.Price),
+		titleStyle.Render(i18n.T("订阅计划")),
+		m.fieldRow(fieldPlan, plan.Name, i18n.Tf("  %s 计划：%s (%s)", glyphs.Bullet, plan.Name, format.Money(plan.Price))),
 	}
 
 	// 优化截止日期显示
 	if m.profile.SubscriptionExpiry != "" {
 		expiryDate := m.formatDate(m.profile.SubscriptionExpiry)
-		lines = append(lines, fmt.Sprintf("  ● 到期：%s", expiryDate))
+		lines = append(lines, m.fieldRow(fieldExpiry, expiryDate, i18n.Tf("  %s 到期：%s", glyphs.Bullet, expiryDate)))
 	}
 
-	lines = append(lines, fmt.Sprintf("  ● 每日额度：$%.2f", plan.DailyBalance))
+	daily := format.Money(plan.DailyBalance)
+	lines = append(lines, m.fieldRow(fieldDailyBalance, daily, i18n.Tf("  %s 每日额度：%s", glyphs.Bullet, daily)))
 
 	// 本周消费（带百分比）
 	weekPercent := 0.0
 	if plan.WeeklyLimit > 0 {
 		weekPercent = (m.profile.CurrentWeekSpend / plan.WeeklyLimit) * 100
 	}
-	lines = append(lines, fmt.Sprintf("  ● 本周：$%.2f / $%.2f (%.1f%%)",
-		m.profile.CurrentWeekSpend, plan.WeeklyLimit, weekPercent))
+	week := format.Money(m.profile.CurrentWeekSpend)
+	lines = append(lines, m.fieldRow(fieldWeekSpend, week, i18n.Tf("  %s 本周：%s / %s (%s%%)",
+		glyphs.Bullet, week, format.Money(plan.WeeklyLimit), format.Number(weekPercent, 1))))
 
 	// 本月消费（带百分比）
 	monthPercent := 0.0
 	if plan.MonthlySpendLimit > 0 {
 		monthPercent = (m.profile.CurrentMonthSpend / plan.MonthlySpendLimit) * 100
 	}
-	lines = append(lines, fmt.Sprintf("  ● 本月：$%.2f / $%.2f (%.1f%%)",
-		m.profile.CurrentMonthSpend, plan.MonthlySpendLimit, monthPercent))
+	month := format.Money(m.profile.CurrentMonthSpend)
+	lines = append(lines, m.fieldRow(fieldMonthSpend, month, i18n.Tf("  %s 本月：%s / %s (%s%%)",
+		glyphs.Bullet, month, format.Money(plan.MonthlySpendLimit), format.Number(monthPercent, 1))))
 
 	return lines
 }
 
 // renderSpendingStats renders spending statistics when no subscription plan exists.
 func (m *Model) renderSpendingStats() []string {
+	week := format.Money(m.profile.CurrentWeekSpend)
+	month := format.Money(m.profile.CurrentMonthSpend)
 	return []string{
-		titleStyle.Render("消费统计"),
-		fmt.Sprintf("  ● 本周消费：$%.2f", m.profile.CurrentWeekSpend),
-		fmt.Sprintf("  ● 本月消费：$%.2f", m.profile.CurrentMonthSpend),
+		titleStyle.Render(i18n.T("消费统计")),
+		m.fieldRow(fieldWeekSpend, week, i18n.Tf("  %s 本周消费：%s", glyphs.Bullet, week)),
+		m.fieldRow(fieldMonthSpend, month, i18n.Tf("  %s 本月消费：%s", glyphs.Bullet, month)),
 	}
 }
 
-// setupProfileViewport configures the viewport with content and dimensions.
+// setupProfileViewport configures the viewport with content and dimensions,
+// wrapping lines wider than the viewport so nothing is clipped.
 func (m *Model) setupProfileViewport(content string) {
-	m.profileViewport.SetContent(content)
 	m.profileViewport.Height = m.contentHeight()
 	if m.width > 0 {
 		m.profileViewport.Width = m.width - viewportWidthMargin
+		content = softWrap(content, m.profileViewport.Width)
 	}
+	m.profileViewport.SetContent(content)
 }
 
 // renderScrollIndicator returns a scroll indicator if more content is available.
@@ -1371,7 +1959,7 @@ func (m *Model) renderScrollIndicator() string {
 	return lipgloss.NewStyle().
 		Foreground(accentColor).
 		Bold(true).
-		Render("▼ 更多内容")
+		Render(glyphs.More + i18n.T(" 更多内容"))
 }
 
 // formatDate 优化日期显示的可读性
@@ -1383,10 +1971,14 @@ func (m *Model) formatDate(dateStr string) string {
 		"2006-01-02",
 	}
 
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			// 返回更友好的格式：2024年1月15日
-			return t.Format("2006年1月2日")
+	for _, layout := range formats {
+		if t, err := time.Parse(layout, dateStr); err == nil {
+			// 带时间的值按本地时区换算，纯日期保持不变
+			if layout != time.DateOnly {
+				t = t.Local()
+			}
+			// 返回更友好的格式：2024年1月15日（按 --locale）
+			return format.Date(t)
 		}
 	}
 
@@ -1396,68 +1988,64 @@ func (m *Model) formatDate(dateStr string) string {
 
 func (m *Model) renderBalancePreferenceTab() string {
 	if m.profile == nil {
-		return "加载中..."
+		return i18n.T("加载中...")
 	}
 
 	var lines []string
 
 	// 优先订阅选项 (索引0)
-	prefix := "  "
-	if m.balancePreferenceIdx == 0 {
-		prefix = "▶ "
-	}
-	label := "优先订阅"
+	prefix := m.rowPrefix(hintPreference, 0, m.balancePreferenceIdx == 0)
+	label := i18n.T("优先订阅")
 	if m.profile.BalancePreference == "subscription_first" {
 		checkStyle := lipgloss.NewStyle().Foreground(successColor)
-		lines = append(lines, selectedItemStyle.Render(prefix+label)+" "+checkStyle.Render("✓"))
+		lines = append(lines, selectedItemStyle.Render(prefix+label)+" "+checkStyle.Render(glyphs.Check))
 	} else {
 		lines = append(lines, prefix+label)
 	}
-	lines = append(lines, "    先使用订阅余额，然后使用按需付费")
-	lines = append(lines, "    OPUS 使用限制适用")
+	lines = append(lines, i18n.T("    先使用订阅余额，然后使用按需付费"))
+	lines = append(lines, i18n.T("    OPUS 使用限制适用"))
+	lines = append(lines, m.preferenceNotes("subscription_first")...)
 	lines = append(lines, "")
 
 	// 仅按需付费选项 (索引1)
-	prefix = "  "
-	if m.balancePreferenceIdx == 1 {
-		prefix = "▶ "
-	}
-	label = "仅按需付费"
+	prefix = m.rowPrefix(hintPreference, 1, m.balancePreferenceIdx == 1)
+	label = i18n.T("仅按需付费")
 	if m.profile.BalancePreference == "payg_only" {
 		checkStyle := lipgloss.NewStyle().Foreground(successColor)
-		lines = append(lines, selectedItemStyle.Render(prefix+label)+" "+checkStyle.Render("✓"))
+		lines = append(lines, selectedItemStyle.Render(prefix+label)+" "+checkStyle.Render(glyphs.Check))
 	} else {
 		lines = append(lines, prefix+label)
 	}
-	lines = append(lines, "    始终使用按需付费余额")
-	lines = append(lines, "    无 OPUS 使用限制")
+	lines = append(lines, i18n.T("    始终使用按需付费余额"))
+	lines = append(lines, i18n.T("    无 OPUS 使用限制"))
+	lines = append(lines, m.preferenceNotes("payg_only")...)
 
 	return strings.Join(lines, "\n")
 }
 
-func loadProfileCmd(client *api.Client) tea.Cmd {
+func loadProfileCmd(ctx context.Context, client api.Service) tea.Cmd {
 	return func() tea.Msg {
-		profile, err := client.GetProfile(context.Background())
+		profile, err := client.GetProfile(ctx)
 		if err != nil {
-			return errMsg{err: err}
+			return errMsg{target: "profile", err: err}
 		}
 		return profileLoadedMsg{profile: profile}
 	}
 }
 
-func loadProvidersCmd(client *api.Client) tea.Cmd {
+func loadProvidersCmd(ctx context.Context, client api.Service) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := client.GetAvailableProviders(context.Background())
+		resp, err := client.GetProvidersPage(ctx, api.Page{})
 		if err != nil {
-			return errMsg{err: err}
+			return errMsg{target: "providers", err: err}
 		}
 		return providersLoadedMsg{response: resp}
 	}
 }
 
-func loadAlternativesCmd(client *api.Client, providerID int) tea.Cmd {
+func loadAlternativesCmd(ctx context.Context, client api.Service, providerID int) tea.Cmd {
 	return func() tea.Msg {
-		alts, err := client.GetProviderAlternatives(context.Background(), providerID)
+		alts, err := client.GetProviderAlternatives(ctx, providerID)
 		if err != nil {
 			return providerLoadFailedMsg{providerID: providerID, target: "alternatives", err: err}
 		}
@@ -1465,9 +2053,9 @@ func loadAlternativesCmd(client *api.Client, providerID int) tea.Cmd {
 	}
 }
 
-func loadSelectionCmd(client *api.Client, providerID int) tea.Cmd {
+func loadSelectionCmd(ctx context.Context, client api.Service, providerID int) tea.Cmd {
 	return func() tea.Msg {
-		selection, err := client.GetProviderSelection(context.Background(), providerID)
+		selection, err := client.GetProviderSelection(ctx, providerID)
 		if err != nil {
 			return providerLoadFailedMsg{providerID: providerID, target: "selection", err: err}
 		}
@@ -1475,9 +2063,9 @@ func loadSelectionCmd(client *api.Client, providerID int) tea.Cmd {
 	}
 }
 
-func switchProviderCmd(client *api.Client, providerID, alternativeID int) tea.Cmd {
+func switchProviderCmd(ctx context.Context, client api.Service, providerID, alternativeID int) tea.Cmd {
 	return func() tea.Msg {
-		selection, err := client.SwitchProvider(context.Background(), providerID, alternativeID)
+		selection, err := client.SwitchProvider(ctx, providerID, alternativeID)
 		if err != nil {
 			return providerLoadFailedMsg{providerID: providerID, target: "switch", err: err}
 		}
@@ -1485,11 +2073,11 @@ func switchProviderCmd(client *api.Client, providerID, alternativeID int) tea.Cm
 	}
 }
 
-func updatePreferenceCmd(client *api.Client, preference string) tea.Cmd {
+func updatePreferenceCmd(ctx context.Context, client api.Service, preference string) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := client.UpdateBalancePreference(context.Background(), preference)
+		resp, err := client.UpdateBalancePreference(ctx, preference)
 		if err != nil {
-			return preferenceFailedMsg{err: err}
+			return preferenceFailedMsg{target: preference, err: err}
 		}
 		return preferenceUpdatedMsg{preference: resp.BalancePreference}
 	}
@@ -1501,8 +2089,8 @@ func clearStatusAfter(d time.Duration) tea.Cmd {
 	})
 }
 
-func profileRefreshTicker() tea.Cmd {
-	return tea.Tick(profileRefreshInterval, func(time.Time) tea.Msg {
+func profileRefreshTicker(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
 		return profileRefreshTickMsg{}
 	})
 }
@@ -1510,12 +2098,12 @@ func profileRefreshTicker() tea.Cmd {
 func describePreference(pref string) string {
 	switch pref {
 	case "subscription_first":
-		return "优先订阅"
+		return i18n.T("优先订阅")
 	case "payg_only":
-		return "仅按需付费"
+		return i18n.T("仅按需付费")
 	default:
 		if pref == "" {
-			return "未知"
+			return i18n.T("未知")
 		}
 		return pref
 	}
@@ -1530,29 +2118,50 @@ func (m *Model) renderHelpDialog() string {
 
 	// 帮助内容
 	helpContent := []string{
-		titleStyle.Render("操作帮助"),
+		titleStyle.Render(i18n.T("操作帮助")),
 		"",
-		sectionStyle.Render("鼠标操作"),
-		normalStyle.Render("  点击标签页        直接切换标签"),
-		normalStyle.Render("  点击列表项        选择提供商或备选方案"),
-		normalStyle.Render("  滚轮滚动         滚动内容或移动选择"),
+		sectionStyle.Render(i18n.T("鼠标操作")),
+		normalStyle.Render(i18n.T("  点击标签页        直接切换标签")),
+		normalStyle.Render(i18n.T("  点击列表项        选择提供商或备选方案")),
+		normalStyle.Render(i18n.T("  滚轮滚动         滚动内容或移动选择")),
 		"",
-		sectionStyle.Render("标签页切换"),
-		normalStyle.Render("  Tab / Shift+Tab  前后切换标签页"),
-		normalStyle.Render("  1 / 2 / 3        直接跳转到指定标签页"),
+		sectionStyle.Render(i18n.T("标签页切换")),
+		normalStyle.Render(i18n.T("  Tab / Shift+Tab  在面板间切换焦点，越过首尾时切换标签页")),
+		normalStyle.Render(i18n.T("  1 / 2 / 3 / 4    直接跳转到指定标签页")),
 		"",
-		sectionStyle.Render("导航操作"),
-		normalStyle.Render("  ↑↓ 或 k/j        上下移动"),
-		normalStyle.Render("  ←→ 或 h/l        切换焦点（提供商标签页）"),
-		normalStyle.Render("  Enter           确认选择"),
-		normalStyle.Render("  r               刷新当前视图"),
+		sectionStyle.Render(i18n.T("导航操作")),
+		normalStyle.Render(i18n.T("  ↑↓ 或 k/j        上下移动")),
+		normalStyle.Render(i18n.T("  ←→ 或 h/l        切换焦点（提供商标签页）/ 选择日期（统计标签页）")),
+		normalStyle.Render(i18n.T("  Enter           确认选择")),
+		normalStyle.Render(i18n.T("  c               复制选中的字段（资料标签页）")),
+		normalStyle.Render(i18n.T("  Enter（资料）    选中余额偏好、订阅计划或按需余额时执行对应操作")),
+		normalStyle.Render(i18n.T("  r               刷新当前视图")),
+		normalStyle.Render(i18n.T("  e               估算用量费用（提供商标签页）")),
+		normalStyle.Render(i18n.T("  d               恢复默认（官方）方案（提供商标签页）")),
+		normalStyle.Render(i18n.T("  D               全部提供商恢复默认（预览后确认）")),
+		normalStyle.Render(i18n.T("  Alt+1/2/3       切换到最近使用的方案（提供商标签页）")),
+		normalStyle.Render(i18n.T("  /               同时模糊筛选提供商和方案列表")),
+		normalStyle.Render(i18n.T("  s / x / X       排序 / 隐藏 / 显示全部提供商")),
+		normalStyle.Render(i18n.T("  < / >           调整左右面板比例")),
+		normalStyle.Render(i18n.T("  Ctrl+Z / Ctrl+Y 撤销 / 重做上述显示调整")),
 		"",
-		sectionStyle.Render("其他"),
-		normalStyle.Render("  ?               显示/隐藏帮助"),
-		normalStyle.Render("  Esc             关闭帮助或退出程序"),
-		normalStyle.Render("  Ctrl+C          退出程序"),
+		sectionStyle.Render(i18n.T("其他")),
+		normalStyle.Render(i18n.T("  Ctrl+F          全局搜索提供商、方案和每日消费")),
+		normalStyle.Render(i18n.T("  f               提示模式：按字母激活标签或列表项")),
+		normalStyle.Render(i18n.T("  :               按提供商 ID 或序号跳转")),
+		normalStyle.Render(i18n.T("  P               计划：对比 --plan 文件并选择要应用的变更")),
+		normalStyle.Render(i18n.T("  R               回滚到上次批量操作之前")),
+		normalStyle.Render(i18n.T("  Ctrl+A          切换账户")),
+		normalStyle.Render(i18n.T("  ?               显示/隐藏帮助")),
+		normalStyle.Render(i18n.T("  Ctrl+D          显示/隐藏诊断信息")),
+		normalStyle.Render(i18n.T("  E               查看最近一次 API 错误的完整响应")),
+		normalStyle.Render(i18n.T("  Ctrl+L          请求日志（需要 --debug）")),
+		normalStyle.Render(i18n.T("  Ctrl+P          显示/隐藏性能信息（渲染耗时、消息速率、协程数）")),
+		normalStyle.Render(i18n.T("  Esc             关闭对话框、清除筛选或焦点，连按两次退出")),
+		normalStyle.Render(i18n.T("  q / Ctrl+Q      退出程序")),
+		normalStyle.Render(i18n.T("  Ctrl+C          立即退出程序")),
 		"",
-		hintStyle.Render("按 Esc 或 ? 键关闭此帮助"),
+		hintStyle.Render(i18n.T("按 Esc 或 ? 键关闭此帮助")),
 	}
 
 	content := strings.Join(helpContent, "\n")
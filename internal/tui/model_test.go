@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/api/apitest"
+)
+
+func newFake() *apitest.Fake {
+	fast := api.ProviderAlternative{ID: 11, DisplayName: "Fast", RateMultiplier: 1}
+	cheap := api.ProviderAlternative{ID: 12, DisplayName: "Cheap", RateMultiplier: 0.5}
+	return &apitest.Fake{
+		Profile: api.Profile{Username: "alice", Balance: 42, BalancePreference: "subscription_first"},
+		Providers: api.ProvidersResponse{Providers: []api.ProviderBucket{
+			{Provider: api.ProviderInfo{ID: 1, DisplayName: "Claude"}, RateMultiplier: 1, IsDefault: true},
+		}},
+		Alternatives: map[int][]api.AlternativeOption{
+			1: {{IsSelf: true, Alternative: fast}, {Alternative: cheap}},
+		},
+		Selections: map[int]api.ProviderSelection{
+			1: {ProviderID: 1, SelectedAlternativeID: fast.ID, SelectedAlternative: fast},
+		},
+	}
+}
+
+func TestProfileLoadsFromService(t *testing.T) {
+	fake := newFake()
+	m := NewModel(fake)
+	m.Update(loadProfileCmd(context.Background(), fake)())
+
+	if m.loadingProfile {
+		t.Error("profile still loading after profileLoadedMsg")
+	}
+	if m.profile == nil || m.profile.Username != "alice" {
+		t.Fatalf("profile = %+v, want alice", m.profile)
+	}
+	if fake.Calls["GetProfile"] != 1 {
+		t.Errorf("GetProfile called %d times, want 1", fake.Calls["GetProfile"])
+	}
+}
+
+func TestProfileLoadFailure(t *testing.T) {
+	fake := newFake()
+	fake.Err = errors.New("boom")
+	m := NewModel(fake)
+	m.Update(loadProfileCmd(context.Background(), fake)())
+
+	if m.loadingProfile {
+		t.Error("profile still loading after the load failed")
+	}
+	if m.profile != nil {
+		t.Errorf("profile = %+v, want none", m.profile)
+	}
+	if m.profileErr == nil {
+		t.Error("profile error not recorded")
+	}
+}
+
+func TestSwitchUpdatesSelection(t *testing.T) {
+	fake := newFake()
+	m := NewModel(fake)
+	ctx := context.Background()
+	m.Update(loadProvidersCmd(ctx, fake)())
+	m.Update(loadAlternativesCmd(ctx, fake, 1)())
+	m.Update(loadSelectionCmd(ctx, fake, 1)())
+
+	m.Update(switchProviderCmd(ctx, fake, 1, 12)())
+
+	state := m.providerData[1]
+	if state == nil || state.selection == nil {
+		t.Fatal("no selection for provider 1 after the switch")
+	}
+	if got := state.selection.SelectedAlternativeID; got != 12 {
+		t.Errorf("selected alternative = %d, want 12", got)
+	}
+	if state.switching {
+		t.Error("provider still marked as switching")
+	}
+	if got := fake.Selections[1].SelectedAlternativeID; got != 12 {
+		t.Errorf("service selection = %d, want 12", got)
+	}
+	if m.summary == nil || len(m.summary.items) != 1 || m.summary.items[0].new != "Cheap" {
+		t.Errorf("summary = %+v, want one change to Cheap", m.summary)
+	}
+}
+
+func TestSwitchToUnknownAlternativeFails(t *testing.T) {
+	fake := newFake()
+	m := NewModel(fake)
+	ctx := context.Background()
+	m.Update(loadProvidersCmd(ctx, fake)())
+	m.Update(loadSelectionCmd(ctx, fake, 1)())
+
+	m.Update(switchProviderCmd(ctx, fake, 1, 99)())
+
+	state := m.providerData[1]
+	if state.lastError == nil {
+		t.Error("switch error not recorded")
+	}
+	if got := state.selection.SelectedAlternativeID; got != 11 {
+		t.Errorf("selected alternative = %d, want 11 unchanged", got)
+	}
+	if m.summary == nil || len(m.summary.items) != 1 || m.summary.items[0].err == nil {
+		t.Errorf("summary = %+v, want the failed change", m.summary)
+	}
+}
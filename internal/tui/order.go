@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"yescode-tui/internal/api"
+)
+
+// WithProviderOrder pins providers to the front of the list in the given order.
+// Each key is a provider ID or display name (case-insensitive); providers not
+// listed keep the API order after the pinned ones.
+func WithProviderOrder(keys []string) Option {
+	return func(m *Model) {
+		m.providerOrder = keys
+	}
+}
+
+// orderProviders sorts buckets in place according to keys.
+func orderProviders(buckets []api.ProviderBucket, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	rank := func(p api.ProviderInfo) int {
+		for i, key := range keys {
+			key = strings.TrimSpace(key)
+			if key == strconv.Itoa(p.ID) || strings.EqualFold(key, p.DisplayName) {
+				return i
+			}
+		}
+		return len(keys)
+	}
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return rank(buckets[i].Provider) < rank(buckets[j].Provider)
+	})
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// overlay draws dialog centered above a dimmed copy of the main view, so the
+// context stays visible behind modals.
+func (m *Model) overlay(main, dialog string) string {
+	if m.width <= 0 || m.height <= 0 {
+		return dialog
+	}
+	fg := strings.Split(dialog, "\n")
+	fgWidth := lipgloss.Width(dialog)
+	if len(fg) > m.height || fgWidth > m.width {
+		// 对话框比屏幕还大时没有可见的背景，按原样显示
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, dialog)
+	}
+	dimStyle := lipgloss.NewStyle().Foreground(mutedColor).Faint(true)
+	dim := func(s string) string {
+		if strings.TrimSpace(s) == "" {
+			return s
+		}
+		return dimStyle.Render(s)
+	}
+
+	// 背景去掉原有颜色后统一置灰，补齐到整屏大小
+	bg := strings.Split(ansi.Strip(main), "\n")
+	for len(bg) < m.height {
+		bg = append(bg, "")
+	}
+	bg = bg[:m.height]
+	for i, line := range bg {
+		line = ansi.Truncate(line, m.width, "")
+		bg[i] = line + strings.Repeat(" ", max(m.width-ansi.StringWidth(line), 0))
+	}
+
+	x := max((m.width-fgWidth)/2, 0)
+	y := max((m.height-len(fg))/2, 0)
+
+	out := make([]string, len(bg))
+	for i, line := range bg {
+		row := i - y
+		if row < 0 || row >= len(fg) {
+			out[i] = dim(line)
+			continue
+		}
+		// 切开背景行时可能截断全角字符，用空格补齐保持对齐
+		left := ansi.Truncate(line, x, "")
+		left += strings.Repeat(" ", x-ansi.StringWidth(left))
+		right := ansi.TruncateLeft(line, x+fgWidth, "")
+		right = strings.Repeat(" ", max(m.width-x-fgWidth-ansi.StringWidth(right), 0)) + right
+		item := fg[row] + strings.Repeat(" ", max(fgWidth-lipgloss.Width(fg[row]), 0))
+		out[i] = dim(left) + item + dim(right)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+// providersLoadAhead starts loading the next page of providers when the
+// cursor comes this close to the end of the list.
+const providersLoadAhead = 3
+
+type providersPageLoadedMsg struct {
+	response *api.ProvidersResponse
+}
+
+// setProvidersNext records where the provider list continues after resp
+// answered page.
+func (m *Model) setProvidersNext(resp *api.ProvidersResponse, page api.Page) {
+	m.providersNext = nil
+	if resp.HasMore {
+		next := resp.Next(page)
+		m.providersNext = &next
+	}
+}
+
+// loadMoreProviders fetches the next page of providers once the cursor
+// nears the end of the list, so large catalogs load as they are browsed.
+func (m *Model) loadMoreProviders() tea.Cmd {
+	if m.providersNext == nil || m.loadingMoreProviders || m.loadingProviders {
+		return nil
+	}
+	if m.providerIdx < len(m.providers)-providersLoadAhead {
+		return nil
+	}
+	return m.loadNextProvidersPage()
+}
+
+// loadNextProvidersPage fetches the next page of providers wherever the
+// cursor is, e.g. to find a provider a jump asks for.
+func (m *Model) loadNextProvidersPage() tea.Cmd {
+	if m.providersNext == nil || m.loadingMoreProviders || m.loadingProviders {
+		return nil
+	}
+	m.loadingMoreProviders = true
+	return loadProvidersPageCmd(m.tabContext(), m.client, *m.providersNext)
+}
+
+// handleProvidersPageLoaded appends a later page to the provider list.
+func (m *Model) handleProvidersPageLoaded(msg providersPageLoadedMsg) []tea.Cmd {
+	m.loadingMoreProviders = false
+	if m.providersNext == nil {
+		// 等待期间列表已重新加载，这一页已过时
+		return nil
+	}
+	n := len(m.allProviders)
+	m.allProviders = api.AppendProviders(m.allProviders, msg.response.Providers)
+	m.setProvidersNext(msg.response, *m.providersNext)
+	if len(m.allProviders) == n {
+		// 这一页没有新的提供商，服务端多半忽略了分页参数，不再继续加载
+		m.providersNext = nil
+	}
+	var cmds []tea.Cmd
+	if m.rebuildProviders() && m.loadProviderDetailsOnMove() {
+		cmds = append(cmds, m.queueProviderDetailLoad(m.currentProviderID()))
+	}
+	if m.pendingGoto != 0 {
+		// 跳转的目标可能在这一页，找不到时继续加载下一页
+		return append(cmds, m.startPrefetch(), m.applyGoto())
+	}
+	// 光标仍停在末尾时继续加载下一页
+	return append(cmds, m.startPrefetch(), m.loadMoreProviders())
+}
+
+// moreProvidersFooter tells that the list continues below the loaded pages.
+func (m *Model) moreProvidersFooter() []string {
+	switch {
+	case m.providersNext == nil:
+		return nil
+	case m.loadingMoreProviders:
+		return []string{helpStyle.Render(i18n.Tf("加载更多提供商... %s", m.spinner.View()))}
+	}
+	return []string{helpStyle.Render(i18n.Tf("%s 还有更多提供商，移到列表底部时加载", glyphs.More))}
+}
+
+func loadProvidersPageCmd(ctx context.Context, client api.Service, page api.Page) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.GetProvidersPage(ctx, page)
+		if err != nil {
+			return errMsg{target: "providers_page", err: err}
+		}
+		return providersPageLoadedMsg{response: resp}
+	}
+}
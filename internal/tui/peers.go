@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/peer"
+)
+
+const (
+	// peerPollInterval is how often the board is checked for changes made by
+	// other instances.
+	peerPollInterval = time.Second
+	// peerClaimTTL bounds a claim left by an instance that quit mid-switch.
+	peerClaimTTL = 30 * time.Second
+)
+
+type peerTickMsg struct{}
+
+type peerEventsMsg struct {
+	events []peer.Event
+}
+
+// WithPeers coordinates with other instances through board: switches are
+// claimed before they start so two terminals can't change the same provider
+// at once, and changes made elsewhere show up here within a second.
+func WithPeers(board *peer.Board) Option {
+	return func(m *Model) {
+		m.peers = board
+	}
+}
+
+func peerTickAfter(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return peerTickMsg{} })
+}
+
+// startPeerPoll schedules the first board check, if coordination is enabled.
+func (m *Model) startPeerPoll() tea.Cmd {
+	if m.peers == nil {
+		return nil
+	}
+	return peerTickAfter(peerPollInterval)
+}
+
+// handlePeerTick reads the board in the background. A board that can't be
+// read is skipped until the next tick rather than reported every second.
+func (m *Model) handlePeerTick() tea.Cmd {
+	board := m.peers
+	return func() tea.Msg {
+		events, _ := board.Poll()
+		return peerEventsMsg{events: events}
+	}
+}
+
+// handlePeerEvents applies changes published by other instances to every
+// account they concern and reloads what went stale.
+func (m *Model) handlePeerEvents(msg peerEventsMsg) tea.Cmd {
+	cmds := []tea.Cmd{peerTickAfter(peerPollInterval)}
+	for _, ev := range msg.events {
+		for i, a := range m.accounts {
+			if a.data == nil || a.data.profile == nil || history.AccountOf(a.data.profile) != ev.Account {
+				continue
+			}
+			cmds = append(cmds, m.applyPeerEvent(i, ev))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) applyPeerEvent(account int, ev peer.Event) tea.Cmd {
+	data := m.accounts[account].data
+	active := account == m.accountIdx
+	var cmds []tea.Cmd
+	switch ev.Kind {
+	case peer.KindSwitch:
+		// 只重新加载已经缓存过的提供商，其余的在打开时自然是最新的
+		if _, ok := data.providerData[ev.ProviderID]; ok {
+			cmds = append(cmds, m.scope(account, loadSelectionCmd(m.ctx, data.client, ev.ProviderID)))
+		}
+		if active {
+			cmds = append(cmds, m.showToast(toastInfo, i18n.Tf("另一终端已将 %s 切换到 %s", m.providerDisplayName(ev.ProviderID), ev.Name)))
+		}
+	case peer.KindPreference:
+		data.profile.BalancePreference = ev.Name
+		if active {
+			m.syncBalancePreferenceIdx()
+			cmds = append(cmds, m.showToast(toastInfo, i18n.Tf("另一终端已将余额偏好切换为 %s", describePreference(ev.Name))))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// claimPeer reserves target before a switch starts. When another instance
+// holds it, the switch must not start and the returned command explains why;
+// busyFormat takes that instance's PID. A board that can't be written
+// doesn't block switching.
+func (m *Model) claimPeer(target, busyFormat string) (tea.Cmd, bool) {
+	owner, err := m.peers.Claim(m.recentAccount(), target, peerClaimTTL)
+	if errors.Is(err, peer.ErrBusy) {
+		return m.showToast(toastWarning, i18n.Tf(busyFormat, owner)), false
+	}
+	return nil, true
+}
+
+// releasePeer drops the claim on target once its switch has finished,
+// publishing ev to the other instances when the switch succeeded.
+func (m *Model) releasePeer(target string, ev *peer.Event) tea.Cmd {
+	if m.peers == nil {
+		return nil
+	}
+	board, account := m.peers, m.recentAccount()
+	return m.persist(func() tea.Msg {
+		board.Release(account, target, ev)
+		return nil
+	})
+}
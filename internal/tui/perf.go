@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"yescode-tui/internal/i18n"
+)
+
+// perfWindow is the span over which the message rate is counted.
+const perfWindow = time.Second
+
+type perfTickMsg struct{}
+
+func perfTick() tea.Cmd {
+	return tea.Tick(perfWindow, func(time.Time) tea.Msg { return perfTickMsg{} })
+}
+
+// perfStats backs the Ctrl+P performance overlay.
+type perfStats struct {
+	shown bool
+	// frames counts rendered views since the overlay was shown.
+	frames     int
+	lastRender time.Duration
+	avgRender  time.Duration
+	maxRender  time.Duration
+	// messages are the arrival times of the messages within perfWindow.
+	messages []time.Time
+}
+
+// togglePerf shows or hides the performance overlay, starting the tick that
+// keeps its numbers current while idle.
+func (m *Model) togglePerf() tea.Cmd {
+	if m.perf.shown {
+		m.perf = perfStats{}
+		return nil
+	}
+	m.perf = perfStats{shown: true}
+	return perfTick()
+}
+
+// handlePerfTick keeps the overlay ticking while it is shown.
+func (m *Model) handlePerfTick() tea.Cmd {
+	if !m.perf.shown {
+		return nil
+	}
+	return perfTick()
+}
+
+// noteMessage counts msg towards the message rate. The overlay's own ticks
+// don't count.
+func (m *Model) noteMessage(msg tea.Msg) {
+	if !m.perf.shown {
+		return
+	}
+	if _, ok := msg.(perfTickMsg); ok {
+		return
+	}
+	now := time.Now()
+	m.perf.messages = append(m.perf.messages, now)
+	m.perf.trim(now)
+}
+
+// trim drops the messages older than perfWindow.
+func (p *perfStats) trim(now time.Time) {
+	i := 0
+	for i < len(p.messages) && now.Sub(p.messages[i]) > perfWindow {
+		i++
+	}
+	p.messages = p.messages[i:]
+}
+
+// noteRender records how long one view took to render.
+func (p *perfStats) noteRender(d time.Duration) {
+	p.frames++
+	p.lastRender = d
+	p.maxRender = max(p.maxRender, d)
+	if p.frames == 1 {
+		p.avgRender = d
+	} else {
+		// 指数滑动平均，最近约 10 帧权重最大
+		p.avgRender += (d - p.avgRender) / 10
+	}
+}
+
+// renderPerf draws the overlay in the bottom-right corner of view, above the
+// status bar.
+func (m *Model) renderPerf(view string) string {
+	p := &m.perf
+	p.trim(time.Now())
+	ms := func(d time.Duration) string {
+		return fmt.Sprintf("%.2fms", float64(d.Microseconds())/1000)
+	}
+	labelStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	row := func(label, value string) string {
+		return labelStyle.Render(padRight(i18n.T(label), 10)) + padLeft(value, 9)
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(mutedColor).
+		Padding(0, 1).
+		Render(strings.Join([]string{
+			row("渲染", ms(p.lastRender)),
+			row("平均渲染", ms(p.avgRender)),
+			row("最长渲染", ms(p.maxRender)),
+			row("消息/秒", fmt.Sprint(len(p.messages))),
+			row("协程", fmt.Sprint(runtime.NumGoroutine())),
+		}, "\n"))
+
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	lines := strings.Split(view, "\n")
+	if m.width <= 0 || boxWidth > m.width || len(boxLines)+1 > len(lines) {
+		return view
+	}
+	x := m.width - boxWidth - 1
+	// 留出最后一行状态栏
+	y := len(lines) - len(boxLines) - 1
+	for i, row := range boxLines {
+		line := lines[y+i]
+		left := ansi.Truncate(line, x, "")
+		left += strings.Repeat(" ", x-ansi.StringWidth(left))
+		right := ansi.TruncateLeft(line, x+boxWidth, "")
+		lines[y+i] = left + row + right
+	}
+	return strings.Join(lines, "\n")
+}
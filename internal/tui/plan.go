@@ -0,0 +1,359 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/snapshot"
+)
+
+// planState drives the "计划" screen: the changes needed to reach a
+// desired-state file, each of which can be deselected before applying.
+type planState struct {
+	title   string
+	path    string
+	live    *snapshot.Snapshot // state the plan was computed against
+	loading bool
+	err     error
+	items   []planItem
+	cursor  int
+	pending int
+	applied bool
+}
+
+// planItem is one pending change. providerID is 0 for the balance preference.
+type planItem struct {
+	label      string
+	from       string
+	to         string
+	providerID int
+	toID       int
+	preference string
+	selected   bool
+	done       bool
+	err        error
+}
+
+type planLoadedMsg struct {
+	plan *snapshot.Plan
+	live *snapshot.Snapshot
+	err  error
+}
+
+type planStepMsg struct {
+	index      int
+	selection  *api.ProviderSelection
+	preference string
+	err        error
+}
+
+// WithPlanFile loads a desired-state file (as written by `yc snapshot`) and
+// opens the plan screen on start; P reopens it against the live state.
+func WithPlanFile(path string) Option {
+	return func(m *Model) {
+		m.planFile = path
+	}
+}
+
+// openPlanFile shows the plan screen for the --plan file.
+func (m *Model) openPlanFile() tea.Cmd {
+	if m.planFile == "" {
+		m.status = i18n.T("未加载期望状态文件，请使用 --plan 指定")
+		return clearStatusAfter(statusClearDelay)
+	}
+	return m.openPlan(i18n.T("计划"), m.planFile, readStateFile(m.planFile))
+}
+
+// openPlan shows the plan screen, computing it from the desired state that
+// read returns against the live state; path shows where it came from.
+func (m *Model) openPlan(title, path string, read func() (*snapshot.Snapshot, error)) tea.Cmd {
+	m.plan = &planState{title: title, path: path, loading: true}
+	return loadPlanCmd(m.ctx, m.client, read)
+}
+
+// readStateFile reads the desired-state file at path.
+func readStateFile(path string) func() (*snapshot.Snapshot, error) {
+	return func() (*snapshot.Snapshot, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		desired, err := snapshot.Decode(f)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("解析状态文件失败: %w"), err)
+		}
+		return desired, nil
+	}
+}
+
+func (m *Model) handlePlanLoaded(msg planLoadedMsg) {
+	if m.plan == nil {
+		return
+	}
+	m.plan.loading = false
+	if msg.err != nil {
+		m.plan.err = msg.err
+		return
+	}
+	m.plan.live = msg.live
+	var items []planItem
+	if p := msg.plan.Preference; p != nil {
+		items = append(items, planItem{
+			label:      i18n.T("余额使用偏好"),
+			from:       describePreference(p.From),
+			to:         describePreference(p.To),
+			preference: p.To,
+			selected:   true,
+		})
+	}
+	for _, s := range msg.plan.Switches {
+		items = append(items, planItem{
+			label:      s.Provider,
+			from:       s.From,
+			to:         s.To,
+			providerID: s.ProviderID,
+			toID:       s.ToID,
+			selected:   true,
+		})
+	}
+	m.plan.items = items
+}
+
+// handlePlanKey handles keys while the plan screen is open.
+func (m *Model) handlePlanKey(msg tea.KeyMsg) tea.Cmd {
+	state := m.plan
+	switch msg.String() {
+	case "esc":
+		// 执行中不允许关闭，避免遗漏结果；完成后结果转入操作摘要
+		if !state.applied {
+			m.plan = nil
+		}
+	case "up", "k":
+		state.cursor = clampIndex(state.cursor-1, len(state.items))
+	case "down", "j":
+		state.cursor = clampIndex(state.cursor+1, len(state.items))
+	case " ":
+		if !state.applied && len(state.items) > 0 {
+			state.items[state.cursor].selected = !state.items[state.cursor].selected
+		}
+	case "enter":
+		if !state.applied && !state.loading && state.err == nil {
+			return m.applyPlan()
+		}
+	}
+	return nil
+}
+
+// planButtons returns the dialog's actions for its current stage; none while
+// changes are being applied.
+func (m *Model) planButtons() []button {
+	state := m.plan
+	switch {
+	case state.applied:
+		return nil
+	case state.loading || state.err != nil || len(state.items) == 0:
+		return []button{{label: "关闭", key: "esc"}}
+	}
+	selected := 0
+	for _, item := range state.items {
+		if item.selected {
+			selected++
+		}
+	}
+	return []button{
+		{label: i18n.Tf("应用 %d 项", selected), key: "enter", kind: buttonDanger},
+		{label: "取消", key: "esc"},
+	}
+}
+
+// applyPlan saves a rollback point and runs every selected change.
+func (m *Model) applyPlan() tea.Cmd {
+	cmds := []tea.Cmd{m.saveRollback(m.plan.live)}
+	for i, item := range m.plan.items {
+		if !item.selected {
+			continue
+		}
+		m.plan.pending++
+		if item.providerID == 0 {
+			m.preferenceSwitching = true
+			cmds = append(cmds, planPreferenceCmd(m.ctx, m.client, i, item.preference))
+			continue
+		}
+		m.ensureProviderState(item.providerID).switching = true
+		cmds = append(cmds, planSwitchCmd(m.ctx, m.client, i, item.providerID, item.toID))
+	}
+	if m.plan.pending == 0 {
+		return nil
+	}
+	m.plan.applied = true
+	return tea.Batch(cmds...)
+}
+
+// handlePlanStep records one change's outcome and updates the cached state.
+func (m *Model) handlePlanStep(msg planStepMsg) tea.Cmd {
+	if m.plan == nil || msg.index >= len(m.plan.items) {
+		return nil
+	}
+	item := &m.plan.items[msg.index]
+	item.done, item.err = true, msg.err
+	m.plan.pending--
+	cmd := m.applyPlanStep(*item, msg)
+	if m.plan.pending == 0 {
+		m.finishPlan()
+	}
+	return cmd
+}
+
+// applyPlanStep updates the cached profile or selection with one change's
+// outcome.
+func (m *Model) applyPlanStep(item planItem, msg planStepMsg) tea.Cmd {
+	if item.providerID == 0 {
+		m.preferenceSwitching = false
+		if msg.err == nil && m.profile != nil {
+			m.profile.BalancePreference = msg.preference
+			m.syncBalancePreferenceIdx()
+		}
+		return nil
+	}
+	state := m.ensureProviderState(item.providerID)
+	state.switching = false
+	var cmd tea.Cmd
+	if msg.err == nil {
+		cmd = m.recordRecent(item.providerID, selectedAlternativeID(state.selection), msg.selection.SelectedAlternativeID)
+		state.selection = msg.selection
+		state.selectionLoaded = true
+		state.lastError = nil
+		m.syncAltIdx(item.providerID)
+	}
+	return cmd
+}
+
+// finishPlan closes the plan screen once every change has answered and
+// lists the outcomes in the summary.
+func (m *Model) finishPlan() {
+	var items []summaryItem
+	for _, item := range m.plan.items {
+		if item.selected {
+			items = append(items, summaryItem{label: item.label, old: item.from, new: item.to, err: item.err})
+		}
+	}
+	title := m.plan.title
+	m.plan = nil
+	m.showSummary(title, items...)
+}
+
+func (m *Model) renderPlanDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(errorColor)
+
+	state := m.plan
+	lines := []string{titleStyle.Render(state.title), helpStyle.Render(truncate(state.path, 56)), ""}
+
+	switch {
+	case state.loading:
+		lines = append(lines, i18n.Tf("正在对比当前状态... %s", m.spinner.View()))
+	case state.err != nil:
+		lines = append(lines, errorStyle.Render(fmt.Sprintf("%s %v", glyphs.Warning, state.err)))
+	case len(state.items) == 0:
+		lines = append(lines, i18n.T("当前状态已与文件一致，无需变更"))
+	default:
+		for i, item := range state.items {
+			lines = append(lines, m.renderPlanItem(item, i == state.cursor)...)
+		}
+	}
+
+	lines = append(lines, "")
+	switch {
+	case state.applied:
+		lines = append(lines, hintStyle.Render(i18n.T("正在应用，请稍候...")))
+	case state.loading || state.err != nil || len(state.items) == 0:
+	default:
+		lines = append(lines, hintStyle.Render(i18n.T("↑↓ 移动 · 空格 选择/取消")))
+	}
+	if buttons := m.planButtons(); len(buttons) > 0 {
+		lines = append(lines, renderButtons(buttons...))
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(64)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderPlanItem draws one change as a colored diff with its checkbox or result.
+func (m *Model) renderPlanItem(item planItem, cursor bool) []string {
+	removed := lipgloss.NewStyle().Foreground(errorColor)
+	added := lipgloss.NewStyle().Foreground(successColor)
+
+	status := checkbox(item.selected)
+	switch {
+	case item.done && item.err != nil:
+		status = removed.Render(glyphs.Warning)
+	case item.done:
+		status = added.Render(glyphs.Check)
+	case m.plan.applied && item.selected:
+		status = m.spinner.View()
+	}
+	lines := []string{cursorPrefix(cursor) + status + " " + item.label}
+	if !item.selected {
+		return append(lines, helpStyle.Render(i18n.Tf("      %s → %s（跳过）", item.from, item.to)))
+	}
+	lines = append(lines,
+		removed.Render("      - "+item.from),
+		added.Render("      + "+item.to))
+	if item.err != nil {
+		lines = append(lines, removed.Render(truncate(fmt.Sprintf("      %v", item.err), 56)))
+	}
+	return lines
+}
+
+// checkbox renders a selection box.
+func checkbox(selected bool) string {
+	if selected {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+func loadPlanCmd(ctx context.Context, client api.Service, read func() (*snapshot.Snapshot, error)) tea.Cmd {
+	return func() tea.Msg {
+		desired, err := read()
+		if err != nil {
+			return planLoadedMsg{err: err}
+		}
+		live, err := snapshot.Capture(ctx, client)
+		if err != nil {
+			return planLoadedMsg{err: err}
+		}
+		plan, err := snapshot.NewPlan(ctx, client, desired, live)
+		return planLoadedMsg{plan: plan, live: live, err: err}
+	}
+}
+
+func planSwitchCmd(ctx context.Context, client api.Service, index, providerID, alternativeID int) tea.Cmd {
+	return func() tea.Msg {
+		selection, err := client.SwitchProvider(ctx, providerID, alternativeID)
+		return planStepMsg{index: index, selection: selection, err: err}
+	}
+}
+
+func planPreferenceCmd(ctx context.Context, client api.Service, index int, preference string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.UpdateBalancePreference(ctx, preference)
+		if err != nil {
+			return planStepMsg{index: index, err: err}
+		}
+		return planStepMsg{index: index, preference: resp.BalancePreference}
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// prefetchConcurrency bounds how many providers load their details at once
+// during the background prefetch.
+const prefetchConcurrency = 3
+
+// prefetcher loads every provider's details in the background once the
+// provider list arrives, so moving the cursor finds them already loaded.
+type prefetcher struct {
+	queue   []int
+	running map[int]bool
+}
+
+// startPrefetch queues the details of every provider but the current one,
+// which loads in the foreground. Low-bandwidth mode skips the prefetch.
+func (m *Model) startPrefetch() tea.Cmd {
+	if !m.loadProviderDetailsOnMove() {
+		return nil
+	}
+	if m.prefetch.running == nil {
+		m.prefetch.running = make(map[int]bool)
+	}
+	m.prefetch.queue = m.prefetch.queue[:0]
+	for _, bucket := range m.providers {
+		id := bucket.Provider.ID
+		if id != m.currentProviderID() && !m.prefetch.running[id] {
+			m.prefetch.queue = append(m.prefetch.queue, id)
+		}
+	}
+	return m.prefetchNext()
+}
+
+// prefetchNext starts queued loads up to the concurrency limit. It holds off
+// while rate limited.
+func (m *Model) prefetchNext() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(m.prefetch.running) < prefetchConcurrency && len(m.prefetch.queue) > 0 && !m.rateLimited() {
+		id := m.prefetch.queue[0]
+		m.prefetch.queue = m.prefetch.queue[1:]
+		if cmd := m.loadProviderDetails(id); cmd != nil {
+			m.prefetch.running[id] = true
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// prefetchSettled moves the prefetch on once both loads of providerID have
+// ended.
+func (m *Model) prefetchSettled(providerID int) tea.Cmd {
+	if !m.prefetch.running[providerID] {
+		return nil
+	}
+	state := m.ensureProviderState(providerID)
+	if state.loadingAlternatives || state.loadingSelection {
+		return nil
+	}
+	delete(m.prefetch.running, providerID)
+	return m.prefetchNext()
+}
+
+// prefetching reports whether a load of providerID runs in the background,
+// so its failure is kept for the provider's panel instead of interrupting
+// the user.
+func (m *Model) prefetching(providerID int) bool {
+	return m.prefetch.running[providerID] && providerID != m.currentProviderID()
+}
@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"yescode-tui/internal/i18n"
+)
+
+// profileFieldKey identifies a value on the profile tab, so actions can be
+// attached to a field regardless of where it ends up on screen.
+type profileFieldKey string
+
+const (
+	fieldUsername            profileFieldKey = "username"
+	fieldEmail               profileFieldKey = "email"
+	fieldSubscriptionBalance profileFieldKey = "subscription_balance"
+	fieldPayAsYouGoBalance   profileFieldKey = "pay_as_you_go_balance"
+	fieldBalance             profileFieldKey = "balance"
+	fieldBalancePreference   profileFieldKey = "balance_preference"
+	fieldPlan                profileFieldKey = "plan"
+	fieldExpiry              profileFieldKey = "expiry"
+	fieldDailyBalance        profileFieldKey = "daily_balance"
+	fieldWeekSpend           profileFieldKey = "week_spend"
+	fieldMonthSpend          profileFieldKey = "month_spend"
+)
+
+// profileField is one selectable row of the profile tab, collected while the
+// tab renders.
+type profileField struct {
+	key   profileFieldKey
+	value string // 复制到剪贴板的内容
+	row   string // 渲染后的整行
+
+	offset int // 在折行后的内容中的起始行
+	height int
+}
+
+// profileCursor is the field cursor of the profile tab. ↑/↓ move it between
+// fields instead of scrolling line by line.
+type profileCursor struct {
+	idx    int
+	fields []profileField
+	// reveal scrolls the viewport to the cursor on the next render; it is
+	// set only by cursor moves so scrolling with the wheel is not undone.
+	reveal bool
+}
+
+// fieldActions are the rows Enter acts on, with the hint shown next to the
+// selected row.
+var fieldActions = map[profileFieldKey]string{
+	fieldBalancePreference: "Enter 修改偏好",
+	fieldPlan:              "Enter 在控制台查看订阅计划",
+	fieldPayAsYouGoBalance: "Enter 充值",
+}
+
+// fieldRow renders text as the row of field key, highlighted when the
+// cursor is on it. text keeps the two-space indent of the other rows.
+func (m *Model) fieldRow(key profileFieldKey, value, text string) string {
+	c := &m.profileCursor
+	if len(c.fields) == c.idx {
+		text = selectedItemStyle.Render(cursorPrefix(true) + strings.TrimPrefix(text, "  "))
+		if hint, ok := fieldActions[key]; ok {
+			text += helpStyle.Render("  " + i18n.T(hint))
+		}
+	}
+	c.fields = append(c.fields, profileField{key: key, value: value, row: text})
+	return text
+}
+
+// selectedField returns the field under the cursor, if any.
+func (m *Model) selectedField() (profileField, bool) {
+	c := &m.profileCursor
+	if c.idx < 0 || c.idx >= len(c.fields) {
+		return profileField{}, false
+	}
+	return c.fields[c.idx], true
+}
+
+// moveProfileCursor moves the field cursor by delta. It reports false when
+// the tab has no fields, e.g. while the profile is loading.
+func (m *Model) moveProfileCursor(delta int) bool {
+	c := &m.profileCursor
+	if len(c.fields) == 0 {
+		return false
+	}
+	c.idx = clampIndex(c.idx+delta, len(c.fields))
+	c.reveal = true
+	return true
+}
+
+// placeProfileFields records where each field landed once lines are wrapped
+// to width, and scrolls the viewport to the cursor after a move.
+func (m *Model) placeProfileFields(lines []string, width int) {
+	c := &m.profileCursor
+	offset, next := 0, 0
+	for _, line := range lines {
+		height := lipgloss.Height(softWrap(line, width))
+		if next < len(c.fields) && line == c.fields[next].row {
+			c.fields[next].offset, c.fields[next].height = offset, height
+			next++
+		}
+		offset += height
+	}
+	if len(c.fields) > 0 {
+		c.idx = min(c.idx, len(c.fields)-1)
+	}
+
+	if !c.reveal {
+		return
+	}
+	c.reveal = false
+	field, ok := m.selectedField()
+	if !ok {
+		return
+	}
+	vp := &m.profileViewport
+	switch {
+	case c.idx == 0:
+		// 第一个字段上方还有标题等内容，一并显示
+		vp.GotoTop()
+	case field.offset < vp.YOffset:
+		vp.SetYOffset(field.offset)
+	case field.offset+field.height > vp.YOffset+vp.Height:
+		vp.SetYOffset(field.offset + field.height - vp.Height)
+	}
+}
+
+// clickProfileField moves the cursor to the field at row of the viewport.
+func (m *Model) clickProfileField(row int) {
+	row += m.profileViewport.YOffset
+	c := &m.profileCursor
+	for i, f := range c.fields {
+		if row >= f.offset && row < f.offset+f.height {
+			c.idx = i
+			return
+		}
+	}
+}
+
+// copyProfileField copies the value under the cursor to the clipboard.
+func (m *Model) copyProfileField() tea.Cmd {
+	field, ok := m.selectedField()
+	if !ok || field.value == "" {
+		return nil
+	}
+	// 通过 OSC 52 写入剪贴板
+	termenv.Copy(field.value)
+	return m.showToast(toastSuccess, i18n.Tf("已复制：%s", field.value))
+}
+
+// consoleOpenedMsg reports whether the console could be opened in a browser.
+type consoleOpenedMsg struct {
+	link string
+	err  error
+}
+
+// runFieldAction performs the inline action of the selected row, saving a
+// trip to another tab or the console for the common tasks.
+func (m *Model) runFieldAction() tea.Cmd {
+	field, ok := m.selectedField()
+	if !ok {
+		return nil
+	}
+	switch field.key {
+	case fieldBalancePreference:
+		return m.switchTab(tabBalancePreference)
+	case fieldPlan:
+		link := m.client.BaseURL()
+		return func() tea.Msg {
+			return consoleOpenedMsg{link: link, err: openBrowser(link)}
+		}
+	case fieldPayAsYouGoBalance:
+		link := m.client.BaseURL()
+		termenv.Copy(link)
+		return m.showToast(toastInfo, i18n.Tf("在控制台充值按需余额：%s（已复制）", link))
+	}
+	return nil
+}
+
+// handleConsoleOpened falls back to the clipboard when no browser could be
+// started, e.g. over SSH.
+func (m *Model) handleConsoleOpened(msg consoleOpenedMsg) tea.Cmd {
+	if msg.err != nil {
+		termenv.Copy(msg.link)
+		return m.showToast(toastWarning, i18n.Tf("无法打开浏览器（%v），链接已复制到剪贴板", msg.err))
+	}
+	return m.showToast(toastSuccess, i18n.T("已在浏览器中打开控制台"))
+}
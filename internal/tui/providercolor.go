@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// providerTypeColors and providerFallbackColors come from the active theme.
+// Fallback colors are assigned to other provider types by hash, so a type
+// keeps its color across runs.
+var (
+	providerTypeColors     map[string]lipgloss.Color
+	providerFallbackColors []lipgloss.Color
+)
+
+// providerFamily normalizes a provider type to a family key.
+func providerFamily(providerType string) string {
+	t := strings.ToLower(strings.TrimSpace(providerType))
+	switch {
+	case strings.Contains(t, "anthropic"), strings.Contains(t, "claude"):
+		return "anthropic"
+	case strings.Contains(t, "openai"), strings.Contains(t, "gpt"), strings.Contains(t, "codex"):
+		return "openai"
+	case strings.Contains(t, "gemini"), strings.Contains(t, "google"):
+		return "gemini"
+	}
+	return t
+}
+
+// providerColor returns the stable color of a provider type.
+func providerColor(providerType string) lipgloss.Color {
+	family := providerFamily(providerType)
+	if c, ok := providerTypeColors[family]; ok {
+		return c
+	}
+	if family == "" || len(providerFallbackColors) == 0 {
+		return mutedColor
+	}
+	h := fnv.New32a()
+	h.Write([]byte(family))
+	return providerFallbackColors[h.Sum32()%uint32(len(providerFallbackColors))]
+}
+
+// providerSwatch renders the provider icon (or a color swatch) in the type's color.
+func providerSwatch(providerType string) string {
+	icon := glyphs.Provider
+	if icon == "" {
+		icon = glyphs.Swatch
+	}
+	return lipgloss.NewStyle().Foreground(providerColor(providerType)).Render(icon)
+}
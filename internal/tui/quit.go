@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/i18n"
+)
+
+// WithConfirmQuit asks "确定退出？" in a dialog before quitting with Esc or q.
+func WithConfirmQuit() Option {
+	return func(m *Model) {
+		m.confirmQuit = true
+	}
+}
+
+// handleEscape steps back one level per press: filters, hint mode and
+// panel focus are cleared first, and quitting needs a second Esc (or q)
+// right after. Dialogs and prompts handle Esc before this is reached.
+func (m *Model) handleEscape() tea.Cmd {
+	if m.currentTab == tabProviders {
+		if cmd, ok := m.clearFilter(); ok {
+			return cmd
+		}
+	}
+	if regions := m.focusRegions(); len(regions) > 0 && m.focus != regions[0] {
+		m.focus = regions[0]
+		return nil
+	}
+	if m.quitArmed {
+		return m.requestQuit()
+	}
+	m.quitArmed = true
+	m.status = i18n.T("再按 Esc 或 q 退出")
+	return clearStatusAfter(statusClearDelay)
+}
+
+// requestQuit quits, or opens the confirmation dialog when it is enabled.
+func (m *Model) requestQuit() tea.Cmd {
+	if m.confirmQuit {
+		m.quitDialog = true
+		return nil
+	}
+	return m.quit()
+}
+
+// handleQuitDialogKey quits with Enter or y; Esc or n keeps running.
+func (m *Model) handleQuitDialogKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter", "y":
+		return m.quit()
+	case "esc", "n":
+		m.quitDialog = false
+		m.status = ""
+	}
+	return nil
+}
+
+func (m *Model) quitButtons() []button {
+	return []button{
+		{label: "退出", key: "enter", kind: buttonDanger},
+		{label: "取消", key: "esc"},
+	}
+}
+
+func (m *Model) renderQuitDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	lines := []string{
+		titleStyle.Render(i18n.T("确定退出？")),
+		"",
+		renderButtons(m.quitButtons()...),
+	}
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
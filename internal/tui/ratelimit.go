@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+// defaultRateLimitPause is how long refreshing pauses after a 429 response
+// that carries no Retry-After header.
+const defaultRateLimitPause = 30 * time.Second
+
+// rateLimit pauses automatic refreshing after the server answers 429, until
+// the time it asked for has passed.
+type rateLimit struct {
+	until  time.Time
+	target string // 暂停结束后重新加载的目标（errMsg.target），为空则不重试
+}
+
+// rateLimitTickMsg redraws the countdown and ends the pause once it's over.
+type rateLimitTickMsg struct{}
+
+func rateLimitTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return rateLimitTickMsg{} })
+}
+
+// noteRateLimit starts a pause when err is a rate-limit response, reloading
+// target when it ends. It reports whether err was one.
+func (m *Model) noteRateLimit(target string, err error) (tea.Cmd, bool) {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || !apiErr.RateLimited() {
+		return nil, false
+	}
+	wait := apiErr.RetryAfter
+	if wait <= 0 {
+		wait = defaultRateLimitPause
+	}
+	ticking := m.rateLimited()
+	until := time.Now().Add(wait)
+	if ticking && m.rateLimit.until.After(until) {
+		until = m.rateLimit.until
+	}
+	if target == "" {
+		target = m.rateLimit.target
+	}
+	m.rateLimit = rateLimit{until: until, target: target}
+	m.lastAPIError = apiErr
+	if ticking {
+		return nil, true
+	}
+	return rateLimitTick(), true
+}
+
+// rateLimited reports whether refreshing is paused.
+func (m *Model) rateLimited() bool {
+	return time.Now().Before(m.rateLimit.until)
+}
+
+// handleRateLimitTick keeps the countdown running and retries the failed
+// load when the pause ends.
+func (m *Model) handleRateLimitTick() tea.Cmd {
+	if m.rateLimited() {
+		return rateLimitTick()
+	}
+	target := m.rateLimit.target
+	m.rateLimit = rateLimit{}
+	switch target {
+	case "profile":
+		return tea.Batch(loadProfileCmd(m.ctx, m.client), m.prefetchNext())
+	case "providers":
+		m.loadingProviders = true
+		return loadProvidersCmd(m.tabContext(), m.client)
+	}
+	return m.prefetchNext()
+}
+
+// rateLimitStatus is the status bar message while refreshing is paused.
+func (m *Model) rateLimitStatus() string {
+	remaining := int(math.Ceil(time.Until(m.rateLimit.until).Seconds()))
+	return i18n.Tf("请求过于频繁，%d 秒后重试", max(remaining, 1))
+}
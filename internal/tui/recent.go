@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/recent"
+)
+
+// recentShown is how many recently used alternatives the panel lists.
+const recentShown = 3
+
+type recentFailedMsg struct {
+	err error
+}
+
+// recentEntry is a recently used alternative that is still offered.
+type recentEntry struct {
+	altIdx int
+	usage  recent.Usage
+}
+
+// WithRecent remembers switched alternatives in store and lists them atop the alternatives panel.
+func WithRecent(store *recent.Store) Option {
+	return func(m *Model) {
+		m.recent = store
+	}
+}
+
+func (m *Model) recentAccount() string {
+	if m.profile == nil {
+		return ""
+	}
+	return history.AccountOf(m.profile)
+}
+
+// recentAlternatives lists the provider's recently used alternatives, skipping
+// the active one and those no longer offered.
+func (m *Model) recentAlternatives(providerID int) []recentEntry {
+	if m.recent == nil {
+		return nil
+	}
+	state := m.ensureProviderState(providerID)
+	var entries []recentEntry
+	for _, usage := range m.recent.Recent(m.recentAccount(), providerID) {
+		if state.selection != nil && state.selection.SelectedAlternativeID == usage.AlternativeID {
+			continue
+		}
+		idx := m.findAlternativeIndex(state.alternatives, usage.AlternativeID)
+		if idx < 0 {
+			continue
+		}
+		entries = append(entries, recentEntry{altIdx: idx, usage: usage})
+		if len(entries) == recentShown {
+			break
+		}
+	}
+	return entries
+}
+
+// renderRecentSection returns the "最近使用" lines shown above the alternatives list.
+func (m *Model) renderRecentSection(providerID int) []string {
+	entries := m.recentAlternatives(providerID)
+	if len(entries) == 0 {
+		return nil
+	}
+	state := m.ensureProviderState(providerID)
+	lines := []string{helpStyle.Render(i18n.T("最近使用"))}
+	for i, entry := range entries {
+		alt := state.alternatives[entry.altIdx].Alternative
+		line := fmt.Sprintf("  %s %s ×%.2f",
+			selectedItemStyle.Render(fmt.Sprintf("alt+%d", i+1)),
+			alt.DisplayName,
+			alt.RateMultiplier,
+		)
+		if entry.usage.Count > 0 {
+			line += helpStyle.Render(i18n.Tf(" · 已选 %d 次", entry.usage.Count))
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, "")
+}
+
+// selectRecent switches to the n-th (1-based) recently used alternative.
+func (m *Model) selectRecent(n int) tea.Cmd {
+	if m.currentTab != tabProviders || len(m.providers) == 0 {
+		return nil
+	}
+	entries := m.recentAlternatives(m.currentProviderID())
+	if n < 1 || n > len(entries) {
+		return nil
+	}
+	m.focus = focusAlternatives
+	m.altIdx = entries[n-1].altIdx
+	return m.switchSelection()
+}
+
+// handleRecentKey handles alt+1..alt+3.
+func (m *Model) handleRecentKey(key string) tea.Cmd {
+	for n := 1; n <= recentShown; n++ {
+		if key == fmt.Sprintf("alt+%d", n) {
+			return m.selectRecent(n)
+		}
+	}
+	return nil
+}
+
+func (m *Model) handleRecentFailed(msg recentFailedMsg) []tea.Cmd {
+	m.err = msg.err
+	return []tea.Cmd{m.showToast(toastError, i18n.Tf("保存最近使用记录失败: %v", msg.err))}
+}
+
+// recordRecent remembers a completed switch from previousID to alternativeID.
+func (m *Model) recordRecent(providerID, previousID, alternativeID int) tea.Cmd {
+	if m.recent == nil {
+		return nil
+	}
+	store, account := m.recent, m.recentAccount()
+	return m.persist(func() tea.Msg {
+		if err := store.Record(account, providerID, previousID, alternativeID, time.Now()); err != nil {
+			return recentFailedMsg{err: err}
+		}
+		return nil
+	})
+}
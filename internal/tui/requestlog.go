@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/debuglog"
+	"yescode-tui/internal/i18n"
+)
+
+// slowRequest marks requests whose duration is highlighted in the viewer.
+const slowRequest = time.Second
+
+// requestLogState is the open request log viewer. It shows a snapshot of the
+// debug log taken when opened; r takes a new one.
+type requestLogState struct {
+	traces []api.Trace
+	cursor int
+	offset int
+}
+
+// WithDebugLog traces every API request to log and enables the Ctrl+L
+// request log viewer.
+func WithDebugLog(log *debuglog.Log) Option {
+	return func(m *Model) {
+		m.debugLog = log
+	}
+}
+
+// openRequestLog shows the latest requests with the newest selected.
+func (m *Model) openRequestLog() tea.Cmd {
+	if m.debugLog == nil {
+		m.status = i18n.T("请求日志需要使用 --debug 启动")
+		return clearStatusAfter(statusClearDelay)
+	}
+	m.requestLog = &requestLogState{}
+	m.reloadRequestLog()
+	return nil
+}
+
+func (m *Model) reloadRequestLog() {
+	m.requestLog.traces = m.debugLog.Recent()
+	m.requestLog.cursor = max(len(m.requestLog.traces)-1, 0)
+}
+
+// handleRequestLogKey moves through the requests, reloads with r and closes
+// with Esc or Ctrl+L.
+func (m *Model) handleRequestLogKey(msg tea.KeyMsg) tea.Cmd {
+	s := m.requestLog
+	switch msg.String() {
+	case "esc", "ctrl+l":
+		m.requestLog = nil
+	case "up", "k":
+		s.cursor = max(s.cursor-1, 0)
+	case "down", "j":
+		s.cursor = max(min(s.cursor+1, len(s.traces)-1), 0)
+	case "home", "g":
+		s.cursor = 0
+	case "end", "G":
+		s.cursor = max(len(s.traces)-1, 0)
+	case "r":
+		m.reloadRequestLog()
+	}
+	return nil
+}
+
+func (m *Model) requestLogButtons() []button {
+	return []button{
+		{label: "刷新", key: "r"},
+		{label: "关闭", key: "esc", kind: buttonPrimary},
+	}
+}
+
+// requestLogRows is how many requests fit in the viewer, leaving room for
+// the selected request's details.
+func (m *Model) requestLogRows() int {
+	if m.height <= 0 {
+		return 10
+	}
+	return max(m.height-22, 3)
+}
+
+func (m *Model) renderRequestLogDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	labelStyle := lipgloss.NewStyle().Foreground(mutedColor)
+
+	width := diagnosticsMaxWidth
+	if m.width > 0 && m.width-4 < width {
+		width = m.width - 4
+	}
+	// 边框 2 + 左右内边距 6
+	inner := width - 8
+
+	s := m.requestLog
+	lines := []string{
+		titleStyle.Render(i18n.T("请求日志")),
+		labelStyle.Render(truncate(i18n.Tf("写入 %s", m.debugLog.Path()), inner)),
+		"",
+	}
+	if len(s.traces) == 0 {
+		lines = append(lines, helpStyle.Render(i18n.T("还没有请求")))
+	} else {
+		rows := m.requestLogRows()
+		// 光标始终在可见范围内
+		s.offset = min(s.offset, s.cursor)
+		s.offset = max(s.offset, s.cursor-rows+1)
+		end := min(s.offset+rows, len(s.traces))
+		for i := s.offset; i < end; i++ {
+			lines = append(lines, m.requestLogRow(s.traces[i], i == s.cursor, inner))
+		}
+		if more := len(s.traces) - end; more > 0 {
+			lines = append(lines, labelStyle.Render(i18n.Tf("▼ 还有 %d 行", more)))
+		}
+		lines = append(lines, "")
+		lines = append(lines, m.requestLogDetail(s.traces[s.cursor], inner)...)
+	}
+
+	lines = append(lines, "", renderButtons(m.requestLogButtons()...))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(width)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
+
+// requestLogRow is one request: time, method, path, status and duration.
+func (m *Model) requestLogRow(t api.Trace, selected bool, width int) string {
+	status := "---"
+	statusColor := errorColor
+	if t.StatusCode != 0 {
+		status = fmt.Sprint(t.StatusCode)
+		switch {
+		case t.StatusCode < 300 || t.StatusCode == 304:
+			statusColor = successColor
+		case t.StatusCode < 500:
+			statusColor = warningColor
+		}
+	}
+	duration := padLeft(t.Duration.Round(time.Millisecond).String(), 8)
+	if t.Duration >= slowRequest {
+		duration = lipgloss.NewStyle().Foreground(warningColor).Render(duration)
+	}
+	prefix := "  "
+	if selected {
+		prefix = glyphs.Cursor + " "
+	}
+	// 时间 12 + 方法 7 + 状态 5 + 耗时 9
+	path := padRight(truncate(t.Path, width-35), width-35)
+	return prefix + t.Time.Format("15:04:05.000") + " " + padRight(t.Method, 6) + " " + path + " " +
+		lipgloss.NewStyle().Foreground(statusColor).Render(padLeft(status, 3)) + " " + duration
+}
+
+// requestLogDetail lists the selected request's error and bodies.
+func (m *Model) requestLogDetail(t api.Trace, width int) []string {
+	labelStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	field := func(label, value string) string {
+		return labelStyle.Render(padRight(i18n.T(label), 10)) + truncate(value, width-10)
+	}
+	lines := []string{field("请求", t.Method+" "+t.Path)}
+	if t.Err != "" {
+		lines = append(lines, field("错误", t.Err))
+	}
+	if !m.debugLog.Bodies() {
+		return append(lines, labelStyle.Render(i18n.T("使用 --debug-bodies 启动可记录请求和响应内容")))
+	}
+	if t.RequestBody != "" {
+		lines = append(lines, field("请求内容", t.RequestBody))
+	}
+	if t.ResponseBody != "" {
+		lines = append(lines, field("响应内容", t.ResponseBody))
+	}
+	return lines
+}
@@ -0,0 +1,353 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/i18n"
+)
+
+// resetAllState drives the "reset every provider to default" dialog:
+// preview first, then per-provider results once confirmed.
+type resetAllState struct {
+	// providers is every provider of the account, hidden and filtered-out
+	// ones and later pages included; nil while the full list loads.
+	providers []api.ProviderBucket
+	listErr   error
+	// applied holds the plan frozen at confirmation; nil while previewing.
+	applied []resetPlanItem
+	results map[int]error
+	pending int
+	cursor  int
+	skipped map[int]bool // providers deselected in the preview
+}
+
+// resetPlanItem describes what resetting one provider would do.
+type resetPlanItem struct {
+	providerID int
+	name       string
+	current    string
+	targetID   int    // 0 when nothing will be switched
+	target     string // official alternative name
+	note       string // why the provider is skipped or not ready
+	loading    bool
+}
+
+type resetProvidersLoadedMsg struct {
+	providers []api.ProviderBucket
+	err       error
+}
+
+type resetResultMsg struct {
+	providerID int
+	selection  *api.ProviderSelection
+	err        error
+}
+
+// openResetAll shows the reset preview. The plan covers every provider, not
+// just the visible list, so the full list is fetched first when pages of it
+// are still unloaded; missing provider details are loaded after that.
+func (m *Model) openResetAll() tea.Cmd {
+	if m.currentTab != tabProviders || len(m.allProviders) == 0 {
+		return nil
+	}
+	m.resetAll = &resetAllState{skipped: make(map[int]bool)}
+	if m.providersNext != nil {
+		return loadResetProvidersCmd(m.ctx, m.client)
+	}
+	return m.handleResetProvidersLoaded(resetProvidersLoadedMsg{providers: m.allProviders})
+}
+
+// handleResetProvidersLoaded fills the preview with the full provider list
+// and loads the details the plan needs.
+func (m *Model) handleResetProvidersLoaded(msg resetProvidersLoadedMsg) tea.Cmd {
+	if m.resetAll == nil || m.resetAll.applied != nil {
+		return nil
+	}
+	if msg.err != nil {
+		m.resetAll.listErr = msg.err
+		return nil
+	}
+	m.resetAll.providers = msg.providers
+	var cmds []tea.Cmd
+	for _, bucket := range msg.providers {
+		cmds = append(cmds, m.queueProviderDetailLoad(bucket.Provider.ID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// resetPlan derives the per-provider reset plan from the loaded details.
+func (m *Model) resetPlan() []resetPlanItem {
+	providers := m.resetAll.providers
+	items := make([]resetPlanItem, 0, len(providers))
+	for _, bucket := range providers {
+		item := resetPlanItem{providerID: bucket.Provider.ID, name: bucket.Provider.DisplayName}
+		state := m.ensureProviderState(bucket.Provider.ID)
+		switch {
+		case state.loadingAlternatives || state.loadingSelection:
+			item.loading = true
+			item.note = i18n.T("加载中...")
+		case state.lastError != nil && (!state.alternativesLoaded || !state.selectionLoaded):
+			item.note = i18n.T("加载失败，已跳过")
+		default:
+			if state.selection != nil {
+				item.current = state.selection.SelectedAlternative.DisplayName
+			}
+			for _, alt := range state.alternatives {
+				if !alt.IsSelf {
+					continue
+				}
+				item.target = alt.Alternative.DisplayName
+				if state.selection == nil || state.selection.SelectedAlternativeID != alt.Alternative.ID {
+					item.targetID = alt.Alternative.ID
+				}
+			}
+			switch {
+			case item.target == "":
+				item.note = i18n.T("无官方方案，已跳过")
+			case item.targetID == 0:
+				item.note = i18n.T("已是官方方案")
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// handleResetAllKey handles keys while the reset dialog is open.
+func (m *Model) handleResetAllKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		// 执行中不允许关闭，避免遗漏结果；完成后结果转入操作摘要
+		if m.resetAll.applied == nil {
+			m.resetAll = nil
+		}
+	case "enter":
+		if m.resetAll.applied != nil {
+			return nil
+		}
+		return m.applyResetAll()
+	case "up", "k":
+		m.resetAll.cursor = clampIndex(m.resetAll.cursor-1, len(m.resetAll.providers))
+	case "down", "j":
+		m.resetAll.cursor = clampIndex(m.resetAll.cursor+1, len(m.resetAll.providers))
+	case " ":
+		if providers := m.resetAll.providers; m.resetAll.applied == nil && len(providers) > 0 {
+			id := providers[clampIndex(m.resetAll.cursor, len(providers))].Provider.ID
+			m.resetAll.skipped[id] = !m.resetAll.skipped[id]
+		}
+	}
+	return nil
+}
+
+// resetAllButtons returns the dialog's actions for its current stage; none
+// while providers are being reset.
+func (m *Model) resetAllButtons() []button {
+	state := m.resetAll
+	switch {
+	case state.applied != nil:
+		return nil
+	case state.providers == nil:
+		return []button{{label: "取消", key: "esc"}}
+	}
+	changes := 0
+	for _, item := range m.resetPlan() {
+		if item.loading {
+			return []button{{label: "取消", key: "esc"}}
+		}
+		if item.targetID != 0 && !state.skipped[item.providerID] {
+			changes++
+		}
+	}
+	if changes == 0 {
+		return []button{{label: "关闭", key: "esc"}}
+	}
+	return []button{
+		{label: i18n.Tf("恢复 %d 个提供商", changes), key: "enter", kind: buttonDanger},
+		{label: "取消", key: "esc"},
+	}
+}
+
+// applyResetAll switches every provider in the plan to its official alternative.
+func (m *Model) applyResetAll() tea.Cmd {
+	if m.resetAll.providers == nil {
+		return nil
+	}
+	plan := m.resetPlan()
+	for _, item := range plan {
+		if item.loading {
+			return nil
+		}
+	}
+
+	selected := 0
+	for i, item := range plan {
+		switch {
+		case item.targetID == 0:
+		case m.resetAll.skipped[item.providerID]:
+			plan[i].targetID, plan[i].note = 0, i18n.T("已取消选择")
+		default:
+			selected++
+		}
+	}
+	if selected == 0 {
+		return nil
+	}
+	m.resetAll.applied = plan
+	m.resetAll.results = make(map[int]error)
+	cmds := []tea.Cmd{m.saveRollback(m.cachedSnapshot())}
+	for _, item := range plan {
+		if item.targetID == 0 {
+			continue
+		}
+		m.ensureProviderState(item.providerID).switching = true
+		m.resetAll.pending++
+		cmds = append(cmds, resetProviderCmd(m.ctx, m.client, item.providerID, item.targetID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleResetResult records one provider's reset outcome.
+func (m *Model) handleResetResult(msg resetResultMsg) tea.Cmd {
+	state := m.ensureProviderState(msg.providerID)
+	state.switching = false
+	var cmd tea.Cmd
+	if msg.err == nil {
+		cmd = m.recordRecent(msg.providerID, selectedAlternativeID(state.selection), msg.selection.SelectedAlternativeID)
+		state.selection = msg.selection
+		state.selectionLoaded = true
+		state.lastError = nil
+		m.syncAltIdx(msg.providerID)
+	}
+	if m.resetAll == nil || m.resetAll.results == nil {
+		return cmd
+	}
+	m.resetAll.results[msg.providerID] = msg.err
+	m.resetAll.pending--
+	if m.resetAll.pending == 0 {
+		m.finishResetAll()
+	}
+	return cmd
+}
+
+// finishResetAll closes the dialog once every reset has answered and lists
+// the outcomes in the summary.
+func (m *Model) finishResetAll() {
+	var items []summaryItem
+	for _, item := range m.resetAll.applied {
+		if item.targetID == 0 {
+			continue
+		}
+		items = append(items, summaryItem{
+			label: item.name,
+			old:   item.current,
+			new:   item.target,
+			err:   m.resetAll.results[item.providerID],
+		})
+	}
+	m.resetAll = nil
+	m.showSummary(i18n.T("全部恢复默认"), items...)
+}
+
+func (m *Model) renderResetAllDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor).Italic(true)
+	okStyle := lipgloss.NewStyle().Foreground(successColor)
+	errorStyle := lipgloss.NewStyle().Foreground(errorColor)
+
+	state := m.resetAll
+	plan := state.applied
+	if plan == nil {
+		plan = m.resetPlan()
+	}
+
+	lines := []string{titleStyle.Render(i18n.T("全部恢复默认")), ""}
+	changes, skipped, loading := 0, 0, false
+	for i, item := range plan {
+		label := "  " + item.name + i18n.T("：")
+		if state.applied == nil {
+			// 预览时可用空格取消选择单个提供商
+			selected := item.targetID != 0 && !state.skipped[item.providerID]
+			box := "    "
+			if item.targetID != 0 {
+				box = checkbox(selected) + " "
+			}
+			label = cursorPrefix(i == state.cursor) + box + item.name + i18n.T("：")
+		}
+		switch {
+		case item.targetID == 0:
+			lines = append(lines, helpStyle.Render(label+item.note))
+			loading = loading || item.loading
+			continue
+		case state.applied == nil && state.skipped[item.providerID]:
+			lines = append(lines, helpStyle.Render(i18n.Tf("%s%s → %s（跳过）", label, item.current, item.target)))
+			skipped++
+			continue
+		case state.applied == nil:
+			lines = append(lines, fmt.Sprintf("%s%s → %s", label, item.current, item.target))
+		default:
+			err, done := state.results[item.providerID]
+			switch {
+			case !done:
+				lines = append(lines, i18n.Tf("%s切换到 %s 中... %s", label, item.target, m.spinner.View()))
+			case err != nil:
+				lines = append(lines, errorStyle.Render(i18n.Tf("%s%s %s → %s 失败：%v", label, glyphs.Warning, item.current, item.target, err)))
+			default:
+				lines = append(lines, okStyle.Render(fmt.Sprintf("%s%s %s → %s", label, glyphs.Check, item.current, item.target)))
+			}
+		}
+		changes++
+	}
+
+	lines = append(lines, "")
+	switch {
+	case state.listErr != nil:
+		lines = append(lines, errorStyle.Render(i18n.Tf("加载提供商列表失败：%v", state.listErr)))
+	case state.providers == nil:
+		lines = append(lines, hintStyle.Render(i18n.Tf("正在加载完整的提供商列表... %s", m.spinner.View())))
+	case state.applied != nil:
+		lines = append(lines, hintStyle.Render(i18n.T("正在恢复，请稍候...")))
+	case loading:
+		lines = append(lines, hintStyle.Render(i18n.T("正在加载提供商详情...")))
+	case changes == 0 && skipped > 0:
+		lines = append(lines, hintStyle.Render(i18n.T("未选择任何提供商 · 空格 选择")))
+	case changes == 0:
+		lines = append(lines, hintStyle.Render(i18n.T("所有提供商均已使用官方方案")))
+	default:
+		lines = append(lines, hintStyle.Render(i18n.T("↑↓ 移动 · 空格 选择/取消")))
+	}
+	if buttons := m.resetAllButtons(); len(buttons) > 0 {
+		lines = append(lines, renderButtons(buttons...))
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(64)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
+
+func resetProviderCmd(ctx context.Context, client api.Service, providerID, alternativeID int) tea.Cmd {
+	return func() tea.Msg {
+		selection, err := client.SwitchProvider(ctx, providerID, alternativeID)
+		return resetResultMsg{providerID: providerID, selection: selection, err: err}
+	}
+}
+
+// loadResetProvidersCmd fetches every page of the provider list for the
+// reset plan.
+func loadResetProvidersCmd(ctx context.Context, client api.Service) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.GetAvailableProviders(ctx)
+		if err != nil {
+			return resetProvidersLoadedMsg{err: err}
+		}
+		return resetProvidersLoadedMsg{providers: resp.Providers}
+	}
+}
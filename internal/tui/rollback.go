@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+	"yescode-tui/internal/snapshot"
+)
+
+type rollbackFailedMsg struct {
+	err error
+}
+
+// WithRollback saves a rollback point before batch operations (plan apply,
+// reset all) so R can restore it.
+func WithRollback(store *snapshot.RollbackStore) Option {
+	return func(m *Model) {
+		m.rollback = store
+	}
+}
+
+// saveRollback stores s as the account's rollback point in the background.
+func (m *Model) saveRollback(s *snapshot.Snapshot) tea.Cmd {
+	if m.rollback == nil || s == nil {
+		return nil
+	}
+	store, account := m.rollback, s.Account.Key()
+	return m.persist(func() tea.Msg {
+		if err := store.Save(account, s); err != nil {
+			return rollbackFailedMsg{err: err}
+		}
+		return nil
+	})
+}
+
+// cachedSnapshot builds a snapshot from the loaded profile and selections;
+// providers whose selection isn't loaded are left out.
+func (m *Model) cachedSnapshot() *snapshot.Snapshot {
+	if m.profile == nil {
+		return nil
+	}
+	var providers []snapshot.Provider
+	for _, bucket := range m.allProviders {
+		state, ok := m.providerData[bucket.Provider.ID]
+		if !ok || state.selection == nil {
+			continue
+		}
+		providers = append(providers, snapshot.Provider{
+			ID:            bucket.Provider.ID,
+			Name:          bucket.Provider.DisplayName,
+			AlternativeID: state.selection.SelectedAlternativeID,
+			Alternative:   state.selection.SelectedAlternative.DisplayName,
+		})
+	}
+	return snapshot.New(m.profile, providers)
+}
+
+// openRollback shows the plan screen for restoring the saved rollback point.
+func (m *Model) openRollback() tea.Cmd {
+	if m.rollback == nil || m.profile == nil {
+		return nil
+	}
+	store, account := m.rollback, history.AccountOf(m.profile)
+	path := store.Path(account)
+	if _, err := os.Stat(path); err != nil {
+		m.status = i18n.T("没有可用的回滚点")
+		return clearStatusAfter(statusClearDelay)
+	}
+	// 经由存储读取，开启 --encrypt-state 时回滚点是加密的
+	return m.openPlan(i18n.T("回滚"), path, func() (*snapshot.Snapshot, error) {
+		return store.Load(account)
+	})
+}
+
+func (m *Model) handleRollbackFailed(msg rollbackFailedMsg) []tea.Cmd {
+	m.err = msg.err
+	return []tea.Cmd{m.showToast(toastError, i18n.Tf("保存回滚点失败: %v", msg.err))}
+}
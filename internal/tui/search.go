@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+// searchMaxResults caps the rows shown in the global search dialog.
+const searchMaxResults = 12
+
+// searchState holds the global search (Ctrl+F) dialog.
+type searchState struct {
+	input  textinput.Model
+	cursor int
+}
+
+// searchResult is one match; jump moves the UI to it.
+type searchResult struct {
+	group string
+	label string
+	jump  func(m *Model) tea.Cmd
+}
+
+// openSearch opens the global search and loads data that is cheap to search.
+func (m *Model) openSearch() tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = i18n.T("搜索：")
+	ti.Placeholder = i18n.T("提供商、方案或日期")
+	ti.CharLimit = 64
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.Focus()
+	m.search = &searchState{input: ti}
+	return tea.Batch(m.ensureProvidersLoaded(), m.loadStats())
+}
+
+// handleSearchKey handles keys while the search dialog is open.
+func (m *Model) handleSearchKey(msg tea.KeyMsg) tea.Cmd {
+	results := m.searchResults()
+	switch msg.String() {
+	case "esc":
+		m.search = nil
+		return nil
+	case "up", "ctrl+p":
+		m.search.cursor = clampIndex(m.search.cursor-1, len(results))
+		return nil
+	case "down", "ctrl+n", "tab":
+		m.search.cursor = clampIndex(m.search.cursor+1, len(results))
+		return nil
+	case "enter":
+		if len(results) == 0 {
+			return nil
+		}
+		result := results[clampIndex(m.search.cursor, len(results))]
+		m.search = nil
+		return result.jump(m)
+	}
+
+	var cmd tea.Cmd
+	m.search.input, cmd = m.search.input.Update(msg)
+	m.search.cursor = 0
+	return cmd
+}
+
+// searchResults matches the query against providers, loaded alternatives and
+// sampled daily spend, grouped in that order.
+func (m *Model) searchResults() []searchResult {
+	query := strings.ToLower(strings.TrimSpace(m.search.input.Value()))
+	if query == "" {
+		return nil
+	}
+	matches := func(fields ...string) bool {
+		for _, f := range fields {
+			if strings.Contains(strings.ToLower(f), query) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var results []searchResult
+	for i, bucket := range m.providers {
+		p := bucket.Provider
+		if !matches(p.DisplayName, p.Type, p.Description) {
+			continue
+		}
+		idx := i
+		results = append(results, searchResult{
+			group: i18n.T("提供商"),
+			label: p.DisplayName + formatTypeSuffix(p.Type),
+			jump: func(m *Model) tea.Cmd {
+				cmd := m.switchTab(tabProviders)
+				m.providerIdx = idx
+				m.syncAltIdx(m.currentProviderID())
+				return tea.Batch(cmd, m.queueProviderDetailLoad(m.currentProviderID()))
+			},
+		})
+	}
+
+	for i, bucket := range m.providers {
+		state, ok := m.providerData[bucket.Provider.ID]
+		if !ok {
+			continue
+		}
+		for j, alt := range state.alternatives {
+			a := alt.Alternative
+			if !matches(a.DisplayName, a.Type, a.Description) {
+				continue
+			}
+			providerIdx, altIdx := i, j
+			results = append(results, searchResult{
+				group: i18n.T("备选方案"),
+				label: i18n.Tf("%s ×%.2f（%s）", a.DisplayName, a.RateMultiplier, bucket.Provider.DisplayName),
+				jump: func(m *Model) tea.Cmd {
+					cmd := m.switchTab(tabProviders)
+					m.providerIdx = providerIdx
+					m.focus = focusAlternatives
+					m.altIdx = altIdx
+					return cmd
+				},
+			})
+		}
+	}
+
+	for i, day := range m.spendDays {
+		if !day.Known() {
+			continue
+		}
+		amount := format.Money(day.Amount)
+		if !matches(day.Date.Format("2006-01-02"), day.Date.Format("01/02"), day.Date.Format(i18n.T("1月2日")), format.Date(day.Date), amount) {
+			continue
+		}
+		idx := i
+		results = append(results, searchResult{
+			group: i18n.T("消费记录"),
+			label: i18n.Tf("%s（%s）%s", day.Date.Format("2006-01-02"), i18n.T(weekdayNames[day.Date.Weekday()]), amount),
+			jump: func(m *Model) tea.Cmd {
+				cmd := m.switchTab(tabStats)
+				m.statsIdx = idx
+				return cmd
+			},
+		})
+	}
+	return results
+}
+
+func (m *Model) renderSearchDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor).Italic(true)
+
+	lines := []string{titleStyle.Render(i18n.T("全局搜索")), "", m.search.input.View(), ""}
+
+	results := m.searchResults()
+	switch {
+	case strings.TrimSpace(m.search.input.Value()) == "":
+		lines = append(lines, helpStyle.Render(i18n.T("  输入关键字搜索提供商、已加载的备选方案和每日消费")))
+	case len(results) == 0:
+		lines = append(lines, helpStyle.Render(i18n.T("  无匹配结果")))
+	default:
+		cur := clampIndex(m.search.cursor, len(results))
+		// 结果过多时让光标保持在可见范围内
+		start := 0
+		if cur >= searchMaxResults {
+			start = cur - searchMaxResults + 1
+		}
+		end := min(start+searchMaxResults, len(results))
+		group := ""
+		for i := start; i < end; i++ {
+			r := results[i]
+			if r.group != group {
+				group = r.group
+				lines = append(lines, sectionStyle.Render(group))
+			}
+			line := cursorPrefix(i == cur) + r.label
+			if i == cur {
+				line = selectedItemStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+		if len(results) > end-start {
+			lines = append(lines, helpStyle.Render(i18n.Tf("  共 %d 条结果", len(results))))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render(i18n.T("↑↓ 选择 · Enter 跳转 · Esc 关闭")))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(64)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// writeQueue tracks the background writes of local state (balance samples,
+// recent alternatives, rollback points) so none is lost on exit. Bubble Tea
+// drops commands still queued when the program quits; flush runs those
+// itself and waits for the ones already running.
+type writeQueue struct {
+	mu      sync.Mutex
+	pending map[int]tea.Cmd
+	nextID  int
+	running sync.WaitGroup
+}
+
+// persist wraps the state write cmd so Shutdown can flush it.
+func (m *Model) persist(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	q := m.writes
+	q.mu.Lock()
+	id := q.nextID
+	q.nextID++
+	q.pending[id] = cmd
+	q.mu.Unlock()
+	return func() tea.Msg {
+		if !q.start(id) {
+			return nil
+		}
+		defer q.running.Done()
+		return cmd()
+	}
+}
+
+// start claims the write id; it reports false when flush already ran it.
+func (q *writeQueue) start(id int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.pending[id]; !ok {
+		return false
+	}
+	delete(q.pending, id)
+	q.running.Add(1)
+	return true
+}
+
+// flush runs the writes that never started and waits for the rest, giving
+// up after timeout.
+func (q *writeQueue) flush(timeout time.Duration) {
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = map[int]tea.Cmd{}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		for _, cmd := range pending {
+			cmd()
+		}
+		q.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// shutdownTimeout bounds how long Shutdown waits for state writes.
+const shutdownTimeout = 3 * time.Second
+
+// quit cancels in-flight requests and ends the program. Every quit key goes
+// through here.
+func (m *Model) quit() tea.Cmd {
+	m.cancel()
+	return tea.Quit
+}
+
+// Shutdown finishes the work the model left behind once the program has
+// returned: it cancels in-flight requests and flushes pending state writes.
+// Call it after tea.Program.Run, whichever way the program ended.
+func (m *Model) Shutdown() {
+	m.cancel()
+	m.writes.flush(shutdownTimeout)
+}
@@ -0,0 +1,287 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+)
+
+const (
+	statsDays        = 30
+	statsChartHeight = 8
+	statsColumnWidth = 2 // bar + gap
+)
+
+var weekdayNames = [...]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+type statsLoadedMsg struct {
+	days []history.DaySpend
+	heat []history.DaySpend
+	err  error
+}
+
+type sampleFailedMsg struct {
+	err error
+}
+
+// WithHistory records profile samples to store and charts them in the stats tab.
+func WithHistory(store *history.Store) Option {
+	return func(m *Model) {
+		m.history = store
+	}
+}
+
+// loadStats reloads daily spend from the local sample store.
+func (m *Model) loadStats() tea.Cmd {
+	if m.history == nil || m.profile == nil || m.loadingStats {
+		return nil
+	}
+	m.loadingStats = true
+	return loadStatsCmd(m.history, history.AccountOf(m.profile))
+}
+
+// handleStatsLoaded stores the loaded daily spend series.
+func (m *Model) handleStatsLoaded(msg statsLoadedMsg) []tea.Cmd {
+	m.loadingStats = false
+	if msg.err != nil {
+		m.err = msg.err
+		return []tea.Cmd{m.showToast(toastError, i18n.Tf("读取本地统计失败: %v", msg.err))}
+	}
+	// 首次加载时游标定位到今天
+	if m.spendDays == nil {
+		m.statsIdx = len(msg.days) - 1
+	}
+	m.spendDays = msg.days
+	m.heatDays = msg.heat
+	m.statsIdx = clampIndex(m.statsIdx, len(m.spendDays))
+	return nil
+}
+
+// handleSampleFailed surfaces local sampling write errors.
+func (m *Model) handleSampleFailed(msg sampleFailedMsg) []tea.Cmd {
+	m.err = msg.err
+	return []tea.Cmd{m.showToast(toastError, i18n.Tf("记录本地采样失败: %v", msg.err))}
+}
+
+// handleStatsCursor moves the day cursor with left/right on the stats tab.
+func (m *Model) handleStatsCursor(key string) {
+	if m.currentTab != tabStats {
+		return
+	}
+	switch key {
+	case "left", "h":
+		m.moveStatsCursor(-m.navAccel.step(key, time.Now()))
+	case "right", "l":
+		m.moveStatsCursor(m.navAccel.step(key, time.Now()))
+	}
+}
+
+func (m *Model) moveStatsCursor(delta int) {
+	if len(m.spendDays) == 0 {
+		return
+	}
+	m.statsIdx = clampIndex(m.statsIdx+delta, len(m.spendDays))
+}
+
+// handleStatsClick selects the day whose bar column was clicked.
+func (m *Model) handleStatsClick(x int) {
+	axisWidth := m.statsAxisWidth()
+	col := (x - axisWidth) / statsColumnWidth
+	if x < axisWidth || col >= len(m.spendDays) {
+		return
+	}
+	m.statsIdx = col
+}
+
+func (m *Model) renderStatsTab() string {
+	if m.history == nil {
+		return helpStyle.Render(i18n.T("本地采样未启用，无法显示消费统计"))
+	}
+	if m.spendDays == nil {
+		return i18n.Tf("加载中... %s", m.spinner.View())
+	}
+
+	chart := []string{titleStyle.Render(i18n.Tf("近 %d 天每日消费", statsDays)), ""}
+	chart = append(chart, m.renderSpendChart()...)
+	heatmap := m.renderHeatmap()
+
+	var lines []string
+	chartWidth := lipgloss.Width(strings.Join(chart, "\n"))
+	if len(heatmap) > 0 && m.width-viewportWidthMargin >= chartWidth+heatmapGap+heatmapWidth() {
+		// 宽度足够时热力图放在柱状图右侧，与之顶部对齐
+		side := lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(chartWidth+heatmapGap).Render(strings.Join(chart, "\n")),
+			strings.Join(heatmap, "\n"))
+		lines = append(lines, side, "")
+		heatmap = nil
+	} else {
+		lines = append(lines, chart...)
+		lines = append(lines, "")
+	}
+	lines = append(lines, m.renderSelectedDay())
+	lines = append(lines, m.renderStatsSummary())
+	if len(heatmap) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, heatmap...)
+	}
+	lines = append(lines, "", helpStyle.Render(i18n.T("数据来自本地采样（程序运行时每 5 分钟记录一次）· ←→ 选择日期 · r 刷新")))
+	return strings.Join(lines, "\n")
+}
+
+// spendPeak returns the highest daily spend in the chart.
+func (m *Model) spendPeak() float64 {
+	peak := 0.0
+	for _, day := range m.spendDays {
+		peak = max(peak, day.Amount)
+	}
+	return peak
+}
+
+// statsAxisWidth is the width of the y axis column: the wider of the top and
+// bottom labels, a space and the tick.
+func (m *Model) statsAxisWidth() int {
+	return max(lipgloss.Width(format.Money(m.spendPeak())), lipgloss.Width(format.Money(0))) + 2
+}
+
+// renderSpendChart draws one vertical bar per day using the eighth-level bar glyphs.
+func (m *Model) renderSpendChart() []string {
+	maxAmount := m.spendPeak()
+	axisWidth := m.statsAxisWidth()
+
+	barStyle := lipgloss.NewStyle().Foreground(primaryColor)
+	cursorStyle := lipgloss.NewStyle().Foreground(accentColor)
+	emptyStyle := lipgloss.NewStyle().Foreground(mutedColor)
+
+	lines := make([]string, 0, statsChartHeight+2)
+	for row := statsChartHeight - 1; row >= 0; row-- {
+		var b strings.Builder
+		switch row {
+		case statsChartHeight - 1:
+			b.WriteString(padLeft(format.Money(maxAmount), axisWidth-2) + " " + glyphs.AxisTick)
+		case 0:
+			b.WriteString(padLeft(format.Money(0), axisWidth-2) + " " + glyphs.AxisTick)
+		default:
+			b.WriteString(strings.Repeat(" ", axisWidth-1) + glyphs.AxisLine)
+		}
+
+		for i, day := range m.spendDays {
+			cell := " "
+			if maxAmount > 0 {
+				eighths := int(day.Amount/maxAmount*statsChartHeight*8 + 0.5)
+				fill := eighths - row*8
+				switch {
+				case fill >= 8:
+					cell = glyphs.Bars[8]
+				case fill > 0:
+					cell = glyphs.Bars[fill]
+				}
+			}
+			style := barStyle
+			switch {
+			case i == m.statsIdx:
+				style = cursorStyle
+			case day.Estimated:
+				// 估算的日期用弱化的颜色，和实际采样的区分开
+				style = emptyStyle
+			}
+			if cell == " " && row == 0 && !day.Known() {
+				cell, style = glyphs.NoSample, emptyStyle
+			}
+			b.WriteString(style.Render(cell) + " ")
+		}
+		lines = append(lines, b.String())
+	}
+
+	lines = append(lines, strings.Repeat(" ", axisWidth-1)+glyphs.AxisCorner+strings.Repeat(glyphs.AxisRule, len(m.spendDays)*statsColumnWidth))
+	lines = append(lines, m.renderChartDates())
+	return lines
+}
+
+// renderChartDates labels the first, middle and last day under the x axis.
+func (m *Model) renderChartDates() string {
+	axisWidth := m.statsAxisWidth()
+	width := axisWidth + len(m.spendDays)*statsColumnWidth
+	axis := []rune(strings.Repeat(" ", width+5))
+	for _, i := range []int{0, len(m.spendDays) / 2, len(m.spendDays) - 1} {
+		if i < 0 || i >= len(m.spendDays) {
+			continue
+		}
+		label := format.ShortDate(m.spendDays[i].Date)
+		copy(axis[axisWidth+i*statsColumnWidth:], []rune(label))
+	}
+	return helpStyle.Render(strings.TrimRight(string(axis), " "))
+}
+
+func (m *Model) renderSelectedDay() string {
+	if len(m.spendDays) == 0 {
+		return ""
+	}
+	day := m.spendDays[clampIndex(m.statsIdx, len(m.spendDays))]
+	date := i18n.Tf("%s（%s）", format.Date(day.Date), i18n.T(weekdayNames[day.Date.Weekday()]))
+	switch {
+	case !day.Known():
+		return selectedItemStyle.Render(i18n.Tf("%s %s：未采样", glyphs.Cursor, date))
+	case day.Estimated:
+		return selectedItemStyle.Render(i18n.Tf("%s %s：约 %s（当天未采样，按前后两次采样估算）", glyphs.Cursor, date, format.Money(day.Amount)))
+	}
+	return selectedItemStyle.Render(i18n.Tf("%s %s：%s", glyphs.Cursor, date, format.Money(day.Amount)))
+}
+
+func (m *Model) renderStatsSummary() string {
+	total, peak := 0.0, 0.0
+	sampled, estimated := 0, 0
+	for _, day := range m.spendDays {
+		total += day.Amount
+		if day.Amount > peak {
+			peak = day.Amount
+		}
+		if day.Sampled {
+			sampled++
+		} else if day.Estimated {
+			estimated++
+		}
+	}
+	// 日均按有数据的天数计算，估算的日期也算在内，它们分摊了合计中的消费
+	avg := 0.0
+	if known := sampled + estimated; known > 0 {
+		avg = total / float64(known)
+	}
+	summary := i18n.Tf("  合计 %s · 日均 %s · 最高 %s · 已采样 %d/%d 天",
+		format.Money(total), format.Money(avg), format.Money(peak), sampled, len(m.spendDays))
+	if estimated > 0 {
+		summary += i18n.Tf("（另有 %d 天为估算）", estimated)
+	}
+	return summary
+}
+
+func loadStatsCmd(store *history.Store, account string) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		// 多取一天，作为窗口内第一个样本的增量基准
+		since := now.AddDate(0, 0, -max(statsDays, heatmapDays(now))-1)
+		samples, err := store.Load(account, since)
+		if err != nil {
+			return statsLoadedMsg{err: err}
+		}
+		return statsLoadedMsg{
+			days: history.DailySpend(samples, statsDays, now),
+			heat: history.DailySpend(samples, heatmapDays(now), now),
+		}
+	}
+}
+
+func recordSampleCmd(store *history.Store, profile *api.Profile) tea.Cmd {
+	return func() tea.Msg {
+		if err := store.Record(profile, time.Now()); err != nil {
+			return sampleFailedMsg{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/i18n"
+)
+
+// renderStatusBar draws the bottom row. The left side shows the open prompt
+// or the latest status message, falling back to key hints for the focused
+// pane; the right side always shows the current tab, the last refresh time
+// and the connection state.
+func (m *Model) renderStatusBar(message string) string {
+	right := m.statusBarInfo()
+	left := statusStyle.Render(message)
+	if message == "" {
+		left = helpStyle.Render(m.contextHints())
+	}
+	if m.width <= 0 {
+		return left + "  " + right
+	}
+	room := m.width - lipgloss.Width(right) - 2
+	left = ansi.Truncate(left, max(room, 0), glyphs.Ellipsis)
+	gap := max(m.width-lipgloss.Width(left)-lipgloss.Width(right), 1)
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// statusBarInfo is the right-hand side of the status bar.
+func (m *Model) statusBarInfo() string {
+	_, tab, _ := strings.Cut(i18n.T(tabTitles[m.currentTab]), ". ")
+	parts := []string{tab}
+	if !m.profileUpdated.IsZero() {
+		parts = append(parts, i18n.T("更新于 ")+format.Time(m.profileUpdated))
+	}
+	label, color := m.connectionState()
+	dot := lipgloss.NewStyle().Foreground(color).Render(glyphs.Bullet)
+	return helpStyle.Render(strings.Join(parts, " · ")+" · ") + dot + " " + helpStyle.Render(label)
+}
+
+// connectionState describes the link to the server from the periodic
+// profile refresh: network failures mean offline, error responses mean the
+// service has a problem, and a 429 pauses refreshing until it may resume.
+func (m *Model) connectionState() (string, lipgloss.Color) {
+	var apiErr *api.APIError
+	switch {
+	case m.rateLimited():
+		return i18n.T("限流中"), warningColor
+	case m.profileErr != nil && errors.As(m.profileErr, &apiErr):
+		return i18n.T("服务异常"), warningColor
+	case m.profileErr != nil:
+		return i18n.T("离线"), errorColor
+	case m.profile == nil:
+		return i18n.T("连接中"), mutedColor
+	}
+	return i18n.T("在线"), successColor
+}
+
+// contextHints lists the most useful keys for the focused pane.
+func (m *Model) contextHints() string {
+	var hints []string
+	switch m.focus {
+	case focusProviders:
+		hints = []string{i18n.T("↑↓ 选择"), i18n.T("→ 方案"), i18n.T("/ 筛选"), i18n.T("s 排序"), i18n.T("r 刷新")}
+	case focusAlternatives:
+		hints = []string{i18n.T("↑↓ 选择"), i18n.T("Enter 切换"), i18n.T("d 恢复默认"), i18n.T("← 提供商")}
+	case focusPreference:
+		hints = []string{i18n.T("↑↓ 选择"), i18n.T("Enter 切换")}
+	case focusChart:
+		hints = []string{i18n.T("←→ 选择日期"), i18n.T("r 刷新")}
+	default:
+		hints = []string{i18n.T("↑↓ 滚动"), i18n.T("r 刷新")}
+	}
+	return strings.Join(append(hints, i18n.T("? 帮助")), " · ")
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/api"
+)
+
+// The event stream reconnects after it drops, backing off from
+// streamRetryMin to streamRetryMax. Polling takes over in the meantime.
+const (
+	streamRetryMin = 5 * time.Second
+	streamRetryMax = 2 * time.Minute
+)
+
+// streamState tracks an account's subscription to the server's event
+// stream. While it is connected, updates are pushed and the profile is not
+// polled.
+type streamState struct {
+	connected   bool
+	unsupported bool // 服务端没有事件流，只使用轮询
+	backoff     time.Duration
+}
+
+type streamOpenedMsg struct {
+	events <-chan api.StreamEvent
+}
+
+type streamFailedMsg struct {
+	err error
+}
+
+type streamEventMsg struct {
+	event  api.StreamEvent
+	events <-chan api.StreamEvent
+}
+
+type streamClosedMsg struct{}
+
+type streamRetryMsg struct{}
+
+// WithStream subscribes to the server's event stream and pauses profile
+// polling while it is connected. The endpoint isn't in the published API
+// documentation, so without this option the profile is always polled.
+func WithStream() Option {
+	return func(m *Model) {
+		m.streamEnabled = true
+	}
+}
+
+// subscribe opens the active account's event stream when it is enabled and
+// the server has one.
+func (m *Model) subscribe() tea.Cmd {
+	if !m.streamEnabled || m.stream.unsupported || m.stream.connected {
+		return nil
+	}
+	return subscribeCmd(m.ctx, m.client)
+}
+
+func subscribeCmd(ctx context.Context, client api.Service) tea.Cmd {
+	return func() tea.Msg {
+		events, err := client.Subscribe(ctx)
+		if err != nil {
+			return streamFailedMsg{err: err}
+		}
+		return streamOpenedMsg{events: events}
+	}
+}
+
+// waitStreamEvent delivers the next event, or streamClosedMsg once the
+// stream ends.
+func waitStreamEvent(events <-chan api.StreamEvent) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return streamClosedMsg{}
+		}
+		return streamEventMsg{event: ev, events: events}
+	}
+}
+
+func (m *Model) handleStreamOpened(msg streamOpenedMsg) tea.Cmd {
+	m.stream.connected = true
+	m.stream.backoff = 0
+	return waitStreamEvent(msg.events)
+}
+
+// handleStreamEvent applies a pushed update as if it had been loaded.
+func (m *Model) handleStreamEvent(msg streamEventMsg) []tea.Cmd {
+	var cmds []tea.Cmd
+	switch ev := msg.event; ev.Type {
+	case api.EventProfile:
+		cmds = m.handleProfileLoaded(profileLoadedMsg{profile: ev.Profile})
+	case api.EventSelection:
+		if state := m.providerData[ev.Selection.ProviderID]; state != nil && state.switching {
+			// 本地切换进行中，以切换结果为准
+			break
+		}
+		cmds = append(cmds, m.handleSelectionLoaded(selectionLoadedMsg{providerID: ev.Selection.ProviderID, selection: ev.Selection}))
+	}
+	return append(cmds, waitStreamEvent(msg.events))
+}
+
+// handleStreamFailed falls back to polling: for good when the server has no
+// event stream, otherwise until the next attempt.
+func (m *Model) handleStreamFailed(msg streamFailedMsg) tea.Cmd {
+	m.stream.connected = false
+	switch {
+	case errors.Is(msg.err, api.ErrStreamUnsupported):
+		m.stream.unsupported = true
+		return nil
+	case canceled(msg.err):
+		return nil
+	}
+	return m.retryStream()
+}
+
+// handleStreamClosed resumes polling once the stream ends, whether the
+// server closed it or it went quiet past the idle deadline, and reloads the
+// profile straight away in case updates were missed meanwhile.
+func (m *Model) handleStreamClosed() tea.Cmd {
+	m.stream.connected = false
+	if m.ctx.Err() != nil {
+		return nil
+	}
+	return tea.Batch(loadProfileCmd(m.ctx, m.client), m.retryStream())
+}
+
+// retryStream schedules the next connection attempt, doubling the delay
+// after every failure.
+func (m *Model) retryStream() tea.Cmd {
+	m.stream.backoff = min(max(m.stream.backoff*2, streamRetryMin), streamRetryMax)
+	return tea.Tick(m.stream.backoff, func(time.Time) tea.Msg {
+		return streamRetryMsg{}
+	})
+}
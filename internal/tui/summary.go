@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/i18n"
+)
+
+// operationSummary is the dialog shown after a state-changing operation: a
+// switch or preference change, or a batch (plan apply, rollback, reset all)
+// once every change has answered. It stays open until dismissed so the
+// outcome can be checked in the moment.
+type operationSummary struct {
+	title string
+	items []summaryItem
+}
+
+// summaryItem records one change: what it applied to, old and new values,
+// and the error when it failed.
+type summaryItem struct {
+	label string
+	old   string
+	new   string
+	err   error
+}
+
+// showSummary opens the summary dialog, replacing any one still open.
+func (m *Model) showSummary(title string, items ...summaryItem) {
+	m.summary = &operationSummary{title: title, items: items}
+}
+
+// handleSummaryKey dismisses the summary on Enter, Esc or space.
+func (m *Model) handleSummaryKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter", "esc", " ":
+		m.summary = nil
+	}
+	return nil
+}
+
+// summaryButtons is the single button acknowledging the summary.
+func (m *Model) summaryButtons() []button {
+	return []button{{label: "关闭", key: "enter", kind: buttonPrimary}}
+}
+
+// providerDisplayName returns the provider's name, falling back to its ID.
+func (m *Model) providerDisplayName(providerID int) string {
+	for _, bucket := range m.providers {
+		if bucket.Provider.ID == providerID {
+			return bucket.Provider.DisplayName
+		}
+	}
+	return i18n.Tf("提供商 %d", providerID)
+}
+
+func (m *Model) renderSummaryDialog() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	okStyle := lipgloss.NewStyle().Foreground(successColor)
+	errorStyle := lipgloss.NewStyle().Foreground(errorColor)
+
+	lines := []string{titleStyle.Render(m.summary.title), ""}
+	failures := 0
+	for _, item := range m.summary.items {
+		lines = append(lines, "  "+item.label)
+		change := fmt.Sprintf("%s → %s", item.old, item.new)
+		if item.err != nil {
+			failures++
+			lines = append(lines,
+				errorStyle.Render(i18n.Tf("    %s %s（未生效）", glyphs.Warning, change)),
+				errorStyle.Render("    "+m.describeError(item.err)),
+				helpStyle.Render(truncate(fmt.Sprintf("    %v", item.err), 56)))
+			continue
+		}
+		lines = append(lines, okStyle.Render(fmt.Sprintf("    %s %s", glyphs.Check, change)))
+	}
+
+	lines = append(lines, "")
+	if failures > 0 {
+		lines = append(lines, errorStyle.Render(i18n.Tf("%d 项失败", failures)))
+	}
+	lines = append(lines, renderButtons(m.summaryButtons()...))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3).
+		Width(64)
+	return dialogStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Built-in theme names accepted by ApplyTheme. ThemeDefault is the Material
+// palette, meant for dark terminals like ThemeDark.
+const (
+	ThemeDefault      = "default"
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeSolarized    = "solarized"
+	ThemeDracula      = "dracula"
+	ThemeHighContrast = "high-contrast"
+)
+
+// Theme is a color palette; every style in the package is derived from the
+// active one.
+type Theme struct {
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Accent    lipgloss.Color
+	Muted     lipgloss.Color
+	Success   lipgloss.Color
+	Error     lipgloss.Color
+	Warning   lipgloss.Color
+	OnPrimary lipgloss.Color // text on a primary background
+
+	// ProviderTypes colors well-known provider families; other types get a
+	// ProviderFallback color by hash.
+	ProviderTypes    map[string]lipgloss.Color
+	ProviderFallback []lipgloss.Color
+}
+
+// brandColors gives well-known provider families their brand-like color.
+var brandColors = map[string]lipgloss.Color{
+	"anthropic": lipgloss.Color("#D97757"), // Claude Coral
+	"openai":    lipgloss.Color("#10A37F"), // OpenAI Green
+	"gemini":    lipgloss.Color("#A142F4"), // Gemini Purple
+}
+
+var materialFallback = []lipgloss.Color{
+	lipgloss.Color("#00BCD4"), // Cyan
+	lipgloss.Color("#CDDC39"), // Lime
+	lipgloss.Color("#795548"), // Brown
+	lipgloss.Color("#E91E63"), // Pink
+	lipgloss.Color("#3F51B5"), // Indigo
+	lipgloss.Color("#FFC107"), // Amber
+}
+
+// Material Design 风格配色
+var materialTheme = Theme{
+	Primary:          lipgloss.Color("#2196F3"), // Material Blue
+	Secondary:        lipgloss.Color("#1976D2"), // Dark Blue
+	Accent:           lipgloss.Color("#FF4081"), // Pink Accent
+	Muted:            lipgloss.Color("#9E9E9E"), // Grey
+	Success:          lipgloss.Color("#4CAF50"), // Green
+	Error:            lipgloss.Color("#F44336"), // Red
+	Warning:          lipgloss.Color("#FF9800"), // Orange
+	OnPrimary:        lipgloss.Color("#FFFFFF"),
+	ProviderTypes:    brandColors,
+	ProviderFallback: materialFallback,
+}
+
+var builtinThemes = map[string]Theme{
+	ThemeDefault: materialTheme,
+	ThemeDark:    materialTheme,
+	// 浅色终端：加深主色，灰色调暗以保证在白底上可读
+	ThemeLight: {
+		Primary:          lipgloss.Color("#1565C0"),
+		Secondary:        lipgloss.Color("#0D47A1"),
+		Accent:           lipgloss.Color("#C2185B"),
+		Muted:            lipgloss.Color("#616161"),
+		Success:          lipgloss.Color("#2E7D32"),
+		Error:            lipgloss.Color("#C62828"),
+		Warning:          lipgloss.Color("#E65100"),
+		OnPrimary:        lipgloss.Color("#FFFFFF"),
+		ProviderTypes:    brandColors,
+		ProviderFallback: []lipgloss.Color{"#00838F", "#827717", "#5D4037", "#AD1457", "#283593", "#FF8F00"},
+	},
+	ThemeSolarized: {
+		Primary:          lipgloss.Color("#268BD2"), // blue
+		Secondary:        lipgloss.Color("#2AA198"), // cyan
+		Accent:           lipgloss.Color("#D33682"), // magenta
+		Muted:            lipgloss.Color("#839496"), // base0
+		Success:          lipgloss.Color("#859900"), // green
+		Error:            lipgloss.Color("#DC322F"), // red
+		Warning:          lipgloss.Color("#CB4B16"), // orange
+		OnPrimary:        lipgloss.Color("#FDF6E3"), // base3
+		ProviderTypes:    map[string]lipgloss.Color{"anthropic": "#CB4B16", "openai": "#859900", "gemini": "#6C71C4"},
+		ProviderFallback: []lipgloss.Color{"#2AA198", "#B58900", "#D33682", "#6C71C4", "#268BD2"},
+	},
+	ThemeDracula: {
+		Primary:          lipgloss.Color("#BD93F9"), // purple
+		Secondary:        lipgloss.Color("#6272A4"), // comment
+		Accent:           lipgloss.Color("#FF79C6"), // pink
+		Muted:            lipgloss.Color("#6272A4"),
+		Success:          lipgloss.Color("#50FA7B"), // green
+		Error:            lipgloss.Color("#FF5555"), // red
+		Warning:          lipgloss.Color("#FFB86C"), // orange
+		OnPrimary:        lipgloss.Color("#282A36"), // background
+		ProviderTypes:    map[string]lipgloss.Color{"anthropic": "#FFB86C", "openai": "#50FA7B", "gemini": "#BD93F9"},
+		ProviderFallback: []lipgloss.Color{"#8BE9FD", "#F1FA8C", "#FF79C6", "#FF5555"},
+	},
+	// 仅使用纯白、纯黑和纯黄
+	ThemeHighContrast: {
+		Primary:          lipgloss.Color("#FFFF00"),
+		Secondary:        lipgloss.Color("#FFFF00"),
+		Accent:           lipgloss.Color("#FFFFFF"),
+		Muted:            lipgloss.Color("#FFFFFF"),
+		Success:          lipgloss.Color("#FFFF00"),
+		Error:            lipgloss.Color("#FFFF00"),
+		Warning:          lipgloss.Color("#FFFF00"),
+		OnPrimary:        lipgloss.Color("#000000"),
+		ProviderFallback: []lipgloss.Color{lipgloss.Color("#FFFFFF")},
+	},
+}
+
+// themes holds the built-in themes plus those added by RegisterTheme.
+var themes = maps.Clone(builtinThemes)
+
+func init() {
+	useTheme(themes[ThemeDefault])
+}
+
+// ApplyTheme switches the color palette used by every view. It must be called
+// before NewModel.
+func ApplyTheme(name string) error {
+	if name == "" {
+		name = ThemeDefault
+	}
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+	useTheme(t)
+	return nil
+}
+
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// RegisterTheme adds a custom theme from a palette of role → hex color
+// entries (primary, secondary, accent, muted, success, error, warning,
+// on_primary). Roles left out come from the built-in theme named by the
+// optional "base" entry, the default theme otherwise.
+func RegisterTheme(name string, palette map[string]string) error {
+	baseName := palette["base"]
+	if baseName == "" {
+		baseName = ThemeDefault
+	}
+	if _, ok := builtinThemes[name]; ok {
+		return fmt.Errorf("theme %s: name is taken by a built-in theme", name)
+	}
+	t, ok := builtinThemes[baseName]
+	if !ok {
+		return fmt.Errorf("theme %s: unknown base %q", name, baseName)
+	}
+	roles := map[string]*lipgloss.Color{
+		"primary":    &t.Primary,
+		"secondary":  &t.Secondary,
+		"accent":     &t.Accent,
+		"muted":      &t.Muted,
+		"success":    &t.Success,
+		"error":      &t.Error,
+		"warning":    &t.Warning,
+		"on_primary": &t.OnPrimary,
+	}
+	for role, value := range palette {
+		if role == "base" {
+			continue
+		}
+		target, ok := roles[role]
+		if !ok {
+			return fmt.Errorf("theme %s: unknown color %q", name, role)
+		}
+		if !hexColor.MatchString(strings.TrimSpace(value)) {
+			return fmt.Errorf("theme %s: %s: invalid hex color %q", name, role, value)
+		}
+		*target = lipgloss.Color(strings.TrimSpace(value))
+	}
+	themes[name] = t
+	return nil
+}
+
+// noColor is set by DisableColor. Elements told apart only by a background
+// color (the current tab, buttons) are bracketed instead.
+var noColor bool
+
+// DisableColor renders every view as plain text without ANSI styling. It must
+// be called before NewModel.
+func DisableColor() {
+	noColor = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+	rebuildStyles()
+}
+
+// useTheme makes t the active palette and rebuilds the shared styles.
+func useTheme(t Theme) {
+	primaryColor = t.Primary
+	secondaryColor = t.Secondary
+	accentColor = t.Accent
+	mutedColor = t.Muted
+	successColor = t.Success
+	errorColor = t.Error
+	warningColor = t.Warning
+	onPrimaryColor = t.OnPrimary
+	providerTypeColors = t.ProviderTypes
+	providerFallbackColors = t.ProviderFallback
+	rebuildStyles()
+}
+
+// rebuildStyles derives the shared styles from the current palette.
+func rebuildStyles() {
+	panelStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2).BorderForeground(mutedColor)
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	helpStyle = lipgloss.NewStyle().Foreground(mutedColor)
+	statusStyle = lipgloss.NewStyle().Foreground(primaryColor)
+	selectedItemStyle = lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	activeTabStyle = lipgloss.NewStyle().Bold(true).Foreground(onPrimaryColor).Background(primaryColor).Padding(0, 2).MarginRight(1)
+	if noColor {
+		// 括号占去两侧各一格内边距，与其他标签页等宽
+		activeTabStyle = activeTabStyle.Padding(0, 1)
+	}
+	inactiveTabStyle = lipgloss.NewStyle().Foreground(mutedColor).Padding(0, 2).MarginRight(1)
+}
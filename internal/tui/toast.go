@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// toastKind picks a toast's icon and border color.
+type toastKind int
+
+const (
+	toastInfo toastKind = iota
+	toastSuccess
+	toastWarning
+	toastError
+)
+
+// toast is a transient notification stacked in the top-right corner. Results
+// of background operations are shown as toasts, so concurrent operations
+// don't overwrite each other's message the way the status line would.
+type toast struct {
+	id   int
+	kind toastKind
+	text string
+	hint string // key hint kept visible after the text
+}
+
+type toastExpiredMsg struct {
+	id int
+}
+
+const (
+	maxToasts     = 4
+	maxToastWidth = 48
+)
+
+// showToast stacks a notification and returns the command expiring it;
+// errors stay up longer. The oldest toast is dropped once the stack is full.
+func (m *Model) showToast(kind toastKind, text string) tea.Cmd {
+	m.nextToastID++
+	t := toast{id: m.nextToastID, kind: kind, text: text}
+	m.toasts = append(m.toasts, t)
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+	delay := statusClearDelay
+	if kind == toastWarning || kind == toastError {
+		delay = errorClearDelay
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: t.id}
+	})
+}
+
+func (m *Model) handleToastExpired(msg toastExpiredMsg) {
+	m.toasts = slices.DeleteFunc(m.toasts, func(t toast) bool { return t.id == msg.id })
+}
+
+func (t toast) render() string {
+	icon, color := "i", primaryColor
+	switch t.kind {
+	case toastSuccess:
+		icon, color = glyphs.Check, successColor
+	case toastWarning:
+		icon, color = glyphs.Warning, warningColor
+	case toastError:
+		icon, color = "!", errorColor
+	}
+	iconStyle := lipgloss.NewStyle().Bold(true).Foreground(color)
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Padding(0, 1).
+		Render(iconStyle.Render(icon) + " " + t.body())
+}
+
+// body is the text truncated to fit, followed by the hint.
+func (t toast) body() string {
+	if t.hint == "" {
+		return truncate(t.text, maxToastWidth-6)
+	}
+	room := maxToastWidth - 7 - lipgloss.Width(t.hint)
+	return truncate(t.text, room) + " " + helpStyle.Render(t.hint)
+}
+
+// renderToasts draws the toast stack over the top-right corner of view,
+// newest at the bottom.
+func (m *Model) renderToasts(view string) string {
+	if len(m.toasts) == 0 || m.width <= 0 {
+		return view
+	}
+	var boxes []string
+	for _, t := range m.toasts {
+		boxes = append(boxes, t.render())
+	}
+	stack := strings.Split(lipgloss.JoinVertical(lipgloss.Right, boxes...), "\n")
+	stackWidth := lipgloss.Width(stack[0])
+
+	lines := strings.Split(view, "\n")
+	for len(lines) < len(stack) {
+		lines = append(lines, "")
+	}
+	x := max(m.width-stackWidth-1, 0)
+	for i, row := range stack {
+		if strings.TrimSpace(ansi.Strip(row)) == "" {
+			continue
+		}
+		// 窄于整个堆叠的提示框左侧留空，露出下面的内容
+		rowStart := x + stackWidth - lipgloss.Width(strings.TrimLeft(row, " "))
+		left := ansi.Truncate(lines[i], rowStart, "")
+		left += strings.Repeat(" ", rowStart-ansi.StringWidth(left))
+		right := ansi.TruncateLeft(lines[i], x+stackWidth, "")
+		lines[i] = left + strings.TrimLeft(row, " ") + right
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"yescode-tui/internal/api"
+	"yescode-tui/internal/format"
+	"yescode-tui/internal/history"
+	"yescode-tui/internal/i18n"
+)
+
+const (
+	defaultTrendWindow = 6 * time.Hour
+	trendBuckets       = 48
+)
+
+type trendLoadedMsg struct {
+	samples []history.Sample
+}
+
+// WithTrendWindow sets how far back the spend sparkline on the profile tab reaches.
+func WithTrendWindow(d time.Duration) Option {
+	return func(m *Model) {
+		m.trendWindow = d
+	}
+}
+
+func (m *Model) spendTrendWindow() time.Duration {
+	if m.trendWindow > 0 {
+		return m.trendWindow
+	}
+	return defaultTrendWindow
+}
+
+// recordTrend keeps every profile refresh in memory for the sparkline. The
+// local history only holds one sample per few minutes, so it just seeds the
+// window once after startup.
+func (m *Model) recordTrend(profile *api.Profile) tea.Cmd {
+	now := time.Now()
+	m.trendSamples = append(m.trendSamples, history.NewSample(profile, now))
+	m.pruneTrend(now)
+	if m.trendSeeded || m.history == nil {
+		return nil
+	}
+	m.trendSeeded = true
+	return loadTrendCmd(m.history, history.AccountOf(profile), now.Add(-m.spendTrendWindow()-time.Hour))
+}
+
+// handleTrendLoaded prepends the stored samples older than the first one
+// recorded in this session.
+func (m *Model) handleTrendLoaded(msg trendLoadedMsg) {
+	var older []history.Sample
+	for _, sample := range msg.samples {
+		if len(m.trendSamples) > 0 && !sample.Time.Before(m.trendSamples[0].Time) {
+			break
+		}
+		older = append(older, sample)
+	}
+	m.trendSamples = append(older, m.trendSamples...)
+	m.pruneTrend(time.Now())
+}
+
+// pruneTrend drops samples outside the window, keeping the last one before
+// it as the baseline of the first bucket.
+func (m *Model) pruneTrend(now time.Time) {
+	start := now.Add(-m.spendTrendWindow())
+	drop := 0
+	for drop+1 < len(m.trendSamples) && m.trendSamples[drop+1].Time.Before(start) {
+		drop++
+	}
+	m.trendSamples = m.trendSamples[drop:]
+}
+
+// renderSpendTrend draws the spend of the recent window as a one-line
+// sparkline, highlighting the busiest bucket.
+func (m *Model) renderSpendTrend() []string {
+	window := m.spendTrendWindow()
+	title := titleStyle.Render(i18n.Tf("消费趋势（近 %s）", describeWindow(window)))
+	hintStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	if len(m.trendSamples) < 2 {
+		return []string{title, hintStyle.Render(i18n.T("  正在采集数据，刷新几次后显示"))}
+	}
+
+	buckets := history.SpendTrend(m.trendSamples, window, trendBuckets, time.Now())
+	total, peak := 0.0, 0.0
+	for _, b := range buckets {
+		total += b.Amount
+		peak = max(peak, b.Amount)
+	}
+
+	barStyle := lipgloss.NewStyle().Foreground(primaryColor)
+	peakStyle := lipgloss.NewStyle().Foreground(warningColor)
+	var spark strings.Builder
+	for _, b := range buckets {
+		switch {
+		case !b.Sampled:
+			spark.WriteString(hintStyle.Render(glyphs.NoSample))
+		case peak == 0:
+			spark.WriteString(barStyle.Render(glyphs.Bars[1]))
+		case b.Amount == peak:
+			spark.WriteString(peakStyle.Render(glyphs.Bars[8]))
+		default:
+			// 最低一级也保留可见的底线，便于和未采样区分
+			spark.WriteString(barStyle.Render(glyphs.Bars[1+int(b.Amount/peak*7+0.5)]))
+		}
+	}
+
+	width := window / trendBuckets
+	left := describeWindow(window) + i18n.T("前")
+	gap := max(trendBuckets-lipgloss.Width(left)-lipgloss.Width(i18n.T("现在")), 1)
+	return []string{
+		title,
+		i18n.Tf("  %s  合计 %s · 峰值 %s / %s", spark.String(), format.Money(total), format.Money(peak), describeWindow(width)),
+		hintStyle.Render("  " + left + strings.Repeat(" ", gap) + i18n.T("现在")),
+	}
+}
+
+// describeWindow renders a duration in whole hours or minutes.
+func describeWindow(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return i18n.Tf("%d 小时", d/time.Hour)
+	}
+	if d >= time.Minute && d%time.Minute == 0 {
+		return i18n.Tf("%d 分钟", d/time.Minute)
+	}
+	if d >= time.Minute {
+		return i18n.Tf("%s 分钟", format.Number(d.Minutes(), 1))
+	}
+	return d.String()
+}
+
+func loadTrendCmd(store *history.Store, account string, since time.Time) tea.Cmd {
+	return func() tea.Msg {
+		samples, err := store.Load(account, since)
+		if err != nil {
+			// 读取失败时仅使用本次会话的采样，记录失败会由 sampleFailedMsg 提示
+			return trendLoadedMsg{}
+		}
+		return trendLoadedMsg{samples: samples}
+	}
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"yescode-tui/internal/i18n"
+)
+
+// maxUndoDepth bounds how many local UI changes can be undone.
+const maxUndoDepth = 50
+
+// uiCommand is one reversible change to local UI preferences. Server
+// mutations never go through the undo stack.
+type uiCommand struct {
+	label  string
+	apply  func(m *Model) tea.Cmd
+	revert func(m *Model) tea.Cmd
+}
+
+// undoStack holds the applied commands and the ones undone since.
+type undoStack struct {
+	done   []uiCommand
+	undone []uiCommand
+}
+
+// execute applies cmd and records it for undo, discarding the redo history.
+func (m *Model) execute(cmd uiCommand) tea.Cmd {
+	m.undo.done = append(m.undo.done, cmd)
+	if len(m.undo.done) > maxUndoDepth {
+		m.undo.done = m.undo.done[1:]
+	}
+	m.undo.undone = nil
+	m.status = cmd.label
+	return tea.Batch(cmd.apply(m), clearStatusAfter(statusClearDelay))
+}
+
+// undoUI reverts the most recent local UI change.
+func (m *Model) undoUI() tea.Cmd {
+	if len(m.undo.done) == 0 {
+		m.status = i18n.T("没有可撤销的操作")
+		return clearStatusAfter(statusClearDelay)
+	}
+	cmd := m.undo.done[len(m.undo.done)-1]
+	m.undo.done = m.undo.done[:len(m.undo.done)-1]
+	m.undo.undone = append(m.undo.undone, cmd)
+	m.status = i18n.Tf("已撤销：%s", cmd.label)
+	return tea.Batch(cmd.revert(m), clearStatusAfter(statusClearDelay))
+}
+
+// redoUI re-applies the most recently undone change.
+func (m *Model) redoUI() tea.Cmd {
+	if len(m.undo.undone) == 0 {
+		m.status = i18n.T("没有可重做的操作")
+		return clearStatusAfter(statusClearDelay)
+	}
+	cmd := m.undo.undone[len(m.undo.undone)-1]
+	m.undo.undone = m.undo.undone[:len(m.undo.undone)-1]
+	m.undo.done = append(m.undo.done, cmd)
+	m.status = i18n.Tf("已重做：%s", cmd.label)
+	return tea.Batch(cmd.apply(m), clearStatusAfter(statusClearDelay))
+}